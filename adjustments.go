@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultAdjustmentReasons は ADJUSTMENT_REASONS が未設定の場合に使う理由コードです。
+const defaultAdjustmentReasons = "damage,loss,sample,return,count_correction"
+
+// AdjustmentRequest は PATCH /stocks/:name のリクエストボディです。
+type AdjustmentRequest struct {
+	Delta         int    `json:"delta"`
+	Reason        string `json:"reason"`
+	AllowNegative bool   `json:"allow_negative"`
+}
+
+// AdjustmentResult は在庫調整の結果のレスポンスです。
+type AdjustmentResult struct {
+	Name           string `json:"name"`
+	Delta          int    `json:"delta"`
+	Reason         string `json:"reason"`
+	PreviousAmount int    `json:"previous_amount"`
+	Amount         int    `json:"amount"`
+}
+
+// adjustmentReasons は使用できる理由コードを環境変数 ADJUSTMENT_REASONS（カンマ区切り）から読み込みます。
+func adjustmentReasons() []string {
+	var reasons []string
+	for _, reason := range strings.Split(getEnv("ADJUSTMENT_REASONS", defaultAdjustmentReasons), ",") {
+		if reason = strings.TrimSpace(reason); reason != "" {
+			reasons = append(reasons, reason)
+		}
+	}
+	return reasons
+}
+
+// isAdjustmentReason は理由コードが設定されたリストに含まれるかを返します。
+func isAdjustmentReason(reason string) bool {
+	for _, r := range adjustmentReasons() {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// adjustStockHandler は PATCH /stocks/:name のリクエストを処理します。
+// 破損・紛失・サンプル使用などによる在庫数の増減を、理由コードとともに記録します。
+func adjustStockHandler(stocks StockRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		name := c.Param("name")
+
+		var req AdjustmentRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+		if req.Delta == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Delta must not be 0"})
+			return
+		}
+		if req.Reason == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Reason is required"})
+			return
+		}
+		if !isAdjustmentReason(req.Reason) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown reason", "reasons": adjustmentReasons()})
+			return
+		}
+
+		result, version, err := stocks.Adjust(ctx, name, req, ifMatchFromContext(c), movementMetaFromContext(c, req.Reason))
+		switch {
+		case errors.Is(err, errStockNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, errInsufficientStock):
+			// 在庫数が負になる場合は現在の在庫数を返す
+			c.JSON(http.StatusConflict, gin.H{
+				"error":  err.Error(),
+				"name":   name,
+				"amount": result.PreviousAmount,
+				"delta":  req.Delta,
+			})
+			return
+		case errors.Is(err, errPreconditionFailed):
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			writeServerError(c, err)
+			return
+		}
+
+		c.Header("ETag", stockETag(version))
+		c.JSON(http.StatusOK, result)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdjustStockHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name         string
+		requestBody  string
+		reasonsEnv   string
+		mockSetup    func(mock sqlmock.Sqlmock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:        "破損による減算",
+			requestBody: `{"delta":-3,"reason":"damage"}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockStock(mock, "apple", 10, 0)
+				mock.ExpectExec("UPDATE stocks SET amount = \\?, version = version \\+ 1 WHERE name = \\?").
+					WithArgs(7, "apple").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRecordMovement(mock, "apple", -3, 7, "damage")
+				expectThresholds(mock, "apple", nil, nil)
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"name":"apple","delta":-3,"reason":"damage","previous_amount":10,"amount":7}`,
+		},
+		{
+			name:        "在庫数が負になる場合は409",
+			requestBody: `{"delta":-11,"reason":"loss"}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockStock(mock, "apple", 10, 0)
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: `"amount":10`,
+		},
+		{
+			name:        "allow_negativeを指定すると負の在庫数を許可する",
+			requestBody: `{"delta":-11,"reason":"count_correction","allow_negative":true}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockStock(mock, "apple", 10, 0)
+				mock.ExpectExec("UPDATE stocks SET amount = \\?, version = version \\+ 1 WHERE name = \\?").
+					WithArgs(-1, "apple").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRecordMovement(mock, "apple", -11, -1, "count_correction")
+				expectThresholds(mock, "apple", nil, nil)
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"previous_amount":10,"amount":-1`,
+		},
+		{
+			name:         "設定にない理由コードは400",
+			requestBody:  `{"delta":1,"reason":"return"}`,
+			reasonsEnv:   "damage, loss",
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `"reasons":["damage","loss"]`,
+		},
+		{
+			name:         "deltaが0の場合は400",
+			requestBody:  `{"delta":0,"reason":"damage"}`,
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "Delta must not be 0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("ADJUSTMENT_REASONS", tc.reasonsEnv)
+
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			router := gin.Default()
+			router.PATCH("/stocks/:name", adjustStockHandler(newMySQLStockRepository(&SQLDB{DB: db})))
+
+			req, _ := http.NewRequest(http.MethodPatch, "/stocks/apple", bytes.NewBufferString(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			responseBody := w.Body.String()
+			t.Logf("テストケース: %s", tc.name)
+			t.Logf("レスポンスボディ: %s", responseBody)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Contains(t, responseBody, tc.expectedBody)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 在庫アラートの種類
+const (
+	alertReorderPoint = "reorder_point"
+	alertSafetyStock  = "safety_stock"
+)
+
+const (
+	defaultAlertLimit = 50
+	maxAlertLimit     = 200
+)
+
+var errAlertNotFound = errors.New("alert not found")
+
+// StockThresholds は在庫の発注点と安全在庫です。nil の場合は設定されていません。
+type StockThresholds struct {
+	Name         string `json:"name"`
+	ReorderPoint *int   `json:"reorder_point"`
+	SafetyStock  *int   `json:"safety_stock"`
+}
+
+// Alert は在庫数がしきい値を下回ったことを表す在庫アラートです。
+type Alert struct {
+	ID             int64      `json:"id"`
+	Name           string     `json:"name"`
+	Kind           string     `json:"kind"`
+	Threshold      int        `json:"threshold"`
+	Amount         int        `json:"amount"`
+	CreatedAt      time.Time  `json:"created_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy string     `json:"acknowledged_by,omitempty"`
+}
+
+// AlertPage は GET /alerts のレスポンスです。
+type AlertPage struct {
+	Alerts     []Alert `json:"alerts"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+// getStockThresholdsHandler は GET /stocks/:name/thresholds のリクエストを処理します。
+func getStockThresholdsHandler(stocks StockRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		thresholds, err := stocks.Thresholds(c.Request.Context(), c.Param("name"))
+		switch {
+		case errors.Is(err, errStockNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": errStockNotFound.Error()})
+			return
+		case err != nil:
+			writeServerError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, thresholds)
+	}
+}
+
+// putStockThresholdsHandler は PUT /stocks/:name/thresholds のリクエストを処理します。
+// null を指定したしきい値は解除します。
+func putStockThresholdsHandler(stocks StockRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var thresholds StockThresholds
+		if err := c.ShouldBindJSON(&thresholds); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+		thresholds.Name = c.Param("name")
+		if (thresholds.ReorderPoint != nil && *thresholds.ReorderPoint < 0) || (thresholds.SafetyStock != nil && *thresholds.SafetyStock < 0) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Thresholds must not be negative"})
+			return
+		}
+		if thresholds.ReorderPoint != nil && thresholds.SafetyStock != nil && *thresholds.SafetyStock > *thresholds.ReorderPoint {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Safety stock must not be greater than reorder point"})
+			return
+		}
+
+		err := stocks.SetThresholds(c.Request.Context(), thresholds)
+		switch {
+		case errors.Is(err, errStockNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": errStockNotFound.Error()})
+			return
+		case err != nil:
+			writeServerError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, thresholds)
+	}
+}
+
+// getStockThresholds は論理削除されていない在庫の発注点と安全在庫を取得します。
+func getStockThresholds(ctx context.Context, db Querier, name string) (StockThresholds, error) {
+	thresholds := StockThresholds{Name: name}
+	var reorderPoint, safetyStock sql.NullInt64
+	err := db.QueryRowContext(ctx, "SELECT reorder_point, safety_stock FROM stocks WHERE name = ? AND deleted_at IS NULL", name).
+		Scan(&reorderPoint, &safetyStock)
+	if errors.Is(err, sql.ErrNoRows) {
+		return StockThresholds{}, errStockNotFound
+	}
+	if err != nil {
+		return StockThresholds{}, err
+	}
+	thresholds.ReorderPoint = nullIntPtr(reorderPoint)
+	thresholds.SafetyStock = nullIntPtr(safetyStock)
+	return thresholds, nil
+}
+
+// setStockThresholds は論理削除されていない在庫の発注点と安全在庫を置き換えます。
+func setStockThresholds(ctx context.Context, db Storer, thresholds StockThresholds) error {
+	result, err := db.ExecContext(ctx, "UPDATE stocks SET reorder_point = ?, safety_stock = ? WHERE name = ? AND deleted_at IS NULL",
+		intPtrValue(thresholds.ReorderPoint), intPtrValue(thresholds.SafetyStock), thresholds.Name)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		// MySQL は値が変わらない行を更新件数に含めないため、在庫の有無を確認する
+		if _, err := getStock(ctx, db, thresholds.Name); errors.Is(err, sql.ErrNoRows) {
+			return errStockNotFound
+		}
+	}
+	return nil
+}
+
+// getAlertsHandler は GET /alerts のリクエストを処理します。
+// status は open（既定、未確認のみ）、acknowledged（確認済みのみ）、all のいずれかで、新しい順に返します。
+func getAlertsHandler(ledger StockLedger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		status := c.DefaultQuery("status", "open")
+		if status != "open" && status != "acknowledged" && status != "all" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "status must be one of open, acknowledged, all"})
+			return
+		}
+		limit, err := parseLimit(c, defaultAlertLimit, maxAlertLimit)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var beforeID int64
+		if v := c.Query("cursor"); v != "" {
+			key, err := decodeCursor(v)
+			if err == nil {
+				beforeID, err = strconv.ParseInt(key, 10, 64)
+			}
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": errInvalidCursor.Error()})
+				return
+			}
+		}
+
+		alerts, err := ledger.Alerts(ctx, status, c.Query("name"), beforeID, limit+1)
+		if err != nil {
+			writeServerError(c, err)
+			return
+		}
+
+		page := AlertPage{Alerts: alerts}
+		if len(alerts) > limit {
+			page.Alerts = alerts[:limit]
+			page.NextCursor = encodeCursor(strconv.FormatInt(alerts[limit-1].ID, 10))
+		}
+		if page.Alerts == nil {
+			page.Alerts = []Alert{}
+		}
+		c.JSON(http.StatusOK, page)
+	}
+}
+
+// acknowledgeAlertHandler は POST /alerts/:id/acknowledge のリクエストを処理します。
+// 確認済みのアラートに対しては、最初に確認したときの内容をそのまま返します。
+func acknowledgeAlertHandler(ledger StockLedger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || id <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid alert ID"})
+			return
+		}
+
+		actor := movementMetaFromContext(c, "").Actor
+		alert, err := ledger.AcknowledgeAlert(c.Request.Context(), id, actor, time.Now())
+		switch {
+		case errors.Is(err, errAlertNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			writeServerError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, alert)
+	}
+}
+
+// checkLowStock は在庫数が発注点または安全在庫を下回ったかを判定し、下回った場合は在庫アラートを記録します。
+// 在庫数を変更したのと同じトランザクション内で呼び出してください。
+func checkLowStock(ctx context.Context, tx Querier, name string, amountBefore, amountAfter int, now time.Time) error {
+	if amountAfter >= amountBefore {
+		return nil
+	}
+	var reorderPoint, safetyStock sql.NullInt64
+	err := tx.QueryRowContext(ctx, "SELECT reorder_point, safety_stock FROM stocks WHERE name = ?", name).Scan(&reorderPoint, &safetyStock)
+	if err != nil {
+		return err
+	}
+	thresholds := StockThresholds{Name: name, ReorderPoint: nullIntPtr(reorderPoint), SafetyStock: nullIntPtr(safetyStock)}
+	return recordLowStock(ctx, tx, thresholds, amountBefore, amountAfter, now)
+}
+
+// recordLowStock は在庫数が thresholds のしきい値を下回った場合に在庫アラートを記録します。
+func recordLowStock(ctx context.Context, tx Querier, thresholds StockThresholds, amountBefore, amountAfter int, now time.Time) error {
+	for _, alert := range lowStockAlerts(thresholds, amountBefore, amountAfter, now) {
+		_, err := tx.ExecContext(ctx, "INSERT INTO stock_alerts (name, kind, threshold, amount, created_at) VALUES (?, ?, ?, ?, ?)",
+			alert.Name, alert.Kind, alert.Threshold, alert.Amount, alert.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lowStockAlerts は在庫数が thresholds のしきい値を下回った場合に記録する在庫アラートを返します（ID は未設定です）。
+// しきい値より多い在庫数から、しきい値以下の在庫数に減った場合のみ返すため、減り続けても同じしきい値のアラートは 1 回です。
+func lowStockAlerts(thresholds StockThresholds, amountBefore, amountAfter int, now time.Time) []Alert {
+	var alerts []Alert
+	for _, threshold := range []struct {
+		kind  string
+		value *int
+	}{
+		{alertReorderPoint, thresholds.ReorderPoint},
+		{alertSafetyStock, thresholds.SafetyStock},
+	} {
+		if threshold.value == nil {
+			continue
+		}
+		t := *threshold.value
+		if amountBefore > t && amountAfter <= t {
+			alerts = append(alerts, Alert{Name: thresholds.Name, Kind: threshold.kind, Threshold: t, Amount: amountAfter, CreatedAt: now})
+		}
+	}
+	return alerts
+}
+
+// acknowledgeAlert は未確認の在庫アラートを確認済みにして、アラートを返します。
+func acknowledgeAlert(ctx context.Context, db Storer, id int64, actor string, now time.Time) (Alert, error) {
+	if _, err := db.ExecContext(ctx, "UPDATE stock_alerts SET acknowledged_at = ?, acknowledged_by = ? WHERE id = ? AND acknowledged_at IS NULL",
+		now, actor, id); err != nil {
+		return Alert{}, err
+	}
+	return getAlert(ctx, db, id)
+}
+
+// alertColumns は stock_alerts テーブルから読み込む列で、scanAlert の引数の順序に対応します。
+const alertColumns = "id, name, kind, threshold, amount, created_at, acknowledged_at, acknowledged_by"
+
+// getAlert は ID で在庫アラートを取得します。
+func getAlert(ctx context.Context, db Querier, id int64) (Alert, error) {
+	var alert Alert
+	err := scanAlert(db.QueryRowContext(ctx, "SELECT "+alertColumns+" FROM stock_alerts WHERE id = ?", id), &alert)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Alert{}, errAlertNotFound
+	}
+	return alert, err
+}
+
+// getAlerts は在庫アラートを新しい順に取得します。beforeID が 0 より大きい場合は、その ID より前のアラートのみを返します。
+func getAlerts(ctx context.Context, db Querier, status, name string, beforeID int64, limit int) ([]Alert, error) {
+	query := "SELECT " + alertColumns + " FROM stock_alerts WHERE 1 = 1"
+	var args []interface{}
+	switch status {
+	case "open":
+		query += " AND acknowledged_at IS NULL"
+	case "acknowledged":
+		query += " AND acknowledged_at IS NOT NULL"
+	}
+	if name != "" {
+		query += " AND name = ?"
+		args = append(args, name)
+	}
+	if beforeID > 0 {
+		query += " AND id < ?"
+		args = append(args, beforeID)
+	}
+	query += " ORDER BY id DESC LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var alerts []Alert
+	for rows.Next() {
+		var alert Alert
+		if err := scanAlert(rows, &alert); err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, rows.Err()
+}
+
+func scanAlert(row rowScanner, alert *Alert) error {
+	var (
+		acknowledgedAt sql.NullTime
+		acknowledgedBy sql.NullString
+	)
+	if err := row.Scan(&alert.ID, &alert.Name, &alert.Kind, &alert.Threshold, &alert.Amount, &alert.CreatedAt, &acknowledgedAt, &acknowledgedBy); err != nil {
+		return err
+	}
+	if acknowledgedAt.Valid {
+		alert.AcknowledgedAt = &acknowledgedAt.Time
+	}
+	alert.AcknowledgedBy = acknowledgedBy.String
+	return nil
+}
+
+// nullIntPtr は NULL を許す整数列の値を、JSON で null を返すためのポインタに変換します。
+func nullIntPtr(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	n := int(v.Int64)
+	return &n
+}
+
+// intPtrValue は nil を NULL として保存するための値に変換します。
+func intPtrValue(v *int) sql.NullInt64 {
+	if v == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*v), Valid: true}
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var alertColumnNames = []string{"id", "name", "kind", "threshold", "amount", "created_at", "acknowledged_at", "acknowledged_by"}
+
+func TestCheckLowStock(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		before       int
+		after        int
+		reorderPoint interface{}
+		safetyStock  interface{}
+		expected     []string
+	}{
+		{name: "発注点を下回るとアラートを記録する", before: 12, after: 10, reorderPoint: 10, safetyStock: 3, expected: []string{alertReorderPoint}},
+		{name: "発注点と安全在庫を一度に下回る", before: 12, after: 2, reorderPoint: 10, safetyStock: 3, expected: []string{alertReorderPoint, alertSafetyStock}},
+		{name: "既に発注点以下の場合は記録しない", before: 9, after: 5, reorderPoint: 10, safetyStock: 3},
+		{name: "しきい値が設定されていない", before: 12, after: 0, reorderPoint: nil, safetyStock: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+
+			expectThresholds(mock, "apple", tc.reorderPoint, tc.safetyStock)
+			for _, kind := range tc.expected {
+				threshold := tc.reorderPoint
+				if kind == alertSafetyStock {
+					threshold = tc.safetyStock
+				}
+				mock.ExpectExec("INSERT INTO stock_alerts").
+					WithArgs("apple", kind, threshold, tc.after, now).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+
+			assert.NoError(t, checkLowStock(context.Background(), db, "apple", tc.before, tc.after, now))
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+
+	t.Run("在庫数が増えた場合はクエリを発行しない", func(t *testing.T) {
+		db, mock := NewMockDB(t)
+		defer db.Close()
+
+		assert.NoError(t, checkLowStock(context.Background(), db, "apple", 5, 8, now))
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("未処理の期待値があります: %s", err)
+		}
+	})
+}
+
+func TestAlertHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		method       string
+		path         string
+		requestBody  string
+		mockSetup    func(mock sqlmock.Sqlmock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:   "しきい値を取得する",
+			method: http.MethodGet,
+			path:   "/v1/stocks/apple/thresholds",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT reorder_point, safety_stock FROM stocks WHERE name = \\? AND deleted_at IS NULL").
+					WithArgs("apple").
+					WillReturnRows(sqlmock.NewRows([]string{"reorder_point", "safety_stock"}).AddRow(10, nil))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"name":"apple","reorder_point":10,"safety_stock":null}`,
+		},
+		{
+			name:        "しきい値を設定する",
+			method:      http.MethodPut,
+			path:        "/v1/stocks/apple/thresholds",
+			requestBody: `{"reorder_point":10,"safety_stock":3}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE stocks SET reorder_point = \\?, safety_stock = \\? WHERE name = \\? AND deleted_at IS NULL").
+					WithArgs(int64(10), int64(3), "apple").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"name":"apple","reorder_point":10,"safety_stock":3}`,
+		},
+		{
+			name:         "安全在庫が発注点より大きい場合は400",
+			method:       http.MethodPut,
+			path:         "/v1/stocks/apple/thresholds",
+			requestBody:  `{"reorder_point":3,"safety_stock":10}`,
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "Safety stock must not be greater than reorder point",
+		},
+		{
+			name:        "存在しない在庫のしきい値は設定できない",
+			method:      http.MethodPut,
+			path:        "/v1/stocks/banana/thresholds",
+			requestBody: `{"reorder_point":10}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE stocks SET reorder_point").
+					WithArgs(int64(10), nil, "banana").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s").
+					WithArgs(sqlmock.AnyArg(), "banana").
+					WillReturnRows(sqlmock.NewRows([]string{"name"}))
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "stock not found",
+		},
+		{
+			name:   "未確認のアラートを新しい順に返す",
+			method: http.MethodGet,
+			path:   "/v1/alerts?limit=1",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM stock_alerts WHERE 1 = 1 AND acknowledged_at IS NULL ORDER BY id DESC LIMIT \\?").
+					WithArgs(2).
+					WillReturnRows(sqlmock.NewRows(alertColumnNames).
+						AddRow(7, "apple", "reorder_point", 10, 9, now, nil, nil).
+						AddRow(6, "orange", "safety_stock", 3, 2, now, nil, nil))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"alerts":[{"id":7,"name":"apple","kind":"reorder_point","threshold":10,"amount":9,"created_at":"2026-01-02T03:04:05Z"}],"next_cursor":"` + encodeCursor("7") + `"}`,
+		},
+		{
+			name:   "カーソルと在庫名で絞り込む",
+			method: http.MethodGet,
+			path:   "/v1/alerts?status=all&name=apple&cursor=" + encodeCursor("7"),
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM stock_alerts WHERE 1 = 1 AND name = \\? AND id < \\? ORDER BY id DESC LIMIT \\?").
+					WithArgs("apple", int64(7), defaultAlertLimit+1).
+					WillReturnRows(sqlmock.NewRows(alertColumnNames))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"alerts":[]}`,
+		},
+		{
+			name:         "不正なstatusは400",
+			method:       http.MethodGet,
+			path:         "/v1/alerts?status=closed",
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "status must be one of open, acknowledged, all",
+		},
+		{
+			name:   "アラートを確認済みにする",
+			method: http.MethodPost,
+			path:   "/v1/alerts/7/acknowledge",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE stock_alerts SET acknowledged_at = \\?, acknowledged_by = \\? WHERE id = \\? AND acknowledged_at IS NULL").
+					WithArgs(sqlmock.AnyArg(), "anonymous", int64(7)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT (.+) FROM stock_alerts WHERE id = \\?").
+					WithArgs(int64(7)).
+					WillReturnRows(sqlmock.NewRows(alertColumnNames).
+						AddRow(7, "apple", "reorder_point", 10, 9, now, now, "anonymous"))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"acknowledged_at":"2026-01-02T03:04:05Z","acknowledged_by":"anonymous"`,
+		},
+		{
+			name:   "存在しないアラートは404",
+			method: http.MethodPost,
+			path:   "/v1/alerts/9/acknowledge",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE stock_alerts SET").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT (.+) FROM stock_alerts WHERE id = \\?").
+					WithArgs(int64(9)).
+					WillReturnRows(sqlmock.NewRows(alertColumnNames))
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "alert not found",
+		},
+		{
+			name:        "引き当てで発注点を下回るとアラートを記録する",
+			method:      http.MethodPost,
+			path:        "/v1/stocks/apple/allocate",
+			requestBody: `{"amount":3}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE stocks SET amount = amount - \\?").
+					WithArgs(3, "apple", sqlmock.AnyArg(), 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectCurrentAmount(mock, "apple", 9)
+				expectRecordMovement(mock, "apple", -3, 9, "allocation")
+				expectThresholds(mock, "apple", 10, nil)
+				mock.ExpectExec("INSERT INTO stock_alerts").
+					WithArgs("apple", alertReorderPoint, 10, 9, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s (.+) WHERE s.name = \\?").
+					WithArgs(sqlmock.AnyArg(), "apple").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}).
+						AddRow("apple", 9, 0, 2, nil, nil))
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"amount":9`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			router := gin.New()
+			require.NoError(t, setupRoutes(router, &SQLDB{DB: db}))
+
+			req, _ := http.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			responseBody := w.Body.String()
+			t.Logf("テストケース: %s", tc.name)
+			t.Logf("レスポンスボディ: %s", responseBody)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Contains(t, responseBody, tc.expectedBody)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errStockNotFound     = errors.New("stock not found")
+	errStockExists       = errors.New("stock already exists")
+	errStockDeleted      = errors.New("stock is deleted")
+	errStockReserved     = errors.New("stock has active reservations")
+	errInsufficientStock = errors.New("insufficient stock")
+)
+
+// AllocationRequest は引き当てリクエストのボディです。
+type AllocationRequest struct {
+	Amount int `json:"amount"`
+}
+
+// AllocationResult は引き当て結果のレスポンスです。
+type AllocationResult struct {
+	Name      string `json:"name"`
+	Allocated int    `json:"allocated"`
+	Amount    int    `json:"amount"`
+	Available int    `json:"available"`
+}
+
+// allocateStockHandler は POST /stocks/:name/allocate のリクエストを処理します。
+func allocateStockHandler(stocks StockRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		name := c.Param("name")
+
+		var req AllocationRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+
+		if req.Amount <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must be greater than 0"})
+			return
+		}
+
+		stock, err := stocks.Allocate(ctx, name, req.Amount, ifMatchFromContext(c), movementMetaFromContext(c, movementAllocation))
+		switch {
+		case errors.Is(err, errPreconditionFailed):
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, errStockNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, errInsufficientStock):
+			// 在庫不足の場合は現在の在庫数を返す
+			c.JSON(http.StatusConflict, gin.H{
+				"error":     err.Error(),
+				"name":      name,
+				"requested": req.Amount,
+				"available": stock.Available,
+			})
+			return
+		case err != nil:
+			writeServerError(c, err)
+			return
+		}
+
+		c.Header("ETag", stockETag(stock.Version))
+		c.JSON(http.StatusOK, AllocationResult{
+			Name:      stock.Name,
+			Allocated: req.Amount,
+			Amount:    stock.Amount,
+			Available: stock.Available,
+		})
+	}
+}
+
+// allocateStock は在庫を引き当て、同じトランザクションで在庫移動を記録します。
+// 引当可能数（在庫数 - 有効な引当予約数）のチェックと減算を 1 つの UPDATE 文で行うため、
+// 複数の Lambda から同時に呼び出されても在庫数が負になることはありません。
+// match が指定された場合は、在庫行のバージョンが一致しなければ errPreconditionFailed を返します。
+func allocateStock(ctx context.Context, db Storer, name string, amount int, match *versionMatch, meta MovementMeta) (Stock, error) {
+	now := time.Now()
+	var (
+		stock    Stock
+		affected int64
+	)
+	err := db.WithTx(ctx, func(tx Querier) error {
+		if err := checkVersion(ctx, tx, name, match); err != nil {
+			return err
+		}
+		result, err := tx.ExecContext(ctx, "UPDATE stocks SET amount = amount - ?, version = version + 1 WHERE name = ? AND deleted_at IS NULL AND amount - ("+reservedSubquery+") >= ?",
+			amount, name, now, amount)
+		if err != nil {
+			return err
+		}
+		affected, err = result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected > 0 {
+			amountAfter, err := currentAmount(ctx, tx, name)
+			if err != nil {
+				return err
+			}
+			if err := recordMovement(ctx, tx, name, -amount, amountAfter, meta, now); err != nil {
+				return err
+			}
+			if err := checkLowStock(ctx, tx, name, amountAfter+amount, amountAfter, now); err != nil {
+				return err
+			}
+		}
+
+		// 引き当て後の在庫は同じトランザクション内で読み込むため、他の更新の影響を受けません
+		stock, err = getStock(ctx, tx, name)
+		if errors.Is(err, sql.ErrNoRows) {
+			return errStockNotFound
+		}
+		return err
+	})
+	if err != nil {
+		return Stock{}, err
+	}
+
+	if affected == 0 {
+		return stock, errInsufficientStock
+	}
+	return stock, nil
+}
+
+// lockStock はトランザクション内で在庫行を FOR UPDATE でロックし、
+// 在庫数・引当予約数・引当可能数を返します。論理削除された在庫は存在しないものとして扱います。
+// 在庫行のロックにより、同じ在庫への予約・引き当ては直列化されます。
+func lockStock(ctx context.Context, tx Querier, name string, now time.Time) (Stock, error) {
+	stock := Stock{Name: name}
+	err := tx.QueryRowContext(ctx, "SELECT amount, version FROM stocks WHERE name = ? AND deleted_at IS NULL FOR UPDATE", name).Scan(&stock.Amount, &stock.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Stock{}, fmt.Errorf("%w: %s", errStockNotFound, name)
+	}
+	if err != nil {
+		return Stock{}, err
+	}
+
+	err = tx.QueryRowContext(ctx, "SELECT COALESCE(SUM(amount), 0) FROM stock_reservations WHERE name = ? AND status = 'active' AND expires_at > ?",
+		name, now).Scan(&stock.Reserved)
+	if err != nil {
+		return Stock{}, err
+	}
+	stock.Available = stock.Amount - stock.Reserved
+	return stock, nil
+}
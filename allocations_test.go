@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocateStockHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name         string
+		stockName    string
+		requestBody  string
+		mockSetup    func(mock sqlmock.Sqlmock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:        "正常な引き当て",
+			stockName:   "apple",
+			requestBody: `{"amount":3}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE stocks SET amount = amount - \\?, version = version \\+ 1 WHERE name = \\? AND deleted_at IS NULL AND amount - \\((.+)\\) >= \\?").
+					WithArgs(3, "apple", sqlmock.AnyArg(), 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectCurrentAmount(mock, "apple", 7)
+				expectRecordMovement(mock, "apple", -3, 7, "allocation")
+				expectThresholds(mock, "apple", nil, nil)
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s (.+) WHERE s.name = \\?").
+					WithArgs(sqlmock.AnyArg(), "apple").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}).
+						AddRow("apple", 7, 0, 1, nil, nil))
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"name":"apple","allocated":3,"amount":7,"available":7}`,
+		},
+		{
+			name:        "在庫不足の場合は409と現在の在庫数を返す",
+			stockName:   "apple",
+			requestBody: `{"amount":20}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE stocks SET amount = amount - \\?, version = version \\+ 1 WHERE name = \\? AND deleted_at IS NULL AND amount - \\((.+)\\) >= \\?").
+					WithArgs(20, "apple", sqlmock.AnyArg(), 20).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s (.+) WHERE s.name = \\?").
+					WithArgs(sqlmock.AnyArg(), "apple").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}).
+						AddRow("apple", 7, 2, 1, nil, nil))
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: `"available":5`,
+		},
+		{
+			name:        "存在しない在庫の場合は404",
+			stockName:   "grape",
+			requestBody: `{"amount":1}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE stocks SET amount = amount - \\?, version = version \\+ 1 WHERE name = \\? AND deleted_at IS NULL AND amount - \\((.+)\\) >= \\?").
+					WithArgs(1, "grape", sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s (.+) WHERE s.name = \\?").
+					WithArgs(sqlmock.AnyArg(), "grape").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}))
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "stock not found",
+		},
+		{
+			name:         "数量が0以下の場合は400",
+			stockName:    "apple",
+			requestBody:  `{"amount":0}`,
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "Amount must be greater than 0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// モックデータベースのセットアップ
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			// モックStorerを作成
+			mockStorer := &SQLDB{DB: db}
+
+			// モックの設定
+			tc.mockSetup(mock)
+
+			router := gin.Default()
+			router.POST("/stocks/:name/allocate", allocateStockHandler(newMySQLStockRepository(mockStorer)))
+
+			body := bytes.NewBufferString(tc.requestBody)
+			req, _ := http.NewRequest(http.MethodPost, "/stocks/"+tc.stockName+"/allocate", body)
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// レスポンスの詳細をログに記録
+			responseBody := w.Body.String()
+			t.Logf("テストケース: %s", tc.name)
+			t.Logf("レスポンスボディ: %s", responseBody)
+
+			// ステータスコードの検証
+			assert.Equal(t, tc.expectedCode, w.Code)
+
+			// レスポンスボディの検証
+			assert.Contains(t, responseBody, tc.expectedBody)
+
+			// モック期待値の確認
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
@@ -15,12 +15,209 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gin-gonic/gin"
 	"github.com/oapi-codegen/runtime"
 )
 
+// Defines values for AlertKind.
+const (
+	ReorderPoint AlertKind = "reorder_point"
+	SafetyStock  AlertKind = "safety_stock"
+)
+
+// Defines values for ImportResultMode.
+const (
+	ImportResultModeAdd ImportResultMode = "add"
+	ImportResultModeSet ImportResultMode = "set"
+)
+
+// Defines values for ImportRowAction.
+const (
+	Create    ImportRowAction = "create"
+	Unchanged ImportRowAction = "unchanged"
+	Update    ImportRowAction = "update"
+)
+
+// Defines values for ReservationStatus.
+const (
+	Active    ReservationStatus = "active"
+	Committed ReservationStatus = "committed"
+	Expired   ReservationStatus = "expired"
+	Released  ReservationStatus = "released"
+)
+
+// Defines values for StockEventType.
+const (
+	StockEventTypeStockChanged  StockEventType = "stock.changed"
+	StockEventTypeStockDeleted  StockEventType = "stock.deleted"
+	StockEventTypeStockRestored StockEventType = "stock.restored"
+)
+
+// Defines values for WebhookEventTypes.
+const (
+	WebhookEventTypesStockChanged  WebhookEventTypes = "stock.changed"
+	WebhookEventTypesStockDeleted  WebhookEventTypes = "stock.deleted"
+	WebhookEventTypesStockRestored WebhookEventTypes = "stock.restored"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	Failed    WebhookDeliveryStatus = "failed"
+	Pending   WebhookDeliveryStatus = "pending"
+	Succeeded WebhookDeliveryStatus = "succeeded"
+)
+
+// Defines values for GetAlertsParamsStatus.
+const (
+	Acknowledged GetAlertsParamsStatus = "acknowledged"
+	All          GetAlertsParamsStatus = "all"
+	Open         GetAlertsParamsStatus = "open"
+)
+
+// Defines values for GetAllStocksParamsSort.
+const (
+	Amount         GetAllStocksParamsSort = "amount"
+	MinusAmount    GetAllStocksParamsSort = "-amount"
+	MinusName      GetAllStocksParamsSort = "-name"
+	MinusUpdatedAt GetAllStocksParamsSort = "-updated_at"
+	Name           GetAllStocksParamsSort = "name"
+	UpdatedAt      GetAllStocksParamsSort = "updated_at"
+)
+
+// Defines values for ImportStocksParamsMode.
+const (
+	ImportStocksParamsModeAdd ImportStocksParamsMode = "add"
+	ImportStocksParamsModeSet ImportStocksParamsMode = "set"
+)
+
+// AdjustmentRequest defines model for AdjustmentRequest.
+type AdjustmentRequest struct {
+	// AllowNegative true の場合、調整後の在庫数が負になることを許可します
+	AllowNegative *bool `json:"allow_negative,omitempty"`
+
+	// Delta 在庫数の増減（0 以外）
+	Delta int `json:"delta"`
+
+	// Reason 理由コード（ADJUSTMENT_REASONS で設定されたもの）
+	Reason string `json:"reason"`
+}
+
+// AdjustmentResult defines model for AdjustmentResult.
+type AdjustmentResult struct {
+	// Amount 調整後の在庫数
+	Amount int `json:"amount"`
+
+	// Delta 適用した増減
+	Delta int `json:"delta"`
+
+	// Name 在庫の名前
+	Name string `json:"name"`
+
+	// PreviousAmount 調整前の在庫数
+	PreviousAmount int `json:"previous_amount"`
+
+	// Reason 理由コード
+	Reason string `json:"reason"`
+}
+
+// Alert defines model for Alert.
+type Alert struct {
+	// AcknowledgedAt 確認した日時（未確認の場合は省略）
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+
+	// AcknowledgedBy 確認した操作者（未確認の場合は省略）
+	AcknowledgedBy *string `json:"acknowledged_by,omitempty"`
+
+	// Amount アラートを記録したときの在庫数
+	Amount    int       `json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
+	Id        int64     `json:"id"`
+
+	// Kind 下回ったしきい値の種類
+	Kind AlertKind `json:"kind"`
+
+	// Name 在庫の名前
+	Name string `json:"name"`
+
+	// Threshold アラートを記録したときのしきい値
+	Threshold int `json:"threshold"`
+}
+
+// AlertKind 下回ったしきい値の種類
+type AlertKind string
+
+// AlertPage defines model for AlertPage.
+type AlertPage struct {
+	Alerts []Alert `json:"alerts"`
+
+	// NextCursor 次のページのカーソル（最後のページでは省略）
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// AllocationRequest defines model for AllocationRequest.
+type AllocationRequest struct {
+	// Amount 引き当てる数量
+	Amount int `json:"amount"`
+}
+
+// AllocationResult defines model for AllocationResult.
+type AllocationResult struct {
+	// Allocated 引き当てた数量
+	Allocated int `json:"allocated"`
+
+	// Amount 引き当て後の在庫の数量
+	Amount int `json:"amount"`
+
+	// Available 引き当て後の引当可能な数量
+	Available int `json:"available"`
+
+	// Name 在庫の名前
+	Name string `json:"name"`
+}
+
+// BarcodeErrorResponse defines model for BarcodeErrorResponse.
+type BarcodeErrorResponse struct {
+	// Code 正規化したバーコード（400 の場合は指定された値）
+	Code  string `json:"code"`
+	Error string `json:"error"`
+
+	// ProductId バーコードに一致した商品の ID（商品が見つかった場合のみ）
+	ProductId *int64 `json:"product_id,omitempty"`
+}
+
+// BatchFailureResponse defines model for BatchFailureResponse.
+type BatchFailureResponse struct {
+	Error   string             `json:"error"`
+	Results *[]BatchItemResult `json:"results,omitempty"`
+}
+
+// BatchItemResult defines model for BatchItemResult.
+type BatchItemResult struct {
+	// Amount 加算後の在庫数（成功した場合のみ）
+	Amount *int `json:"amount,omitempty"`
+
+	// Error 失敗した場合のエラー
+	Error *string `json:"error,omitempty"`
+
+	// Index リクエストの配列内の位置
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+
+	// Status 在庫ごとの結果（200 成功、400 検証エラー、409 論理削除済み、424 ロールバックにより未反映、500 サーバーエラー）
+	Status int `json:"status"`
+}
+
+// BatchResult defines model for BatchResult.
+type BatchResult struct {
+	Atomic    bool              `json:"atomic"`
+	Failed    int               `json:"failed"`
+	Results   []BatchItemResult `json:"results"`
+	Succeeded int               `json:"succeeded"`
+}
+
 // EmptyDataResponse defines model for EmptyDataResponse.
 type EmptyDataResponse struct {
 	Message *string `json:"message,omitempty"`
@@ -32,562 +229,9270 @@ type ErrorResponse struct {
 	Error string `json:"error"`
 }
 
-// Stock defines model for Stock.
-type Stock struct {
-	// Amount 在庫の数量
-	Amount *int `json:"amount,omitempty"`
+// ImportError defines model for ImportError.
+type ImportError struct {
+	Error string  `json:"error"`
+	Line  int     `json:"line"`
+	Name  *string `json:"name,omitempty"`
+}
+
+// ImportResult defines model for ImportResult.
+type ImportResult struct {
+	Created *int  `json:"created,omitempty"`
+	DryRun  *bool `json:"dry_run,omitempty"`
+
+	// Error CSV 全体を読み込めない場合のエラー
+	Error     *string           `json:"error,omitempty"`
+	Errors    *[]ImportError    `json:"errors,omitempty"`
+	Mode      *ImportResultMode `json:"mode,omitempty"`
+	Rows      *[]ImportRow      `json:"rows,omitempty"`
+	Unchanged *int              `json:"unchanged,omitempty"`
+	Updated   *int              `json:"updated,omitempty"`
+}
+
+// ImportResultMode defines model for ImportResult.Mode.
+type ImportResultMode string
+
+// ImportRow defines model for ImportRow.
+type ImportRow struct {
+	Action ImportRowAction `json:"action"`
+
+	// Amount 取り込み後の在庫数
+	Amount int `json:"amount"`
+
+	// Line CSV の行番号（ヘッダー行が 1）
+	Line           int    `json:"line"`
+	Name           string `json:"name"`
+	PreviousAmount int    `json:"previous_amount"`
+}
+
+// ImportRowAction defines model for ImportRow.Action.
+type ImportRowAction string
+
+// InsufficientStockResponse defines model for InsufficientStockResponse.
+type InsufficientStockResponse struct {
+	// Available 現在の引当可能な数量
+	Available int `json:"available"`
+
+	// Error エラーメッセージ
+	Error string `json:"error"`
+
+	// Name 在庫の名前
+	Name string `json:"name"`
+
+	// Requested 要求された数量
+	Requested int `json:"requested"`
+}
+
+// Location defines model for Location.
+type Location struct {
+	// Code ロケーションのコード（英数字、'-'、'_' の 64 文字以内）
+	Code      string     `json:"code"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Name 表示名（省略時はコード）
+	Name *string `json:"name,omitempty"`
+}
+
+// LocationList defines model for LocationList.
+type LocationList struct {
+	Locations []Location `json:"locations"`
+}
+
+// Movement defines model for Movement.
+type Movement struct {
+	// Actor 変更した操作者（X-Actor ヘッダー）
+	Actor string `json:"actor"`
+
+	// AmountAfter 変更後の在庫数
+	AmountAfter int `json:"amount_after"`
+
+	// CreatedAt 変更日時
+	CreatedAt time.Time `json:"created_at"`
+
+	// Delta 在庫数の増減
+	Delta int `json:"delta"`
+
+	// Id 在庫移動 ID
+	Id int64 `json:"id"`
+
+	// Location ロケーションのコード
+	Location *string `json:"location,omitempty"`
+
+	// Name 在庫の名前
+	Name string `json:"name"`
+
+	// Reason 変更理由。receipt, stocktake, allocation, reservation_commit, order, delete, restore、
+	// または PATCH で指定した調整の理由コード
+	Reason string `json:"reason"`
+
+	// RequestId 変更したリクエストの ID
+	RequestId string `json:"request_id"`
+}
+
+// MovementHistory defines model for MovementHistory.
+type MovementHistory struct {
+	Movements []Movement `json:"movements"`
+
+	// Name 在庫の名前
+	Name string `json:"name"`
+
+	// NextCursor 次のページのカーソル。最後のページでは省略されます
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// Order defines model for Order.
+type Order struct {
+	// CreatedAt 作成日時
+	CreatedAt time.Time `json:"created_at"`
+
+	// Id 注文 ID
+	Id    string      `json:"id"`
+	Lines []OrderLine `json:"lines"`
+}
+
+// OrderLine defines model for OrderLine.
+type OrderLine struct {
+	// Amount 数量
+	Amount int `json:"amount"`
+
+	// Name 在庫の名前
+	Name string `json:"name"`
+}
+
+// OrderRequest defines model for OrderRequest.
+type OrderRequest struct {
+	// Lines 明細行。同じ在庫の行は合算されます
+	Lines []OrderLine `json:"lines"`
+}
+
+// OrderShortageResponse defines model for OrderShortageResponse.
+type OrderShortageResponse struct {
+	// Error エラーメッセージ
+	Error     string     `json:"error"`
+	Shortages []Shortage `json:"shortages"`
+}
+
+// Product defines model for Product.
+type Product struct {
+	// Attributes 任意の属性
+	Attributes *map[string]interface{} `json:"attributes,omitempty"`
+
+	// Barcode JAN/EAN-13、EAN-8 または UPC-A のコード。チェックデジットを検証し、UPC-A は EAN-13 に変換して保存します
+	Barcode   *string    `json:"barcode,omitempty"`
+	Category  *string    `json:"category,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// DisplayName 表示名
+	DisplayName string `json:"display_name"`
+	Id          *int64 `json:"id,omitempty"`
+	Sku         string `json:"sku"`
+
+	// Unit 単位（省略時は pcs）
+	Unit      *string    `json:"unit,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// ProductPage defines model for ProductPage.
+type ProductPage struct {
+	// NextCursor 次のページのカーソル（最後のページでは省略）
+	NextCursor *string   `json:"next_cursor,omitempty"`
+	Products   []Product `json:"products"`
+}
+
+// ProductUpdate defines model for ProductUpdate.
+type ProductUpdate struct {
+	Attributes  *map[string]interface{} `json:"attributes,omitempty"`
+	Barcode     *string                 `json:"barcode,omitempty"`
+	Category    *string                 `json:"category,omitempty"`
+	DisplayName *string                 `json:"display_name,omitempty"`
+	Sku         *string                 `json:"sku,omitempty"`
+	Unit        *string                 `json:"unit,omitempty"`
+}
+
+// RelayResult defines model for RelayResult.
+type RelayResult struct {
+	// Error 配信に失敗した場合のエラー
+	Error *string `json:"error,omitempty"`
+
+	// Published 配信したイベントの件数
+	Published int `json:"published"`
+}
+
+// Reservation defines model for Reservation.
+type Reservation struct {
+	// Amount 予約数量
+	Amount int `json:"amount"`
+
+	// CreatedAt 作成日時
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExpiresAt 有効期限
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Id 予約 ID
+	Id string `json:"id"`
+
+	// Name 在庫の名前
+	Name string `json:"name"`
+
+	// Status 予約のステータス
+	Status ReservationStatus `json:"status"`
+}
+
+// ReservationStatus 予約のステータス
+type ReservationStatus string
+
+// ReservationConflictResponse defines model for ReservationConflictResponse.
+type ReservationConflictResponse struct {
+	// Error エラーメッセージ
+	Error string `json:"error"`
+
+	// Status 予約の現在のステータス
+	Status *string `json:"status,omitempty"`
+}
+
+// ReservationRequest defines model for ReservationRequest.
+type ReservationRequest struct {
+	// Amount 予約する数量
+	Amount int `json:"amount"`
+
+	// TtlSeconds 予約の有効期間（秒）。省略時は 900 秒
+	TtlSeconds *int `json:"ttl_seconds,omitempty"`
+}
+
+// SetStockRequest defines model for SetStockRequest.
+type SetStockRequest struct {
+	// Amount 設定する在庫数
+	Amount int `json:"amount"`
+}
+
+// Shortage defines model for Shortage.
+type Shortage struct {
+	// Available 現在の引当可能な数量
+	Available int `json:"available"`
+
+	// Name 在庫の名前
+	Name string `json:"name"`
+
+	// Requested 要求された数量
+	Requested int `json:"requested"`
+}
+
+// Stock defines model for Stock.
+type Stock struct {
+	// Amount 在庫の数量（手持ち数）
+	Amount *int `json:"amount,omitempty"`
+
+	// Available 引当可能な数量（amount - reserved）
+	Available *int `json:"available,omitempty"`
+
+	// DeletedAt 論理削除された日時。include_deleted=true の場合のみ含まれます
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+	// Name 在庫の名前
+	Name    string   `json:"name"`
+	Product *Product `json:"product,omitempty"`
+
+	// Reserved 有効期限内の引当予約の数量
+	Reserved *int `json:"reserved,omitempty"`
+
+	// UpdatedAt 最終更新日時
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// StockEvent Webhook で配信する在庫イベント（リクエストボディ）。在庫移動 1 件に対応します
+type StockEvent struct {
+	Data struct {
+		Actor *string `json:"actor,omitempty"`
+
+		// Amount 変更後のロケーションの在庫数
+		Amount    *int    `json:"amount,omitempty"`
+		Delta     *int    `json:"delta,omitempty"`
+		Location  *string `json:"location,omitempty"`
+		Name      *string `json:"name,omitempty"`
+		Reason    *string `json:"reason,omitempty"`
+		RequestId *string `json:"request_id,omitempty"`
+	} `json:"data"`
+
+	// Id イベント ID（X-Webhook-Delivery ヘッダーと同じ値で、再試行でも変わりません）
+	Id         string         `json:"id"`
+	OccurredAt time.Time      `json:"occurred_at"`
+	Type       StockEventType `json:"type"`
+}
+
+// StockEventType defines model for StockEvent.Type.
+type StockEventType string
+
+// StockLevel defines model for StockLevel.
+type StockLevel struct {
+	Amount    int        `json:"amount"`
+	Location  string     `json:"location"`
+	Name      string     `json:"name"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// StockLevelPage defines model for StockLevelPage.
+type StockLevelPage struct {
+	Location string `json:"location"`
+
+	// NextCursor 次のページのカーソル（最後のページでは省略）
+	NextCursor *string      `json:"next_cursor,omitempty"`
+	Stocks     []StockLevel `json:"stocks"`
+}
+
+// StockLocations defines model for StockLocations.
+type StockLocations struct {
+	Locations []StockLevel `json:"locations"`
+	Name      string       `json:"name"`
+
+	// Total 全てのロケーションの在庫数の合計
+	Total int `json:"total"`
+}
+
+// StockProductRequest defines model for StockProductRequest.
+type StockProductRequest struct {
+	ProductId int64 `json:"product_id"`
+}
+
+// StockRequest defines model for StockRequest.
+type StockRequest struct {
+	// Amount 在庫の数量
+	Amount *int `json:"amount,omitempty"`
+
+	// Name 在庫の名前
+	Name string `json:"name"`
+}
+
+// StockThresholds defines model for StockThresholds.
+type StockThresholds struct {
+	// Name 在庫の名前（リクエストでは無視されます）
+	Name *string `json:"name,omitempty"`
+
+	// ReorderPoint 発注点。在庫数がこの値以下になるとアラートを記録します
+	ReorderPoint *int `json:"reorder_point"`
+
+	// SafetyStock 安全在庫。発注点以下の値を指定します
+	SafetyStock *int `json:"safety_stock"`
+}
+
+// StocksResponse 在庫のリスト（1 ページ分）
+type StocksResponse struct {
+	// NextCursor 次のページのカーソル。最後のページでは省略されます
+	NextCursor *string `json:"next_cursor,omitempty"`
+	Stocks     []Stock `json:"stocks"`
+}
+
+// Transfer defines model for Transfer.
+type Transfer struct {
+	Amount int    `json:"amount"`
+	From   string `json:"from"`
+
+	// FromAmount 移動後の移動元の在庫数
+	FromAmount int    `json:"from_amount"`
+	Name       string `json:"name"`
+	To         string `json:"to"`
+
+	// ToAmount 移動後の移動先の在庫数
+	ToAmount int `json:"to_amount"`
+}
+
+// TransferRequest defines model for TransferRequest.
+type TransferRequest struct {
+	Amount int `json:"amount"`
+
+	// From 移動元のロケーション
+	From string `json:"from"`
+	Name string `json:"name"`
+
+	// To 移動先のロケーション
+	To string `json:"to"`
+}
+
+// Webhook defines model for Webhook.
+type Webhook struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// EventTypes 購読する在庫イベントの種類
+	EventTypes []WebhookEventTypes `json:"event_types"`
+	Id         *int64              `json:"id,omitempty"`
+
+	// Secret 署名に使う共有鍵。登録時のレスポンスでのみ返します
+	Secret *string `json:"secret,omitempty"`
+
+	// Url 配信先の https の URL。プライベート・ループバック・リンクローカルのアドレスには送信しません
+	Url string `json:"url"`
+}
+
+// WebhookEventTypes defines model for Webhook.EventTypes.
+type WebhookEventTypes string
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	// Attempts 再試行を含めた送信回数
+	Attempts  int       `json:"attempts"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Error 最後の送信のエラー（成功した場合は省略）
+	Error     *string `json:"error,omitempty"`
+	EventId   string  `json:"event_id"`
+	EventType string  `json:"event_type"`
+	Id        int64   `json:"id"`
+
+	// NextAttemptAt 次に送信する日時（pending の場合のみ）
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+
+	// Payload Webhook で配信する在庫イベント（リクエストボディ）。在庫移動 1 件に対応します
+	Payload StockEvent `json:"payload"`
+
+	// ResponseStatus 最後の送信の応答ステータス（応答がない場合は省略）
+	ResponseStatus *int `json:"response_status,omitempty"`
+
+	// Status pending は配信待ちまたは再試行待ち
+	Status    WebhookDeliveryStatus `json:"status"`
+	WebhookId int64                 `json:"webhook_id"`
+}
+
+// WebhookDeliveryStatus pending は配信待ちまたは再試行待ち
+type WebhookDeliveryStatus string
+
+// WebhookDeliveryPage defines model for WebhookDeliveryPage.
+type WebhookDeliveryPage struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+
+	// NextCursor 次のページのカーソル（最後のページでは省略）
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// WebhookList defines model for WebhookList.
+type WebhookList struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// IncludeDeleted defines model for IncludeDeleted.
+type IncludeDeleted = bool
+
+// LocationCode defines model for LocationCode.
+type LocationCode = string
+
+// ProductId defines model for ProductId.
+type ProductId = int64
+
+// ReservationId defines model for ReservationId.
+type ReservationId = string
+
+// WebhookId defines model for WebhookId.
+type WebhookId = int64
+
+// GetAlertsParams defines parameters for GetAlerts.
+type GetAlertsParams struct {
+	// Status open は未確認、acknowledged は確認済みのアラートのみを返す
+	Status *GetAlertsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Name 指定した在庫のアラートのみを返す
+	Name *string `form:"name,omitempty" json:"name,omitempty"`
+
+	// Limit 1 ページの件数
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor 前のページの next_cursor
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetAlertsParamsStatus defines parameters for GetAlerts.
+type GetAlertsParamsStatus string
+
+// GetLocationStocksParams defines parameters for GetLocationStocks.
+type GetLocationStocksParams struct {
+	// Limit 1 ページの件数
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor 前のページの next_cursor
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetProductsParams defines parameters for GetProducts.
+type GetProductsParams struct {
+	// Category 指定したカテゴリの商品のみを返す
+	Category *string `form:"category,omitempty" json:"category,omitempty"`
+
+	// Limit 1 ページの件数
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor 前のページの next_cursor
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetAllStocksParams defines parameters for GetAllStocks.
+type GetAllStocksParams struct {
+	// Prefix 名前の前方一致
+	Prefix *string `form:"prefix,omitempty" json:"prefix,omitempty"`
+
+	// Contains 名前の部分一致
+	Contains *string `form:"contains,omitempty" json:"contains,omitempty"`
+
+	// MinAmount 在庫数の下限（この値を含む）
+	MinAmount *int `form:"min_amount,omitempty" json:"min_amount,omitempty"`
+
+	// MaxAmount 在庫数の上限（この値を含む）
+	MaxAmount *int `form:"max_amount,omitempty" json:"max_amount,omitempty"`
+
+	// UpdatedSince この日時以降に更新された在庫のみを返します（RFC 3339）
+	UpdatedSince *time.Time `form:"updated_since,omitempty" json:"updated_since,omitempty"`
+
+	// Sort 並べ替えのキー。先頭に - を付けると降順になります
+	Sort *GetAllStocksParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Limit 1 ページの件数
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor 前のページの next_cursor
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// IncludeDeleted true の場合、論理削除された在庫も返します
+	IncludeDeleted *IncludeDeleted `form:"include_deleted,omitempty" json:"include_deleted,omitempty"`
+}
+
+// GetAllStocksParamsSort defines parameters for GetAllStocks.
+type GetAllStocksParamsSort string
+
+// CreateOrUpdateStockParams defines parameters for CreateOrUpdateStock.
+type CreateOrUpdateStockParams struct {
+	// IfMatch GET で取得した ETag。指定した場合、在庫が他のリクエストで変更されていれば 412 を返します
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+
+	// IdempotencyKey リトライ時に同じ値を指定するとリクエストを 1 回だけ処理します。キーは 24 時間保存されます
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// ExportStocksParams defines parameters for ExportStocks.
+type ExportStocksParams struct {
+	// IncludeDeleted true の場合、論理削除された在庫も返します
+	IncludeDeleted *IncludeDeleted `form:"include_deleted,omitempty" json:"include_deleted,omitempty"`
+}
+
+// ImportStocksParams defines parameters for ImportStocks.
+type ImportStocksParams struct {
+	// Mode add は CSV の数量を在庫数に加算し、set は CSV の数量で在庫数を上書きする
+	Mode *ImportStocksParamsMode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// DryRun true の場合は反映せずに変更内容のみを返す
+	DryRun *bool `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+}
+
+// ImportStocksParamsMode defines parameters for ImportStocks.
+type ImportStocksParamsMode string
+
+// DeleteStockParams defines parameters for DeleteStock.
+type DeleteStockParams struct {
+	// IfMatch GET で取得した ETag。指定した場合、在庫が他のリクエストで変更されていれば 412 を返します
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// GetStockByNameParams defines parameters for GetStockByName.
+type GetStockByNameParams struct {
+	// IncludeDeleted true の場合、論理削除された在庫も返します
+	IncludeDeleted *IncludeDeleted `form:"include_deleted,omitempty" json:"include_deleted,omitempty"`
+}
+
+// AdjustStockParams defines parameters for AdjustStock.
+type AdjustStockParams struct {
+	// IfMatch GET で取得した ETag。指定した場合、在庫が他のリクエストで変更されていれば 412 を返します
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// SetStockParams defines parameters for SetStock.
+type SetStockParams struct {
+	// CreateOnly true の場合、在庫が存在しないときだけ作成します
+	CreateOnly *bool `form:"create_only,omitempty" json:"create_only,omitempty"`
+
+	// IfMatch GET で取得した ETag。指定した場合、在庫が他のリクエストで変更されていれば 412 を返します
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// AllocateStockParams defines parameters for AllocateStock.
+type AllocateStockParams struct {
+	// IfMatch GET で取得した ETag。指定した場合、在庫が他のリクエストで変更されていれば 412 を返します
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// GetStockHistoryParams defines parameters for GetStockHistory.
+type GetStockHistoryParams struct {
+	// Limit 1 ページの件数
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor 前のページの next_cursor
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// RestoreStockParams defines parameters for RestoreStock.
+type RestoreStockParams struct {
+	// IfMatch GET で取得した ETag。指定した場合、在庫が他のリクエストで変更されていれば 412 を返します
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// BatchCreateOrUpdateStocksJSONBody defines parameters for BatchCreateOrUpdateStocks.
+type BatchCreateOrUpdateStocksJSONBody = []StockRequest
+
+// BatchCreateOrUpdateStocksParams defines parameters for BatchCreateOrUpdateStocks.
+type BatchCreateOrUpdateStocksParams struct {
+	// Atomic true の場合は全ての在庫を 1 つのトランザクションで加算する
+	Atomic *bool `form:"atomic,omitempty" json:"atomic,omitempty"`
+
+	// IdempotencyKey リトライ時に同じ値を指定するとリクエストを 1 回だけ処理します。キーは 24 時間保存されます
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetWebhookDeliveriesParams defines parameters for GetWebhookDeliveries.
+type GetWebhookDeliveriesParams struct {
+	// Limit 1 ページの件数
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor 前のページの next_cursor
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// CreateLocationJSONRequestBody defines body for CreateLocation for application/json ContentType.
+type CreateLocationJSONRequestBody = Location
+
+// SetStockLevelJSONRequestBody defines body for SetStockLevel for application/json ContentType.
+type SetStockLevelJSONRequestBody = SetStockRequest
+
+// CreateOrderJSONRequestBody defines body for CreateOrder for application/json ContentType.
+type CreateOrderJSONRequestBody = OrderRequest
+
+// CreateProductJSONRequestBody defines body for CreateProduct for application/json ContentType.
+type CreateProductJSONRequestBody = Product
+
+// UpdateProductJSONRequestBody defines body for UpdateProduct for application/json ContentType.
+type UpdateProductJSONRequestBody = ProductUpdate
+
+// CreateOrUpdateStockJSONRequestBody defines body for CreateOrUpdateStock for application/json ContentType.
+type CreateOrUpdateStockJSONRequestBody = StockRequest
+
+// AdjustStockJSONRequestBody defines body for AdjustStock for application/json ContentType.
+type AdjustStockJSONRequestBody = AdjustmentRequest
+
+// SetStockJSONRequestBody defines body for SetStock for application/json ContentType.
+type SetStockJSONRequestBody = SetStockRequest
+
+// AllocateStockJSONRequestBody defines body for AllocateStock for application/json ContentType.
+type AllocateStockJSONRequestBody = AllocationRequest
+
+// LinkStockProductJSONRequestBody defines body for LinkStockProduct for application/json ContentType.
+type LinkStockProductJSONRequestBody = StockProductRequest
+
+// CreateReservationJSONRequestBody defines body for CreateReservation for application/json ContentType.
+type CreateReservationJSONRequestBody = ReservationRequest
+
+// PutStockThresholdsJSONRequestBody defines body for PutStockThresholds for application/json ContentType.
+type PutStockThresholdsJSONRequestBody = StockThresholds
+
+// BatchCreateOrUpdateStocksJSONRequestBody defines body for BatchCreateOrUpdateStocks for application/json ContentType.
+type BatchCreateOrUpdateStocksJSONRequestBody = BatchCreateOrUpdateStocksJSONBody
+
+// TransferStockJSONRequestBody defines body for TransferStock for application/json ContentType.
+type TransferStockJSONRequestBody = TransferRequest
+
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = Webhook
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetAlerts request
+	GetAlerts(ctx context.Context, params *GetAlertsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AcknowledgeAlert request
+	AcknowledgeAlert(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLocations request
+	GetLocations(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateLocationWithBody request with any body
+	CreateLocationWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateLocation(ctx context.Context, body CreateLocationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLocationStocks request
+	GetLocationStocks(ctx context.Context, code LocationCode, params *GetLocationStocksParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetStockLevel request
+	GetStockLevel(ctx context.Context, code LocationCode, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetStockLevelWithBody request with any body
+	SetStockLevelWithBody(ctx context.Context, code LocationCode, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetStockLevel(ctx context.Context, code LocationCode, name string, body SetStockLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateOrderWithBody request with any body
+	CreateOrderWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateOrder(ctx context.Context, body CreateOrderJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetOrder request
+	GetOrder(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RelayOutbox request
+	RelayOutbox(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetProducts request
+	GetProducts(ctx context.Context, params *GetProductsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateProductWithBody request with any body
+	CreateProductWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateProduct(ctx context.Context, body CreateProductJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetProduct request
+	GetProduct(ctx context.Context, id ProductId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateProductWithBody request with any body
+	UpdateProductWithBody(ctx context.Context, id ProductId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateProduct(ctx context.Context, id ProductId, body UpdateProductJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetReservation request
+	GetReservation(ctx context.Context, id ReservationId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CommitReservation request
+	CommitReservation(ctx context.Context, id ReservationId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReleaseReservation request
+	ReleaseReservation(ctx context.Context, id ReservationId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAllStocks request
+	GetAllStocks(ctx context.Context, params *GetAllStocksParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateOrUpdateStockWithBody request with any body
+	CreateOrUpdateStockWithBody(ctx context.Context, params *CreateOrUpdateStockParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateOrUpdateStock(ctx context.Context, params *CreateOrUpdateStockParams, body CreateOrUpdateStockJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetStockByBarcode request
+	GetStockByBarcode(ctx context.Context, code string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ExportStocks request
+	ExportStocks(ctx context.Context, params *ExportStocksParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ImportStocksWithBody request with any body
+	ImportStocksWithBody(ctx context.Context, params *ImportStocksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteStock request
+	DeleteStock(ctx context.Context, name string, params *DeleteStockParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetStockByName request
+	GetStockByName(ctx context.Context, name string, params *GetStockByNameParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AdjustStockWithBody request with any body
+	AdjustStockWithBody(ctx context.Context, name string, params *AdjustStockParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AdjustStock(ctx context.Context, name string, params *AdjustStockParams, body AdjustStockJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetStockWithBody request with any body
+	SetStockWithBody(ctx context.Context, name string, params *SetStockParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetStock(ctx context.Context, name string, params *SetStockParams, body SetStockJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AllocateStockWithBody request with any body
+	AllocateStockWithBody(ctx context.Context, name string, params *AllocateStockParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AllocateStock(ctx context.Context, name string, params *AllocateStockParams, body AllocateStockJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetStockHistory request
+	GetStockHistory(ctx context.Context, name string, params *GetStockHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetStockLocations request
+	GetStockLocations(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UnlinkStockProduct request
+	UnlinkStockProduct(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LinkStockProductWithBody request with any body
+	LinkStockProductWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	LinkStockProduct(ctx context.Context, name string, body LinkStockProductJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateReservationWithBody request with any body
+	CreateReservationWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateReservation(ctx context.Context, name string, body CreateReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RestoreStock request
+	RestoreStock(ctx context.Context, name string, params *RestoreStockParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetStockThresholds request
+	GetStockThresholds(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutStockThresholdsWithBody request with any body
+	PutStockThresholdsWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutStockThresholds(ctx context.Context, name string, body PutStockThresholdsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// BatchCreateOrUpdateStocksWithBody request with any body
+	BatchCreateOrUpdateStocksWithBody(ctx context.Context, params *BatchCreateOrUpdateStocksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	BatchCreateOrUpdateStocks(ctx context.Context, params *BatchCreateOrUpdateStocksParams, body BatchCreateOrUpdateStocksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TransferStockWithBody request with any body
+	TransferStockWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TransferStock(ctx context.Context, body TransferStockJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWebhooks request
+	GetWebhooks(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateWebhookWithBody request with any body
+	CreateWebhookWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateWebhook(ctx context.Context, body CreateWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteWebhook request
+	DeleteWebhook(ctx context.Context, id WebhookId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWebhook request
+	GetWebhook(ctx context.Context, id WebhookId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWebhookDeliveries request
+	GetWebhookDeliveries(ctx context.Context, id WebhookId, params *GetWebhookDeliveriesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetAlerts(ctx context.Context, params *GetAlertsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAlertsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AcknowledgeAlert(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAcknowledgeAlertRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetLocations(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLocationsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateLocationWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateLocationRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateLocation(ctx context.Context, body CreateLocationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateLocationRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetLocationStocks(ctx context.Context, code LocationCode, params *GetLocationStocksParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLocationStocksRequest(c.Server, code, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetStockLevel(ctx context.Context, code LocationCode, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetStockLevelRequest(c.Server, code, name)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetStockLevelWithBody(ctx context.Context, code LocationCode, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetStockLevelRequestWithBody(c.Server, code, name, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetStockLevel(ctx context.Context, code LocationCode, name string, body SetStockLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetStockLevelRequest(c.Server, code, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateOrderWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateOrderRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateOrder(ctx context.Context, body CreateOrderJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateOrderRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetOrder(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOrderRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RelayOutbox(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRelayOutboxRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetProducts(ctx context.Context, params *GetProductsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetProductsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateProductWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateProductRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateProduct(ctx context.Context, body CreateProductJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateProductRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetProduct(ctx context.Context, id ProductId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetProductRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateProductWithBody(ctx context.Context, id ProductId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateProductRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateProduct(ctx context.Context, id ProductId, body UpdateProductJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateProductRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetReservation(ctx context.Context, id ReservationId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetReservationRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CommitReservation(ctx context.Context, id ReservationId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCommitReservationRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReleaseReservation(ctx context.Context, id ReservationId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReleaseReservationRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAllStocks(ctx context.Context, params *GetAllStocksParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAllStocksRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateOrUpdateStockWithBody(ctx context.Context, params *CreateOrUpdateStockParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateOrUpdateStockRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateOrUpdateStock(ctx context.Context, params *CreateOrUpdateStockParams, body CreateOrUpdateStockJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateOrUpdateStockRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetStockByBarcode(ctx context.Context, code string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetStockByBarcodeRequest(c.Server, code)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ExportStocks(ctx context.Context, params *ExportStocksParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportStocksRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ImportStocksWithBody(ctx context.Context, params *ImportStocksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportStocksRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteStock(ctx context.Context, name string, params *DeleteStockParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteStockRequest(c.Server, name, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetStockByName(ctx context.Context, name string, params *GetStockByNameParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetStockByNameRequest(c.Server, name, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AdjustStockWithBody(ctx context.Context, name string, params *AdjustStockParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAdjustStockRequestWithBody(c.Server, name, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AdjustStock(ctx context.Context, name string, params *AdjustStockParams, body AdjustStockJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAdjustStockRequest(c.Server, name, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetStockWithBody(ctx context.Context, name string, params *SetStockParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetStockRequestWithBody(c.Server, name, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetStock(ctx context.Context, name string, params *SetStockParams, body SetStockJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetStockRequest(c.Server, name, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AllocateStockWithBody(ctx context.Context, name string, params *AllocateStockParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAllocateStockRequestWithBody(c.Server, name, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AllocateStock(ctx context.Context, name string, params *AllocateStockParams, body AllocateStockJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAllocateStockRequest(c.Server, name, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetStockHistory(ctx context.Context, name string, params *GetStockHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetStockHistoryRequest(c.Server, name, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetStockLocations(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetStockLocationsRequest(c.Server, name)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UnlinkStockProduct(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnlinkStockProductRequest(c.Server, name)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LinkStockProductWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLinkStockProductRequestWithBody(c.Server, name, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LinkStockProduct(ctx context.Context, name string, body LinkStockProductJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLinkStockProductRequest(c.Server, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateReservationWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateReservationRequestWithBody(c.Server, name, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateReservation(ctx context.Context, name string, body CreateReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateReservationRequest(c.Server, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RestoreStock(ctx context.Context, name string, params *RestoreStockParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRestoreStockRequest(c.Server, name, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetStockThresholds(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetStockThresholdsRequest(c.Server, name)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutStockThresholdsWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutStockThresholdsRequestWithBody(c.Server, name, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutStockThresholds(ctx context.Context, name string, body PutStockThresholdsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutStockThresholdsRequest(c.Server, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) BatchCreateOrUpdateStocksWithBody(ctx context.Context, params *BatchCreateOrUpdateStocksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBatchCreateOrUpdateStocksRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) BatchCreateOrUpdateStocks(ctx context.Context, params *BatchCreateOrUpdateStocksParams, body BatchCreateOrUpdateStocksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBatchCreateOrUpdateStocksRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TransferStockWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTransferStockRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TransferStock(ctx context.Context, body TransferStockJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTransferStockRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetWebhooks(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWebhooksRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateWebhookWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateWebhookRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateWebhook(ctx context.Context, body CreateWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateWebhookRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, id WebhookId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteWebhookRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetWebhook(ctx context.Context, id WebhookId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWebhookRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetWebhookDeliveries(ctx context.Context, id WebhookId, params *GetWebhookDeliveriesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWebhookDeliveriesRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetAlertsRequest generates requests for GetAlerts
+func NewGetAlertsRequest(server string, params *GetAlertsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/alerts")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Name != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "name", runtime.ParamLocationQuery, *params.Name); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAcknowledgeAlertRequest generates requests for AcknowledgeAlert
+func NewAcknowledgeAlertRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/alerts/%s/acknowledge", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetLocationsRequest generates requests for GetLocations
+func NewGetLocationsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/locations")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateLocationRequest calls the generic CreateLocation builder with application/json body
+func NewCreateLocationRequest(server string, body CreateLocationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateLocationRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateLocationRequestWithBody generates requests for CreateLocation with any type of body
+func NewCreateLocationRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/locations")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetLocationStocksRequest generates requests for GetLocationStocks
+func NewGetLocationStocksRequest(server string, code LocationCode, params *GetLocationStocksParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "code", runtime.ParamLocationPath, code)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/locations/%s/stocks", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetStockLevelRequest generates requests for GetStockLevel
+func NewGetStockLevelRequest(server string, code LocationCode, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "code", runtime.ParamLocationPath, code)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/locations/%s/stocks/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetStockLevelRequest calls the generic SetStockLevel builder with application/json body
+func NewSetStockLevelRequest(server string, code LocationCode, name string, body SetStockLevelJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetStockLevelRequestWithBody(server, code, name, "application/json", bodyReader)
+}
+
+// NewSetStockLevelRequestWithBody generates requests for SetStockLevel with any type of body
+func NewSetStockLevelRequestWithBody(server string, code LocationCode, name string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "code", runtime.ParamLocationPath, code)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/locations/%s/stocks/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCreateOrderRequest calls the generic CreateOrder builder with application/json body
+func NewCreateOrderRequest(server string, body CreateOrderJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateOrderRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateOrderRequestWithBody generates requests for CreateOrder with any type of body
+func NewCreateOrderRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/orders")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetOrderRequest generates requests for GetOrder
+func NewGetOrderRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/orders/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRelayOutboxRequest generates requests for RelayOutbox
+func NewRelayOutboxRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/outbox/relay")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetProductsRequest generates requests for GetProducts
+func NewGetProductsRequest(server string, params *GetProductsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/products")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Category != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "category", runtime.ParamLocationQuery, *params.Category); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateProductRequest calls the generic CreateProduct builder with application/json body
+func NewCreateProductRequest(server string, body CreateProductJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateProductRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateProductRequestWithBody generates requests for CreateProduct with any type of body
+func NewCreateProductRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/products")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetProductRequest generates requests for GetProduct
+func NewGetProductRequest(server string, id ProductId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/products/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateProductRequest calls the generic UpdateProduct builder with application/json body
+func NewUpdateProductRequest(server string, id ProductId, body UpdateProductJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateProductRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewUpdateProductRequestWithBody generates requests for UpdateProduct with any type of body
+func NewUpdateProductRequestWithBody(server string, id ProductId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/products/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetReservationRequest generates requests for GetReservation
+func NewGetReservationRequest(server string, id ReservationId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reservations/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCommitReservationRequest generates requests for CommitReservation
+func NewCommitReservationRequest(server string, id ReservationId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reservations/%s/commit", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewReleaseReservationRequest generates requests for ReleaseReservation
+func NewReleaseReservationRequest(server string, id ReservationId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reservations/%s/release", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAllStocksRequest generates requests for GetAllStocks
+func NewGetAllStocksRequest(server string, params *GetAllStocksParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stocks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Prefix != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "prefix", runtime.ParamLocationQuery, *params.Prefix); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Contains != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "contains", runtime.ParamLocationQuery, *params.Contains); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.MinAmount != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "min_amount", runtime.ParamLocationQuery, *params.MinAmount); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.MaxAmount != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "max_amount", runtime.ParamLocationQuery, *params.MaxAmount); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.UpdatedSince != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "updated_since", runtime.ParamLocationQuery, *params.UpdatedSince); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Sort != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sort", runtime.ParamLocationQuery, *params.Sort); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.IncludeDeleted != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "include_deleted", runtime.ParamLocationQuery, *params.IncludeDeleted); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateOrUpdateStockRequest calls the generic CreateOrUpdateStock builder with application/json body
+func NewCreateOrUpdateStockRequest(server string, params *CreateOrUpdateStockParams, body CreateOrUpdateStockJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateOrUpdateStockRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewCreateOrUpdateStockRequestWithBody generates requests for CreateOrUpdateStock with any type of body
+func NewCreateOrUpdateStockRequestWithBody(server string, params *CreateOrUpdateStockParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stocks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+		if params.IdempotencyKey != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam1)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetStockByBarcodeRequest generates requests for GetStockByBarcode
+func NewGetStockByBarcodeRequest(server string, code string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "code", runtime.ParamLocationPath, code)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stocks/by-barcode/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewExportStocksRequest generates requests for ExportStocks
+func NewExportStocksRequest(server string, params *ExportStocksParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stocks/export.csv")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.IncludeDeleted != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "include_deleted", runtime.ParamLocationQuery, *params.IncludeDeleted); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewImportStocksRequestWithBody generates requests for ImportStocks with any type of body
+func NewImportStocksRequestWithBody(server string, params *ImportStocksParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stocks/import")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Mode != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "mode", runtime.ParamLocationQuery, *params.Mode); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.DryRun != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "dry_run", runtime.ParamLocationQuery, *params.DryRun); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteStockRequest generates requests for DeleteStock
+func NewDeleteStockRequest(server string, name string, params *DeleteStockParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stocks/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetStockByNameRequest generates requests for GetStockByName
+func NewGetStockByNameRequest(server string, name string, params *GetStockByNameParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stocks/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.IncludeDeleted != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "include_deleted", runtime.ParamLocationQuery, *params.IncludeDeleted); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAdjustStockRequest calls the generic AdjustStock builder with application/json body
+func NewAdjustStockRequest(server string, name string, params *AdjustStockParams, body AdjustStockJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAdjustStockRequestWithBody(server, name, params, "application/json", bodyReader)
+}
+
+// NewAdjustStockRequestWithBody generates requests for AdjustStock with any type of body
+func NewAdjustStockRequestWithBody(server string, name string, params *AdjustStockParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stocks/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewSetStockRequest calls the generic SetStock builder with application/json body
+func NewSetStockRequest(server string, name string, params *SetStockParams, body SetStockJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetStockRequestWithBody(server, name, params, "application/json", bodyReader)
+}
+
+// NewSetStockRequestWithBody generates requests for SetStock with any type of body
+func NewSetStockRequestWithBody(server string, name string, params *SetStockParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stocks/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.CreateOnly != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "create_only", runtime.ParamLocationQuery, *params.CreateOnly); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewAllocateStockRequest calls the generic AllocateStock builder with application/json body
+func NewAllocateStockRequest(server string, name string, params *AllocateStockParams, body AllocateStockJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAllocateStockRequestWithBody(server, name, params, "application/json", bodyReader)
+}
+
+// NewAllocateStockRequestWithBody generates requests for AllocateStock with any type of body
+func NewAllocateStockRequestWithBody(server string, name string, params *AllocateStockParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stocks/%s/allocate", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetStockHistoryRequest generates requests for GetStockHistory
+func NewGetStockHistoryRequest(server string, name string, params *GetStockHistoryParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stocks/%s/history", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetStockLocationsRequest generates requests for GetStockLocations
+func NewGetStockLocationsRequest(server string, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stocks/%s/locations", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUnlinkStockProductRequest generates requests for UnlinkStockProduct
+func NewUnlinkStockProductRequest(server string, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stocks/%s/product", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewLinkStockProductRequest calls the generic LinkStockProduct builder with application/json body
+func NewLinkStockProductRequest(server string, name string, body LinkStockProductJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewLinkStockProductRequestWithBody(server, name, "application/json", bodyReader)
+}
+
+// NewLinkStockProductRequestWithBody generates requests for LinkStockProduct with any type of body
+func NewLinkStockProductRequestWithBody(server string, name string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stocks/%s/product", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCreateReservationRequest calls the generic CreateReservation builder with application/json body
+func NewCreateReservationRequest(server string, name string, body CreateReservationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateReservationRequestWithBody(server, name, "application/json", bodyReader)
+}
+
+// NewCreateReservationRequestWithBody generates requests for CreateReservation with any type of body
+func NewCreateReservationRequestWithBody(server string, name string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stocks/%s/reservations", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRestoreStockRequest generates requests for RestoreStock
+func NewRestoreStockRequest(server string, name string, params *RestoreStockParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stocks/%s/restore", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetStockThresholdsRequest generates requests for GetStockThresholds
+func NewGetStockThresholdsRequest(server string, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stocks/%s/thresholds", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutStockThresholdsRequest calls the generic PutStockThresholds builder with application/json body
+func NewPutStockThresholdsRequest(server string, name string, body PutStockThresholdsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutStockThresholdsRequestWithBody(server, name, "application/json", bodyReader)
+}
+
+// NewPutStockThresholdsRequestWithBody generates requests for PutStockThresholds with any type of body
+func NewPutStockThresholdsRequestWithBody(server string, name string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stocks/%s/thresholds", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewBatchCreateOrUpdateStocksRequest calls the generic BatchCreateOrUpdateStocks builder with application/json body
+func NewBatchCreateOrUpdateStocksRequest(server string, params *BatchCreateOrUpdateStocksParams, body BatchCreateOrUpdateStocksJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewBatchCreateOrUpdateStocksRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewBatchCreateOrUpdateStocksRequestWithBody generates requests for BatchCreateOrUpdateStocks with any type of body
+func NewBatchCreateOrUpdateStocksRequestWithBody(server string, params *BatchCreateOrUpdateStocksParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stocks:batch")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Atomic != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "atomic", runtime.ParamLocationQuery, *params.Atomic); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewTransferStockRequest calls the generic TransferStock builder with application/json body
+func NewTransferStockRequest(server string, body TransferStockJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTransferStockRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewTransferStockRequestWithBody generates requests for TransferStock with any type of body
+func NewTransferStockRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/transfers")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetWebhooksRequest generates requests for GetWebhooks
+func NewGetWebhooksRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateWebhookRequest calls the generic CreateWebhook builder with application/json body
+func NewCreateWebhookRequest(server string, body CreateWebhookJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateWebhookRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateWebhookRequestWithBody generates requests for CreateWebhook with any type of body
+func NewCreateWebhookRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteWebhookRequest generates requests for DeleteWebhook
+func NewDeleteWebhookRequest(server string, id WebhookId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetWebhookRequest generates requests for GetWebhook
+func NewGetWebhookRequest(server string, id WebhookId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetWebhookDeliveriesRequest generates requests for GetWebhookDeliveries
+func NewGetWebhookDeliveriesRequest(server string, id WebhookId, params *GetWebhookDeliveriesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks/%s/deliveries", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetAlertsWithResponse request
+	GetAlertsWithResponse(ctx context.Context, params *GetAlertsParams, reqEditors ...RequestEditorFn) (*GetAlertsResponse, error)
+
+	// AcknowledgeAlertWithResponse request
+	AcknowledgeAlertWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*AcknowledgeAlertResponse, error)
+
+	// GetLocationsWithResponse request
+	GetLocationsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLocationsResponse, error)
+
+	// CreateLocationWithBodyWithResponse request with any body
+	CreateLocationWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateLocationResponse, error)
+
+	CreateLocationWithResponse(ctx context.Context, body CreateLocationJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateLocationResponse, error)
+
+	// GetLocationStocksWithResponse request
+	GetLocationStocksWithResponse(ctx context.Context, code LocationCode, params *GetLocationStocksParams, reqEditors ...RequestEditorFn) (*GetLocationStocksResponse, error)
+
+	// GetStockLevelWithResponse request
+	GetStockLevelWithResponse(ctx context.Context, code LocationCode, name string, reqEditors ...RequestEditorFn) (*GetStockLevelResponse, error)
+
+	// SetStockLevelWithBodyWithResponse request with any body
+	SetStockLevelWithBodyWithResponse(ctx context.Context, code LocationCode, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetStockLevelResponse, error)
+
+	SetStockLevelWithResponse(ctx context.Context, code LocationCode, name string, body SetStockLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*SetStockLevelResponse, error)
+
+	// CreateOrderWithBodyWithResponse request with any body
+	CreateOrderWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateOrderResponse, error)
+
+	CreateOrderWithResponse(ctx context.Context, body CreateOrderJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateOrderResponse, error)
+
+	// GetOrderWithResponse request
+	GetOrderWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetOrderResponse, error)
+
+	// RelayOutboxWithResponse request
+	RelayOutboxWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*RelayOutboxResponse, error)
+
+	// GetProductsWithResponse request
+	GetProductsWithResponse(ctx context.Context, params *GetProductsParams, reqEditors ...RequestEditorFn) (*GetProductsResponse, error)
+
+	// CreateProductWithBodyWithResponse request with any body
+	CreateProductWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateProductResponse, error)
+
+	CreateProductWithResponse(ctx context.Context, body CreateProductJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateProductResponse, error)
+
+	// GetProductWithResponse request
+	GetProductWithResponse(ctx context.Context, id ProductId, reqEditors ...RequestEditorFn) (*GetProductResponse, error)
+
+	// UpdateProductWithBodyWithResponse request with any body
+	UpdateProductWithBodyWithResponse(ctx context.Context, id ProductId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateProductResponse, error)
+
+	UpdateProductWithResponse(ctx context.Context, id ProductId, body UpdateProductJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateProductResponse, error)
+
+	// GetReservationWithResponse request
+	GetReservationWithResponse(ctx context.Context, id ReservationId, reqEditors ...RequestEditorFn) (*GetReservationResponse, error)
+
+	// CommitReservationWithResponse request
+	CommitReservationWithResponse(ctx context.Context, id ReservationId, reqEditors ...RequestEditorFn) (*CommitReservationResponse, error)
+
+	// ReleaseReservationWithResponse request
+	ReleaseReservationWithResponse(ctx context.Context, id ReservationId, reqEditors ...RequestEditorFn) (*ReleaseReservationResponse, error)
+
+	// GetAllStocksWithResponse request
+	GetAllStocksWithResponse(ctx context.Context, params *GetAllStocksParams, reqEditors ...RequestEditorFn) (*GetAllStocksResponse, error)
+
+	// CreateOrUpdateStockWithBodyWithResponse request with any body
+	CreateOrUpdateStockWithBodyWithResponse(ctx context.Context, params *CreateOrUpdateStockParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateOrUpdateStockResponse, error)
+
+	CreateOrUpdateStockWithResponse(ctx context.Context, params *CreateOrUpdateStockParams, body CreateOrUpdateStockJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateOrUpdateStockResponse, error)
+
+	// GetStockByBarcodeWithResponse request
+	GetStockByBarcodeWithResponse(ctx context.Context, code string, reqEditors ...RequestEditorFn) (*GetStockByBarcodeResponse, error)
+
+	// ExportStocksWithResponse request
+	ExportStocksWithResponse(ctx context.Context, params *ExportStocksParams, reqEditors ...RequestEditorFn) (*ExportStocksResponse, error)
+
+	// ImportStocksWithBodyWithResponse request with any body
+	ImportStocksWithBodyWithResponse(ctx context.Context, params *ImportStocksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportStocksResponse, error)
+
+	// DeleteStockWithResponse request
+	DeleteStockWithResponse(ctx context.Context, name string, params *DeleteStockParams, reqEditors ...RequestEditorFn) (*DeleteStockResponse, error)
+
+	// GetStockByNameWithResponse request
+	GetStockByNameWithResponse(ctx context.Context, name string, params *GetStockByNameParams, reqEditors ...RequestEditorFn) (*GetStockByNameResponse, error)
+
+	// AdjustStockWithBodyWithResponse request with any body
+	AdjustStockWithBodyWithResponse(ctx context.Context, name string, params *AdjustStockParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AdjustStockResponse, error)
+
+	AdjustStockWithResponse(ctx context.Context, name string, params *AdjustStockParams, body AdjustStockJSONRequestBody, reqEditors ...RequestEditorFn) (*AdjustStockResponse, error)
+
+	// SetStockWithBodyWithResponse request with any body
+	SetStockWithBodyWithResponse(ctx context.Context, name string, params *SetStockParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetStockResponse, error)
+
+	SetStockWithResponse(ctx context.Context, name string, params *SetStockParams, body SetStockJSONRequestBody, reqEditors ...RequestEditorFn) (*SetStockResponse, error)
+
+	// AllocateStockWithBodyWithResponse request with any body
+	AllocateStockWithBodyWithResponse(ctx context.Context, name string, params *AllocateStockParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AllocateStockResponse, error)
+
+	AllocateStockWithResponse(ctx context.Context, name string, params *AllocateStockParams, body AllocateStockJSONRequestBody, reqEditors ...RequestEditorFn) (*AllocateStockResponse, error)
+
+	// GetStockHistoryWithResponse request
+	GetStockHistoryWithResponse(ctx context.Context, name string, params *GetStockHistoryParams, reqEditors ...RequestEditorFn) (*GetStockHistoryResponse, error)
+
+	// GetStockLocationsWithResponse request
+	GetStockLocationsWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*GetStockLocationsResponse, error)
+
+	// UnlinkStockProductWithResponse request
+	UnlinkStockProductWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*UnlinkStockProductResponse, error)
+
+	// LinkStockProductWithBodyWithResponse request with any body
+	LinkStockProductWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LinkStockProductResponse, error)
+
+	LinkStockProductWithResponse(ctx context.Context, name string, body LinkStockProductJSONRequestBody, reqEditors ...RequestEditorFn) (*LinkStockProductResponse, error)
+
+	// CreateReservationWithBodyWithResponse request with any body
+	CreateReservationWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateReservationResponse, error)
+
+	CreateReservationWithResponse(ctx context.Context, name string, body CreateReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateReservationResponse, error)
+
+	// RestoreStockWithResponse request
+	RestoreStockWithResponse(ctx context.Context, name string, params *RestoreStockParams, reqEditors ...RequestEditorFn) (*RestoreStockResponse, error)
+
+	// GetStockThresholdsWithResponse request
+	GetStockThresholdsWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*GetStockThresholdsResponse, error)
+
+	// PutStockThresholdsWithBodyWithResponse request with any body
+	PutStockThresholdsWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutStockThresholdsResponse, error)
+
+	PutStockThresholdsWithResponse(ctx context.Context, name string, body PutStockThresholdsJSONRequestBody, reqEditors ...RequestEditorFn) (*PutStockThresholdsResponse, error)
+
+	// BatchCreateOrUpdateStocksWithBodyWithResponse request with any body
+	BatchCreateOrUpdateStocksWithBodyWithResponse(ctx context.Context, params *BatchCreateOrUpdateStocksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BatchCreateOrUpdateStocksResponse, error)
+
+	BatchCreateOrUpdateStocksWithResponse(ctx context.Context, params *BatchCreateOrUpdateStocksParams, body BatchCreateOrUpdateStocksJSONRequestBody, reqEditors ...RequestEditorFn) (*BatchCreateOrUpdateStocksResponse, error)
+
+	// TransferStockWithBodyWithResponse request with any body
+	TransferStockWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TransferStockResponse, error)
+
+	TransferStockWithResponse(ctx context.Context, body TransferStockJSONRequestBody, reqEditors ...RequestEditorFn) (*TransferStockResponse, error)
+
+	// GetWebhooksWithResponse request
+	GetWebhooksWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWebhooksResponse, error)
+
+	// CreateWebhookWithBodyWithResponse request with any body
+	CreateWebhookWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateWebhookResponse, error)
+
+	CreateWebhookWithResponse(ctx context.Context, body CreateWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateWebhookResponse, error)
+
+	// DeleteWebhookWithResponse request
+	DeleteWebhookWithResponse(ctx context.Context, id WebhookId, reqEditors ...RequestEditorFn) (*DeleteWebhookResponse, error)
+
+	// GetWebhookWithResponse request
+	GetWebhookWithResponse(ctx context.Context, id WebhookId, reqEditors ...RequestEditorFn) (*GetWebhookResponse, error)
+
+	// GetWebhookDeliveriesWithResponse request
+	GetWebhookDeliveriesWithResponse(ctx context.Context, id WebhookId, params *GetWebhookDeliveriesParams, reqEditors ...RequestEditorFn) (*GetWebhookDeliveriesResponse, error)
+}
+
+type GetAlertsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AlertPage
+	JSON400      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAlertsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAlertsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AcknowledgeAlertResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Alert
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r AcknowledgeAlertResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AcknowledgeAlertResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLocationsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LocationList
+	JSON500      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLocationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLocationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateLocationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Location
+	JSON400      *ErrorResponse
+	JSON409      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateLocationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateLocationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLocationStocksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StockLevelPage
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON501      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLocationStocksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLocationStocksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetStockLevelResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StockLevel
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON501      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetStockLevelResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetStockLevelResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetStockLevelResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StockLevel
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON409      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON501      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SetStockLevelResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetStockLevelResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateOrderResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Order
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON409      *OrderShortageResponse
+	JSON500      *ErrorResponse
+	JSON501      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateOrderResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateOrderResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetOrderResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Order
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOrderResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOrderResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RelayOutboxResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RelayResult
+	JSON502      *RelayResult
+	JSON503      *ErrorResponse
+	JSON504      *RelayResult
+}
+
+// Status returns HTTPResponse.Status
+func (r RelayOutboxResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RelayOutboxResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetProductsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ProductPage
+	JSON400      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetProductsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetProductsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateProductResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Product
+	JSON400      *ErrorResponse
+	JSON409      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateProductResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateProductResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetProductResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Product
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetProductResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetProductResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateProductResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Product
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON409      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateProductResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateProductResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetReservationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Reservation
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetReservationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetReservationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CommitReservationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Reservation
+	JSON404      *ErrorResponse
+	JSON409      *ReservationConflictResponse
+	JSON500      *ErrorResponse
+	JSON501      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CommitReservationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CommitReservationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReleaseReservationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Reservation
+	JSON404      *ErrorResponse
+	JSON409      *ReservationConflictResponse
+	JSON500      *ErrorResponse
+	JSON501      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ReleaseReservationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReleaseReservationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAllStocksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		union json.RawMessage
+	}
+	JSON400 *ErrorResponse
+	JSON500 *ErrorResponse
+	JSON504 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAllStocksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAllStocksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateOrUpdateStockResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Stock
+	JSON400      *ErrorResponse
+	JSON409      *ErrorResponse
+	JSON412      *ErrorResponse
+	JSON422      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateOrUpdateStockResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateOrUpdateStockResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetStockByBarcodeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Stock
+	JSON400      *BarcodeErrorResponse
+	JSON404      *BarcodeErrorResponse
+	JSON409      *BarcodeErrorResponse
+	JSON500      *ErrorResponse
+	JSON501      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetStockByBarcodeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetStockByBarcodeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportStocksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON500      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportStocksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportStocksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ImportStocksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ImportResult
+	JSON400      *ImportResult
+	JSON500      *ErrorResponse
+	JSON501      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ImportStocksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ImportStocksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteStockResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *ErrorResponse
+	JSON409      *ErrorResponse
+	JSON412      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteStockResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteStockResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetStockByNameResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		union json.RawMessage
+	}
+	JSON500 *ErrorResponse
+	JSON504 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetStockByNameResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetStockByNameResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AdjustStockResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AdjustmentResult
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON409      *ErrorResponse
+	JSON412      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r AdjustStockResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AdjustStockResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetStockResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Stock
+	JSON201      *Stock
+	JSON400      *ErrorResponse
+	JSON409      *ErrorResponse
+	JSON412      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SetStockResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetStockResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AllocateStockResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AllocationResult
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON409      *InsufficientStockResponse
+	JSON412      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r AllocateStockResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AllocateStockResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetStockHistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MovementHistory
+	JSON400      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetStockHistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetStockHistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetStockLocationsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StockLocations
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON501      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetStockLocationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetStockLocationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UnlinkStockProductResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON501      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r UnlinkStockProductResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UnlinkStockProductResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LinkStockProductResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Stock
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON501      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r LinkStockProductResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LinkStockProductResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateReservationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Reservation
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON409      *InsufficientStockResponse
+	JSON500      *ErrorResponse
+	JSON501      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateReservationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateReservationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RestoreStockResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Stock
+	JSON404      *ErrorResponse
+	JSON412      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r RestoreStockResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RestoreStockResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetStockThresholdsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StockThresholds
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetStockThresholdsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetStockThresholdsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutStockThresholdsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StockThresholds
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PutStockThresholdsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutStockThresholdsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type BatchCreateOrUpdateStocksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BatchResult
+	JSON400      *BatchResult
+	JSON409      *BatchFailureResponse
+	JSON422      *ErrorResponse
+	JSON500      *BatchFailureResponse
+	JSON504      *BatchFailureResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r BatchCreateOrUpdateStocksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r BatchCreateOrUpdateStocksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TransferStockResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Transfer
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON409      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON501      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r TransferStockResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TransferStockResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWebhooksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WebhookList
+	JSON500      *ErrorResponse
+	JSON501      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWebhooksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWebhooksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateWebhookResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Webhook
+	JSON400      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON501      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateWebhookResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateWebhookResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteWebhookResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON501      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteWebhookResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteWebhookResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWebhookResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Webhook
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON501      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWebhookResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWebhookResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWebhookDeliveriesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WebhookDeliveryPage
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON501      *ErrorResponse
+	JSON504      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWebhookDeliveriesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWebhookDeliveriesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetAlertsWithResponse request returning *GetAlertsResponse
+func (c *ClientWithResponses) GetAlertsWithResponse(ctx context.Context, params *GetAlertsParams, reqEditors ...RequestEditorFn) (*GetAlertsResponse, error) {
+	rsp, err := c.GetAlerts(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAlertsResponse(rsp)
+}
+
+// AcknowledgeAlertWithResponse request returning *AcknowledgeAlertResponse
+func (c *ClientWithResponses) AcknowledgeAlertWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*AcknowledgeAlertResponse, error) {
+	rsp, err := c.AcknowledgeAlert(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAcknowledgeAlertResponse(rsp)
+}
+
+// GetLocationsWithResponse request returning *GetLocationsResponse
+func (c *ClientWithResponses) GetLocationsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLocationsResponse, error) {
+	rsp, err := c.GetLocations(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLocationsResponse(rsp)
+}
+
+// CreateLocationWithBodyWithResponse request with arbitrary body returning *CreateLocationResponse
+func (c *ClientWithResponses) CreateLocationWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateLocationResponse, error) {
+	rsp, err := c.CreateLocationWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateLocationResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateLocationWithResponse(ctx context.Context, body CreateLocationJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateLocationResponse, error) {
+	rsp, err := c.CreateLocation(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateLocationResponse(rsp)
+}
+
+// GetLocationStocksWithResponse request returning *GetLocationStocksResponse
+func (c *ClientWithResponses) GetLocationStocksWithResponse(ctx context.Context, code LocationCode, params *GetLocationStocksParams, reqEditors ...RequestEditorFn) (*GetLocationStocksResponse, error) {
+	rsp, err := c.GetLocationStocks(ctx, code, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLocationStocksResponse(rsp)
+}
+
+// GetStockLevelWithResponse request returning *GetStockLevelResponse
+func (c *ClientWithResponses) GetStockLevelWithResponse(ctx context.Context, code LocationCode, name string, reqEditors ...RequestEditorFn) (*GetStockLevelResponse, error) {
+	rsp, err := c.GetStockLevel(ctx, code, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetStockLevelResponse(rsp)
+}
+
+// SetStockLevelWithBodyWithResponse request with arbitrary body returning *SetStockLevelResponse
+func (c *ClientWithResponses) SetStockLevelWithBodyWithResponse(ctx context.Context, code LocationCode, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetStockLevelResponse, error) {
+	rsp, err := c.SetStockLevelWithBody(ctx, code, name, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetStockLevelResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetStockLevelWithResponse(ctx context.Context, code LocationCode, name string, body SetStockLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*SetStockLevelResponse, error) {
+	rsp, err := c.SetStockLevel(ctx, code, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetStockLevelResponse(rsp)
+}
+
+// CreateOrderWithBodyWithResponse request with arbitrary body returning *CreateOrderResponse
+func (c *ClientWithResponses) CreateOrderWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateOrderResponse, error) {
+	rsp, err := c.CreateOrderWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateOrderResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateOrderWithResponse(ctx context.Context, body CreateOrderJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateOrderResponse, error) {
+	rsp, err := c.CreateOrder(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateOrderResponse(rsp)
+}
+
+// GetOrderWithResponse request returning *GetOrderResponse
+func (c *ClientWithResponses) GetOrderWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetOrderResponse, error) {
+	rsp, err := c.GetOrder(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOrderResponse(rsp)
+}
+
+// RelayOutboxWithResponse request returning *RelayOutboxResponse
+func (c *ClientWithResponses) RelayOutboxWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*RelayOutboxResponse, error) {
+	rsp, err := c.RelayOutbox(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRelayOutboxResponse(rsp)
+}
+
+// GetProductsWithResponse request returning *GetProductsResponse
+func (c *ClientWithResponses) GetProductsWithResponse(ctx context.Context, params *GetProductsParams, reqEditors ...RequestEditorFn) (*GetProductsResponse, error) {
+	rsp, err := c.GetProducts(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetProductsResponse(rsp)
+}
+
+// CreateProductWithBodyWithResponse request with arbitrary body returning *CreateProductResponse
+func (c *ClientWithResponses) CreateProductWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateProductResponse, error) {
+	rsp, err := c.CreateProductWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateProductResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateProductWithResponse(ctx context.Context, body CreateProductJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateProductResponse, error) {
+	rsp, err := c.CreateProduct(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateProductResponse(rsp)
+}
+
+// GetProductWithResponse request returning *GetProductResponse
+func (c *ClientWithResponses) GetProductWithResponse(ctx context.Context, id ProductId, reqEditors ...RequestEditorFn) (*GetProductResponse, error) {
+	rsp, err := c.GetProduct(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetProductResponse(rsp)
+}
+
+// UpdateProductWithBodyWithResponse request with arbitrary body returning *UpdateProductResponse
+func (c *ClientWithResponses) UpdateProductWithBodyWithResponse(ctx context.Context, id ProductId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateProductResponse, error) {
+	rsp, err := c.UpdateProductWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateProductResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateProductWithResponse(ctx context.Context, id ProductId, body UpdateProductJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateProductResponse, error) {
+	rsp, err := c.UpdateProduct(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateProductResponse(rsp)
+}
+
+// GetReservationWithResponse request returning *GetReservationResponse
+func (c *ClientWithResponses) GetReservationWithResponse(ctx context.Context, id ReservationId, reqEditors ...RequestEditorFn) (*GetReservationResponse, error) {
+	rsp, err := c.GetReservation(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetReservationResponse(rsp)
+}
+
+// CommitReservationWithResponse request returning *CommitReservationResponse
+func (c *ClientWithResponses) CommitReservationWithResponse(ctx context.Context, id ReservationId, reqEditors ...RequestEditorFn) (*CommitReservationResponse, error) {
+	rsp, err := c.CommitReservation(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCommitReservationResponse(rsp)
+}
+
+// ReleaseReservationWithResponse request returning *ReleaseReservationResponse
+func (c *ClientWithResponses) ReleaseReservationWithResponse(ctx context.Context, id ReservationId, reqEditors ...RequestEditorFn) (*ReleaseReservationResponse, error) {
+	rsp, err := c.ReleaseReservation(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReleaseReservationResponse(rsp)
+}
+
+// GetAllStocksWithResponse request returning *GetAllStocksResponse
+func (c *ClientWithResponses) GetAllStocksWithResponse(ctx context.Context, params *GetAllStocksParams, reqEditors ...RequestEditorFn) (*GetAllStocksResponse, error) {
+	rsp, err := c.GetAllStocks(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAllStocksResponse(rsp)
+}
+
+// CreateOrUpdateStockWithBodyWithResponse request with arbitrary body returning *CreateOrUpdateStockResponse
+func (c *ClientWithResponses) CreateOrUpdateStockWithBodyWithResponse(ctx context.Context, params *CreateOrUpdateStockParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateOrUpdateStockResponse, error) {
+	rsp, err := c.CreateOrUpdateStockWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateOrUpdateStockResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateOrUpdateStockWithResponse(ctx context.Context, params *CreateOrUpdateStockParams, body CreateOrUpdateStockJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateOrUpdateStockResponse, error) {
+	rsp, err := c.CreateOrUpdateStock(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateOrUpdateStockResponse(rsp)
+}
+
+// GetStockByBarcodeWithResponse request returning *GetStockByBarcodeResponse
+func (c *ClientWithResponses) GetStockByBarcodeWithResponse(ctx context.Context, code string, reqEditors ...RequestEditorFn) (*GetStockByBarcodeResponse, error) {
+	rsp, err := c.GetStockByBarcode(ctx, code, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetStockByBarcodeResponse(rsp)
+}
+
+// ExportStocksWithResponse request returning *ExportStocksResponse
+func (c *ClientWithResponses) ExportStocksWithResponse(ctx context.Context, params *ExportStocksParams, reqEditors ...RequestEditorFn) (*ExportStocksResponse, error) {
+	rsp, err := c.ExportStocks(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportStocksResponse(rsp)
+}
+
+// ImportStocksWithBodyWithResponse request with arbitrary body returning *ImportStocksResponse
+func (c *ClientWithResponses) ImportStocksWithBodyWithResponse(ctx context.Context, params *ImportStocksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportStocksResponse, error) {
+	rsp, err := c.ImportStocksWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportStocksResponse(rsp)
+}
+
+// DeleteStockWithResponse request returning *DeleteStockResponse
+func (c *ClientWithResponses) DeleteStockWithResponse(ctx context.Context, name string, params *DeleteStockParams, reqEditors ...RequestEditorFn) (*DeleteStockResponse, error) {
+	rsp, err := c.DeleteStock(ctx, name, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteStockResponse(rsp)
+}
+
+// GetStockByNameWithResponse request returning *GetStockByNameResponse
+func (c *ClientWithResponses) GetStockByNameWithResponse(ctx context.Context, name string, params *GetStockByNameParams, reqEditors ...RequestEditorFn) (*GetStockByNameResponse, error) {
+	rsp, err := c.GetStockByName(ctx, name, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetStockByNameResponse(rsp)
+}
+
+// AdjustStockWithBodyWithResponse request with arbitrary body returning *AdjustStockResponse
+func (c *ClientWithResponses) AdjustStockWithBodyWithResponse(ctx context.Context, name string, params *AdjustStockParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AdjustStockResponse, error) {
+	rsp, err := c.AdjustStockWithBody(ctx, name, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdjustStockResponse(rsp)
+}
+
+func (c *ClientWithResponses) AdjustStockWithResponse(ctx context.Context, name string, params *AdjustStockParams, body AdjustStockJSONRequestBody, reqEditors ...RequestEditorFn) (*AdjustStockResponse, error) {
+	rsp, err := c.AdjustStock(ctx, name, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdjustStockResponse(rsp)
+}
+
+// SetStockWithBodyWithResponse request with arbitrary body returning *SetStockResponse
+func (c *ClientWithResponses) SetStockWithBodyWithResponse(ctx context.Context, name string, params *SetStockParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetStockResponse, error) {
+	rsp, err := c.SetStockWithBody(ctx, name, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetStockResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetStockWithResponse(ctx context.Context, name string, params *SetStockParams, body SetStockJSONRequestBody, reqEditors ...RequestEditorFn) (*SetStockResponse, error) {
+	rsp, err := c.SetStock(ctx, name, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetStockResponse(rsp)
+}
+
+// AllocateStockWithBodyWithResponse request with arbitrary body returning *AllocateStockResponse
+func (c *ClientWithResponses) AllocateStockWithBodyWithResponse(ctx context.Context, name string, params *AllocateStockParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AllocateStockResponse, error) {
+	rsp, err := c.AllocateStockWithBody(ctx, name, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAllocateStockResponse(rsp)
+}
+
+func (c *ClientWithResponses) AllocateStockWithResponse(ctx context.Context, name string, params *AllocateStockParams, body AllocateStockJSONRequestBody, reqEditors ...RequestEditorFn) (*AllocateStockResponse, error) {
+	rsp, err := c.AllocateStock(ctx, name, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAllocateStockResponse(rsp)
+}
+
+// GetStockHistoryWithResponse request returning *GetStockHistoryResponse
+func (c *ClientWithResponses) GetStockHistoryWithResponse(ctx context.Context, name string, params *GetStockHistoryParams, reqEditors ...RequestEditorFn) (*GetStockHistoryResponse, error) {
+	rsp, err := c.GetStockHistory(ctx, name, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetStockHistoryResponse(rsp)
+}
+
+// GetStockLocationsWithResponse request returning *GetStockLocationsResponse
+func (c *ClientWithResponses) GetStockLocationsWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*GetStockLocationsResponse, error) {
+	rsp, err := c.GetStockLocations(ctx, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetStockLocationsResponse(rsp)
+}
+
+// UnlinkStockProductWithResponse request returning *UnlinkStockProductResponse
+func (c *ClientWithResponses) UnlinkStockProductWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*UnlinkStockProductResponse, error) {
+	rsp, err := c.UnlinkStockProduct(ctx, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnlinkStockProductResponse(rsp)
+}
+
+// LinkStockProductWithBodyWithResponse request with arbitrary body returning *LinkStockProductResponse
+func (c *ClientWithResponses) LinkStockProductWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LinkStockProductResponse, error) {
+	rsp, err := c.LinkStockProductWithBody(ctx, name, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLinkStockProductResponse(rsp)
+}
+
+func (c *ClientWithResponses) LinkStockProductWithResponse(ctx context.Context, name string, body LinkStockProductJSONRequestBody, reqEditors ...RequestEditorFn) (*LinkStockProductResponse, error) {
+	rsp, err := c.LinkStockProduct(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLinkStockProductResponse(rsp)
+}
+
+// CreateReservationWithBodyWithResponse request with arbitrary body returning *CreateReservationResponse
+func (c *ClientWithResponses) CreateReservationWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateReservationResponse, error) {
+	rsp, err := c.CreateReservationWithBody(ctx, name, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateReservationResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateReservationWithResponse(ctx context.Context, name string, body CreateReservationJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateReservationResponse, error) {
+	rsp, err := c.CreateReservation(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateReservationResponse(rsp)
+}
+
+// RestoreStockWithResponse request returning *RestoreStockResponse
+func (c *ClientWithResponses) RestoreStockWithResponse(ctx context.Context, name string, params *RestoreStockParams, reqEditors ...RequestEditorFn) (*RestoreStockResponse, error) {
+	rsp, err := c.RestoreStock(ctx, name, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRestoreStockResponse(rsp)
+}
+
+// GetStockThresholdsWithResponse request returning *GetStockThresholdsResponse
+func (c *ClientWithResponses) GetStockThresholdsWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*GetStockThresholdsResponse, error) {
+	rsp, err := c.GetStockThresholds(ctx, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetStockThresholdsResponse(rsp)
+}
+
+// PutStockThresholdsWithBodyWithResponse request with arbitrary body returning *PutStockThresholdsResponse
+func (c *ClientWithResponses) PutStockThresholdsWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutStockThresholdsResponse, error) {
+	rsp, err := c.PutStockThresholdsWithBody(ctx, name, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutStockThresholdsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutStockThresholdsWithResponse(ctx context.Context, name string, body PutStockThresholdsJSONRequestBody, reqEditors ...RequestEditorFn) (*PutStockThresholdsResponse, error) {
+	rsp, err := c.PutStockThresholds(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutStockThresholdsResponse(rsp)
+}
+
+// BatchCreateOrUpdateStocksWithBodyWithResponse request with arbitrary body returning *BatchCreateOrUpdateStocksResponse
+func (c *ClientWithResponses) BatchCreateOrUpdateStocksWithBodyWithResponse(ctx context.Context, params *BatchCreateOrUpdateStocksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BatchCreateOrUpdateStocksResponse, error) {
+	rsp, err := c.BatchCreateOrUpdateStocksWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBatchCreateOrUpdateStocksResponse(rsp)
+}
+
+func (c *ClientWithResponses) BatchCreateOrUpdateStocksWithResponse(ctx context.Context, params *BatchCreateOrUpdateStocksParams, body BatchCreateOrUpdateStocksJSONRequestBody, reqEditors ...RequestEditorFn) (*BatchCreateOrUpdateStocksResponse, error) {
+	rsp, err := c.BatchCreateOrUpdateStocks(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBatchCreateOrUpdateStocksResponse(rsp)
+}
+
+// TransferStockWithBodyWithResponse request with arbitrary body returning *TransferStockResponse
+func (c *ClientWithResponses) TransferStockWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TransferStockResponse, error) {
+	rsp, err := c.TransferStockWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTransferStockResponse(rsp)
+}
+
+func (c *ClientWithResponses) TransferStockWithResponse(ctx context.Context, body TransferStockJSONRequestBody, reqEditors ...RequestEditorFn) (*TransferStockResponse, error) {
+	rsp, err := c.TransferStock(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTransferStockResponse(rsp)
+}
+
+// GetWebhooksWithResponse request returning *GetWebhooksResponse
+func (c *ClientWithResponses) GetWebhooksWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWebhooksResponse, error) {
+	rsp, err := c.GetWebhooks(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWebhooksResponse(rsp)
+}
+
+// CreateWebhookWithBodyWithResponse request with arbitrary body returning *CreateWebhookResponse
+func (c *ClientWithResponses) CreateWebhookWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateWebhookResponse, error) {
+	rsp, err := c.CreateWebhookWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateWebhookResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateWebhookWithResponse(ctx context.Context, body CreateWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateWebhookResponse, error) {
+	rsp, err := c.CreateWebhook(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateWebhookResponse(rsp)
+}
+
+// DeleteWebhookWithResponse request returning *DeleteWebhookResponse
+func (c *ClientWithResponses) DeleteWebhookWithResponse(ctx context.Context, id WebhookId, reqEditors ...RequestEditorFn) (*DeleteWebhookResponse, error) {
+	rsp, err := c.DeleteWebhook(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteWebhookResponse(rsp)
+}
+
+// GetWebhookWithResponse request returning *GetWebhookResponse
+func (c *ClientWithResponses) GetWebhookWithResponse(ctx context.Context, id WebhookId, reqEditors ...RequestEditorFn) (*GetWebhookResponse, error) {
+	rsp, err := c.GetWebhook(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWebhookResponse(rsp)
+}
+
+// GetWebhookDeliveriesWithResponse request returning *GetWebhookDeliveriesResponse
+func (c *ClientWithResponses) GetWebhookDeliveriesWithResponse(ctx context.Context, id WebhookId, params *GetWebhookDeliveriesParams, reqEditors ...RequestEditorFn) (*GetWebhookDeliveriesResponse, error) {
+	rsp, err := c.GetWebhookDeliveries(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWebhookDeliveriesResponse(rsp)
+}
+
+// ParseGetAlertsResponse parses an HTTP response from a GetAlertsWithResponse call
+func ParseGetAlertsResponse(rsp *http.Response) (*GetAlertsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAlertsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AlertPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAcknowledgeAlertResponse parses an HTTP response from a AcknowledgeAlertWithResponse call
+func ParseAcknowledgeAlertResponse(rsp *http.Response) (*AcknowledgeAlertResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AcknowledgeAlertResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Alert
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetLocationsResponse parses an HTTP response from a GetLocationsWithResponse call
+func ParseGetLocationsResponse(rsp *http.Response) (*GetLocationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLocationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LocationList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateLocationResponse parses an HTTP response from a CreateLocationWithResponse call
+func ParseCreateLocationResponse(rsp *http.Response) (*CreateLocationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateLocationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Location
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetLocationStocksResponse parses an HTTP response from a GetLocationStocksWithResponse call
+func ParseGetLocationStocksResponse(rsp *http.Response) (*GetLocationStocksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLocationStocksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StockLevelPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetStockLevelResponse parses an HTTP response from a GetStockLevelWithResponse call
+func ParseGetStockLevelResponse(rsp *http.Response) (*GetStockLevelResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetStockLevelResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StockLevel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetStockLevelResponse parses an HTTP response from a SetStockLevelWithResponse call
+func ParseSetStockLevelResponse(rsp *http.Response) (*SetStockLevelResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetStockLevelResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StockLevel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateOrderResponse parses an HTTP response from a CreateOrderWithResponse call
+func ParseCreateOrderResponse(rsp *http.Response) (*CreateOrderResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateOrderResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Order
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest OrderShortageResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetOrderResponse parses an HTTP response from a GetOrderWithResponse call
+func ParseGetOrderResponse(rsp *http.Response) (*GetOrderResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetOrderResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Order
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRelayOutboxResponse parses an HTTP response from a RelayOutboxWithResponse call
+func ParseRelayOutboxResponse(rsp *http.Response) (*RelayOutboxResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RelayOutboxResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RelayResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest RelayResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest RelayResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetProductsResponse parses an HTTP response from a GetProductsWithResponse call
+func ParseGetProductsResponse(rsp *http.Response) (*GetProductsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetProductsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ProductPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateProductResponse parses an HTTP response from a CreateProductWithResponse call
+func ParseCreateProductResponse(rsp *http.Response) (*CreateProductResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateProductResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Product
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetProductResponse parses an HTTP response from a GetProductWithResponse call
+func ParseGetProductResponse(rsp *http.Response) (*GetProductResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetProductResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Product
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateProductResponse parses an HTTP response from a UpdateProductWithResponse call
+func ParseUpdateProductResponse(rsp *http.Response) (*UpdateProductResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateProductResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Product
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetReservationResponse parses an HTTP response from a GetReservationWithResponse call
+func ParseGetReservationResponse(rsp *http.Response) (*GetReservationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetReservationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Reservation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCommitReservationResponse parses an HTTP response from a CommitReservationWithResponse call
+func ParseCommitReservationResponse(rsp *http.Response) (*CommitReservationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CommitReservationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Reservation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ReservationConflictResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReleaseReservationResponse parses an HTTP response from a ReleaseReservationWithResponse call
+func ParseReleaseReservationResponse(rsp *http.Response) (*ReleaseReservationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReleaseReservationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Reservation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ReservationConflictResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAllStocksResponse parses an HTTP response from a GetAllStocksWithResponse call
+func ParseGetAllStocksResponse(rsp *http.Response) (*GetAllStocksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAllStocksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			union json.RawMessage
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateOrUpdateStockResponse parses an HTTP response from a CreateOrUpdateStockWithResponse call
+func ParseCreateOrUpdateStockResponse(rsp *http.Response) (*CreateOrUpdateStockResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateOrUpdateStockResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Stock
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetStockByBarcodeResponse parses an HTTP response from a GetStockByBarcodeWithResponse call
+func ParseGetStockByBarcodeResponse(rsp *http.Response) (*GetStockByBarcodeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetStockByBarcodeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Stock
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BarcodeErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest BarcodeErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest BarcodeErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseExportStocksResponse parses an HTTP response from a ExportStocksWithResponse call
+func ParseExportStocksResponse(rsp *http.Response) (*ExportStocksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExportStocksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseImportStocksResponse parses an HTTP response from a ImportStocksWithResponse call
+func ParseImportStocksResponse(rsp *http.Response) (*ImportStocksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ImportStocksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ImportResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ImportResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteStockResponse parses an HTTP response from a DeleteStockWithResponse call
+func ParseDeleteStockResponse(rsp *http.Response) (*DeleteStockResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteStockResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetStockByNameResponse parses an HTTP response from a GetStockByNameWithResponse call
+func ParseGetStockByNameResponse(rsp *http.Response) (*GetStockByNameResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetStockByNameResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			union json.RawMessage
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAdjustStockResponse parses an HTTP response from a AdjustStockWithResponse call
+func ParseAdjustStockResponse(rsp *http.Response) (*AdjustStockResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AdjustStockResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AdjustmentResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetStockResponse parses an HTTP response from a SetStockWithResponse call
+func ParseSetStockResponse(rsp *http.Response) (*SetStockResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetStockResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Stock
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Stock
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAllocateStockResponse parses an HTTP response from a AllocateStockWithResponse call
+func ParseAllocateStockResponse(rsp *http.Response) (*AllocateStockResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AllocateStockResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AllocationResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest InsufficientStockResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetStockHistoryResponse parses an HTTP response from a GetStockHistoryWithResponse call
+func ParseGetStockHistoryResponse(rsp *http.Response) (*GetStockHistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetStockHistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MovementHistory
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetStockLocationsResponse parses an HTTP response from a GetStockLocationsWithResponse call
+func ParseGetStockLocationsResponse(rsp *http.Response) (*GetStockLocationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetStockLocationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StockLocations
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUnlinkStockProductResponse parses an HTTP response from a UnlinkStockProductWithResponse call
+func ParseUnlinkStockProductResponse(rsp *http.Response) (*UnlinkStockProductResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UnlinkStockProductResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLinkStockProductResponse parses an HTTP response from a LinkStockProductWithResponse call
+func ParseLinkStockProductResponse(rsp *http.Response) (*LinkStockProductResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LinkStockProductResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Stock
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateReservationResponse parses an HTTP response from a CreateReservationWithResponse call
+func ParseCreateReservationResponse(rsp *http.Response) (*CreateReservationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateReservationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Reservation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest InsufficientStockResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRestoreStockResponse parses an HTTP response from a RestoreStockWithResponse call
+func ParseRestoreStockResponse(rsp *http.Response) (*RestoreStockResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RestoreStockResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Stock
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetStockThresholdsResponse parses an HTTP response from a GetStockThresholdsWithResponse call
+func ParseGetStockThresholdsResponse(rsp *http.Response) (*GetStockThresholdsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetStockThresholdsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StockThresholds
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutStockThresholdsResponse parses an HTTP response from a PutStockThresholdsWithResponse call
+func ParsePutStockThresholdsResponse(rsp *http.Response) (*PutStockThresholdsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutStockThresholdsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StockThresholds
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseBatchCreateOrUpdateStocksResponse parses an HTTP response from a BatchCreateOrUpdateStocksWithResponse call
+func ParseBatchCreateOrUpdateStocksResponse(rsp *http.Response) (*BatchCreateOrUpdateStocksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BatchCreateOrUpdateStocksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BatchResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BatchResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest BatchFailureResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest BatchFailureResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest BatchFailureResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTransferStockResponse parses an HTTP response from a TransferStockWithResponse call
+func ParseTransferStockResponse(rsp *http.Response) (*TransferStockResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TransferStockResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Transfer
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetWebhooksResponse parses an HTTP response from a GetWebhooksWithResponse call
+func ParseGetWebhooksResponse(rsp *http.Response) (*GetWebhooksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetWebhooksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WebhookList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateWebhookResponse parses an HTTP response from a CreateWebhookWithResponse call
+func ParseCreateWebhookResponse(rsp *http.Response) (*CreateWebhookResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateWebhookResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Webhook
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteWebhookResponse parses an HTTP response from a DeleteWebhookWithResponse call
+func ParseDeleteWebhookResponse(rsp *http.Response) (*DeleteWebhookResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteWebhookResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetWebhookResponse parses an HTTP response from a GetWebhookWithResponse call
+func ParseGetWebhookResponse(rsp *http.Response) (*GetWebhookResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetWebhookResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Webhook
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetWebhookDeliveriesResponse parses an HTTP response from a GetWebhookDeliveriesWithResponse call
+func ParseGetWebhookDeliveriesResponse(rsp *http.Response) (*GetWebhookDeliveriesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetWebhookDeliveriesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WebhookDeliveryPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 504:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON504 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// 在庫アラートの一覧を取得
+	// (GET /alerts)
+	GetAlerts(c *gin.Context, params GetAlertsParams)
+	// 在庫アラートを確認済みにする
+	// (POST /alerts/{id}/acknowledge)
+	AcknowledgeAlert(c *gin.Context, id int64)
+	// ロケーションの一覧を取得
+	// (GET /locations)
+	GetLocations(c *gin.Context)
+	// ロケーションを登録
+	// (POST /locations)
+	CreateLocation(c *gin.Context)
+	// ロケーションの在庫を取得
+	// (GET /locations/{code}/stocks)
+	GetLocationStocks(c *gin.Context, code LocationCode, params GetLocationStocksParams)
+	// ロケーションの在庫数を取得
+	// (GET /locations/{code}/stocks/{name})
+	GetStockLevel(c *gin.Context, code LocationCode, name string)
+	// ロケーションの在庫数を設定
+	// (PUT /locations/{code}/stocks/{name})
+	SetStockLevel(c *gin.Context, code LocationCode, name string)
+	// 注文を作成
+	// (POST /orders)
+	CreateOrder(c *gin.Context)
+	// 注文を取得
+	// (GET /orders/{id})
+	GetOrder(c *gin.Context, id string)
+	// outbox のイベントを配信
+	// (POST /outbox/relay)
+	RelayOutbox(c *gin.Context)
+	// 商品の一覧を取得
+	// (GET /products)
+	GetProducts(c *gin.Context, params GetProductsParams)
+	// 商品を登録
+	// (POST /products)
+	CreateProduct(c *gin.Context)
+	// 商品を取得
+	// (GET /products/{id})
+	GetProduct(c *gin.Context, id ProductId)
+	// 商品を変更
+	// (PATCH /products/{id})
+	UpdateProduct(c *gin.Context, id ProductId)
+	// 引当予約を取得
+	// (GET /reservations/{id})
+	GetReservation(c *gin.Context, id ReservationId)
+	// 引当予約を確定
+	// (POST /reservations/{id}/commit)
+	CommitReservation(c *gin.Context, id ReservationId)
+	// 引当予約を解放
+	// (POST /reservations/{id}/release)
+	ReleaseReservation(c *gin.Context, id ReservationId)
+	// 在庫の一覧を取得
+	// (GET /stocks)
+	GetAllStocks(c *gin.Context, params GetAllStocksParams)
+	// 在庫を登録または更新
+	// (POST /stocks)
+	CreateOrUpdateStock(c *gin.Context, params CreateOrUpdateStockParams)
+	// バーコードで在庫を取得
+	// (GET /stocks/by-barcode/{code})
+	GetStockByBarcode(c *gin.Context, code string)
+	// 在庫を CSV でエクスポート
+	// (GET /stocks/export.csv)
+	ExportStocks(c *gin.Context, params ExportStocksParams)
+	// CSV から在庫を取り込む
+	// (POST /stocks/import)
+	ImportStocks(c *gin.Context, params ImportStocksParams)
+	// 在庫を削除
+	// (DELETE /stocks/{name})
+	DeleteStock(c *gin.Context, name string, params DeleteStockParams)
+	// 指定した名前の在庫を取得
+	// (GET /stocks/{name})
+	GetStockByName(c *gin.Context, name string, params GetStockByNameParams)
+	// 在庫数を調整
+	// (PATCH /stocks/{name})
+	AdjustStock(c *gin.Context, name string, params AdjustStockParams)
+	// 在庫数を指定した値に設定
+	// (PUT /stocks/{name})
+	SetStock(c *gin.Context, name string, params SetStockParams)
+	// 在庫を引き当て
+	// (POST /stocks/{name}/allocate)
+	AllocateStock(c *gin.Context, name string, params AllocateStockParams)
+	// 在庫移動の履歴を取得
+	// (GET /stocks/{name}/history)
+	GetStockHistory(c *gin.Context, name string, params GetStockHistoryParams)
+	// 在庫のロケーション別の在庫数を取得
+	// (GET /stocks/{name}/locations)
+	GetStockLocations(c *gin.Context, name string)
+	// 在庫と商品の紐付けを解除
+	// (DELETE /stocks/{name}/product)
+	UnlinkStockProduct(c *gin.Context, name string)
+	// 在庫を商品に紐付ける
+	// (PUT /stocks/{name}/product)
+	LinkStockProduct(c *gin.Context, name string)
+	// 在庫の引当予約を作成
+	// (POST /stocks/{name}/reservations)
+	CreateReservation(c *gin.Context, name string)
+	// 削除した在庫を元に戻す
+	// (POST /stocks/{name}/restore)
+	RestoreStock(c *gin.Context, name string, params RestoreStockParams)
+	// 在庫のしきい値を取得
+	// (GET /stocks/{name}/thresholds)
+	GetStockThresholds(c *gin.Context, name string)
+	// 在庫のしきい値を設定
+	// (PUT /stocks/{name}/thresholds)
+	PutStockThresholds(c *gin.Context, name string)
+	// 在庫を一括で登録または更新
+	// (POST /stocks:batch)
+	BatchCreateOrUpdateStocks(c *gin.Context, params BatchCreateOrUpdateStocksParams)
+	// ロケーション間で在庫を移動
+	// (POST /transfers)
+	TransferStock(c *gin.Context)
+	// Webhook の一覧を取得
+	// (GET /webhooks)
+	GetWebhooks(c *gin.Context)
+	// Webhook を登録
+	// (POST /webhooks)
+	CreateWebhook(c *gin.Context)
+	// Webhook を削除
+	// (DELETE /webhooks/{id})
+	DeleteWebhook(c *gin.Context, id WebhookId)
+	// Webhook を取得
+	// (GET /webhooks/{id})
+	GetWebhook(c *gin.Context, id WebhookId)
+	// Webhook の配信ログを取得
+	// (GET /webhooks/{id}/deliveries)
+	GetWebhookDeliveries(c *gin.Context, id WebhookId, params GetWebhookDeliveriesParams)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandler       func(*gin.Context, error, int)
+}
+
+type MiddlewareFunc func(c *gin.Context)
+
+// GetAlerts operation middleware
+func (siw *ServerInterfaceWrapper) GetAlerts(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAlertsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", c.Request.URL.Query(), &params.Name)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAlerts(c, params)
+}
+
+// AcknowledgeAlert operation middleware
+func (siw *ServerInterfaceWrapper) AcknowledgeAlert(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AcknowledgeAlert(c, id)
+}
+
+// GetLocations operation middleware
+func (siw *ServerInterfaceWrapper) GetLocations(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetLocations(c)
+}
+
+// CreateLocation operation middleware
+func (siw *ServerInterfaceWrapper) CreateLocation(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateLocation(c)
+}
+
+// GetLocationStocks operation middleware
+func (siw *ServerInterfaceWrapper) GetLocationStocks(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code LocationCode
+
+	err = runtime.BindStyledParameterWithOptions("simple", "code", c.Param("code"), &code, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter code: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLocationStocksParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetLocationStocks(c, code, params)
+}
+
+// GetStockLevel operation middleware
+func (siw *ServerInterfaceWrapper) GetStockLevel(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code LocationCode
+
+	err = runtime.BindStyledParameterWithOptions("simple", "code", c.Param("code"), &code, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter code: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetStockLevel(c, code, name)
+}
+
+// SetStockLevel operation middleware
+func (siw *ServerInterfaceWrapper) SetStockLevel(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code LocationCode
+
+	err = runtime.BindStyledParameterWithOptions("simple", "code", c.Param("code"), &code, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter code: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SetStockLevel(c, code, name)
+}
+
+// CreateOrder operation middleware
+func (siw *ServerInterfaceWrapper) CreateOrder(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateOrder(c)
+}
+
+// GetOrder operation middleware
+func (siw *ServerInterfaceWrapper) GetOrder(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetOrder(c, id)
+}
+
+// RelayOutbox operation middleware
+func (siw *ServerInterfaceWrapper) RelayOutbox(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RelayOutbox(c)
+}
+
+// GetProducts operation middleware
+func (siw *ServerInterfaceWrapper) GetProducts(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProductsParams
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", c.Request.URL.Query(), &params.Category)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter category: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetProducts(c, params)
+}
+
+// CreateProduct operation middleware
+func (siw *ServerInterfaceWrapper) CreateProduct(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateProduct(c)
+}
+
+// GetProduct operation middleware
+func (siw *ServerInterfaceWrapper) GetProduct(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ProductId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetProduct(c, id)
+}
+
+// UpdateProduct operation middleware
+func (siw *ServerInterfaceWrapper) UpdateProduct(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ProductId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateProduct(c, id)
+}
+
+// GetReservation operation middleware
+func (siw *ServerInterfaceWrapper) GetReservation(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ReservationId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetReservation(c, id)
+}
+
+// CommitReservation operation middleware
+func (siw *ServerInterfaceWrapper) CommitReservation(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ReservationId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CommitReservation(c, id)
+}
+
+// ReleaseReservation operation middleware
+func (siw *ServerInterfaceWrapper) ReleaseReservation(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ReservationId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ReleaseReservation(c, id)
+}
+
+// GetAllStocks operation middleware
+func (siw *ServerInterfaceWrapper) GetAllStocks(c *gin.Context) {
 
-	// Name 在庫の名前
-	Name string `json:"name"`
-}
+	var err error
 
-// StockRequest defines model for StockRequest.
-type StockRequest struct {
-	// Amount 在庫の数量
-	Amount *int `json:"amount,omitempty"`
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAllStocksParams
 
-	// Name 在庫の名前
-	Name string `json:"name"`
-}
+	// ------------- Optional query parameter "prefix" -------------
 
-// StocksResponse 在庫のリスト
-type StocksResponse = []Stock
+	err = runtime.BindQueryParameter("form", true, false, "prefix", c.Request.URL.Query(), &params.Prefix)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter prefix: %w", err), http.StatusBadRequest)
+		return
+	}
 
-// CreateOrUpdateStockJSONRequestBody defines body for CreateOrUpdateStock for application/json ContentType.
-type CreateOrUpdateStockJSONRequestBody = StockRequest
+	// ------------- Optional query parameter "contains" -------------
 
-// RequestEditorFn  is the function signature for the RequestEditor callback function
-type RequestEditorFn func(ctx context.Context, req *http.Request) error
+	err = runtime.BindQueryParameter("form", true, false, "contains", c.Request.URL.Query(), &params.Contains)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter contains: %w", err), http.StatusBadRequest)
+		return
+	}
 
-// Doer performs HTTP requests.
-//
-// The standard http.Client implements this interface.
-type HttpRequestDoer interface {
-	Do(req *http.Request) (*http.Response, error)
-}
+	// ------------- Optional query parameter "min_amount" -------------
 
-// Client which conforms to the OpenAPI3 specification for this service.
-type Client struct {
-	// The endpoint of the server conforming to this interface, with scheme,
-	// https://api.deepmap.com for example. This can contain a path relative
-	// to the server, such as https://api.deepmap.com/dev-test, and all the
-	// paths in the swagger spec will be appended to the server.
-	Server string
+	err = runtime.BindQueryParameter("form", true, false, "min_amount", c.Request.URL.Query(), &params.MinAmount)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter min_amount: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	// Doer for performing requests, typically a *http.Client with any
-	// customized settings, such as certificate chains.
-	Client HttpRequestDoer
+	// ------------- Optional query parameter "max_amount" -------------
 
-	// A list of callbacks for modifying requests which are generated before sending over
-	// the network.
-	RequestEditors []RequestEditorFn
-}
+	err = runtime.BindQueryParameter("form", true, false, "max_amount", c.Request.URL.Query(), &params.MaxAmount)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter max_amount: %w", err), http.StatusBadRequest)
+		return
+	}
 
-// ClientOption allows setting custom parameters during construction
-type ClientOption func(*Client) error
+	// ------------- Optional query parameter "updated_since" -------------
 
-// Creates a new Client, with reasonable defaults
-func NewClient(server string, opts ...ClientOption) (*Client, error) {
-	// create a client with sane default values
-	client := Client{
-		Server: server,
+	err = runtime.BindQueryParameter("form", true, false, "updated_since", c.Request.URL.Query(), &params.UpdatedSince)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter updated_since: %w", err), http.StatusBadRequest)
+		return
 	}
-	// mutate client and add all optional params
-	for _, o := range opts {
-		if err := o(&client); err != nil {
-			return nil, err
-		}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort: %w", err), http.StatusBadRequest)
+		return
 	}
-	// ensure the server URL always has a trailing slash
-	if !strings.HasSuffix(client.Server, "/") {
-		client.Server += "/"
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
 	}
-	// create httpClient, if not already present
-	if client.Client == nil {
-		client.Client = &http.Client{}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
 	}
-	return &client, nil
-}
 
-// WithHTTPClient allows overriding the default Doer, which is
-// automatically created using http.Client. This is useful for tests.
-func WithHTTPClient(doer HttpRequestDoer) ClientOption {
-	return func(c *Client) error {
-		c.Client = doer
-		return nil
+	// ------------- Optional query parameter "include_deleted" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_deleted", c.Request.URL.Query(), &params.IncludeDeleted)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter include_deleted: %w", err), http.StatusBadRequest)
+		return
 	}
-}
 
-// WithRequestEditorFn allows setting up a callback function, which will be
-// called right before sending the request. This can be used to mutate the request.
-func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
-	return func(c *Client) error {
-		c.RequestEditors = append(c.RequestEditors, fn)
-		return nil
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
+
+	siw.Handler.GetAllStocks(c, params)
 }
 
-// The interface specification for the client above.
-type ClientInterface interface {
-	// GetAllStocks request
-	GetAllStocks(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+// CreateOrUpdateStock operation middleware
+func (siw *ServerInterfaceWrapper) CreateOrUpdateStock(c *gin.Context) {
 
-	// CreateOrUpdateStockWithBody request with any body
-	CreateOrUpdateStockWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+	var err error
 
-	CreateOrUpdateStock(ctx context.Context, body CreateOrUpdateStockJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateOrUpdateStockParams
 
-	// GetStockByName request
-	GetStockByName(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Idempotency-Key, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Idempotency-Key: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateOrUpdateStock(c, params)
 }
 
-func (c *Client) GetAllStocks(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetAllStocksRequest(c.Server)
+// GetStockByBarcode operation middleware
+func (siw *ServerInterfaceWrapper) GetStockByBarcode(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "code", c.Param("code"), &code, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return nil, err
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter code: %w", err), http.StatusBadRequest)
+		return
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
-	return c.Client.Do(req)
+
+	siw.Handler.GetStockByBarcode(c, code)
 }
 
-func (c *Client) CreateOrUpdateStockWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewCreateOrUpdateStockRequestWithBody(c.Server, contentType, body)
+// ExportStocks operation middleware
+func (siw *ServerInterfaceWrapper) ExportStocks(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportStocksParams
+
+	// ------------- Optional query parameter "include_deleted" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_deleted", c.Request.URL.Query(), &params.IncludeDeleted)
 	if err != nil {
-		return nil, err
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter include_deleted: %w", err), http.StatusBadRequest)
+		return
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
-	return c.Client.Do(req)
+
+	siw.Handler.ExportStocks(c, params)
 }
 
-func (c *Client) CreateOrUpdateStock(ctx context.Context, body CreateOrUpdateStockJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewCreateOrUpdateStockRequest(c.Server, body)
+// ImportStocks operation middleware
+func (siw *ServerInterfaceWrapper) ImportStocks(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ImportStocksParams
+
+	// ------------- Optional query parameter "mode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mode", c.Request.URL.Query(), &params.Mode)
 	if err != nil {
-		return nil, err
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter mode: %w", err), http.StatusBadRequest)
+		return
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
+
+	// ------------- Optional query parameter "dry_run" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dry_run", c.Request.URL.Query(), &params.DryRun)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter dry_run: %w", err), http.StatusBadRequest)
+		return
 	}
-	return c.Client.Do(req)
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ImportStocks(c, params)
 }
 
-func (c *Client) GetStockByName(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetStockByNameRequest(c.Server, name)
+// DeleteStock operation middleware
+func (siw *ServerInterfaceWrapper) DeleteStock(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return nil, err
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteStockParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
 	}
-	return c.Client.Do(req)
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteStock(c, name, params)
 }
 
-// NewGetAllStocksRequest generates requests for GetAllStocks
-func NewGetAllStocksRequest(server string) (*http.Request, error) {
+// GetStockByName operation middleware
+func (siw *ServerInterfaceWrapper) GetStockByName(c *gin.Context) {
+
 	var err error
 
-	serverURL, err := url.Parse(server)
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return nil, err
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	operationPath := fmt.Sprintf("/stocks")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStockByNameParams
 
-	queryURL, err := serverURL.Parse(operationPath)
+	// ------------- Optional query parameter "include_deleted" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_deleted", c.Request.URL.Query(), &params.IncludeDeleted)
 	if err != nil {
-		return nil, err
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter include_deleted: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
 
-	return req, nil
+	siw.Handler.GetStockByName(c, name, params)
 }
 
-// NewCreateOrUpdateStockRequest calls the generic CreateOrUpdateStock builder with application/json body
-func NewCreateOrUpdateStockRequest(server string, body CreateOrUpdateStockJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader = bytes.NewReader(buf)
-	return NewCreateOrUpdateStockRequestWithBody(server, "application/json", bodyReader)
-}
+// AdjustStock operation middleware
+func (siw *ServerInterfaceWrapper) AdjustStock(c *gin.Context) {
 
-// NewCreateOrUpdateStockRequestWithBody generates requests for CreateOrUpdateStock with any type of body
-func NewCreateOrUpdateStockRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return nil, err
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	operationPath := fmt.Sprintf("/stocks")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdjustStockParams
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
-	}
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfMatch = &IfMatch
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
-		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	return req, nil
+	siw.Handler.AdjustStock(c, name, params)
 }
 
-// NewGetStockByNameRequest generates requests for GetStockByName
-func NewGetStockByNameRequest(server string, name string) (*http.Request, error) {
+// SetStock operation middleware
+func (siw *ServerInterfaceWrapper) SetStock(c *gin.Context) {
+
 	var err error
 
-	var pathParam0 string
+	// ------------- Path parameter "name" -------------
+	var name string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return nil, err
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SetStockParams
 
-	operationPath := fmt.Sprintf("/stocks/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+	// ------------- Optional query parameter "create_only" -------------
 
-	queryURL, err := serverURL.Parse(operationPath)
+	err = runtime.BindQueryParameter("form", true, false, "create_only", c.Request.URL.Query(), &params.CreateOnly)
 	if err != nil {
-		return nil, err
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter create_only: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
+	headers := c.Request.Header
 
-	return req, nil
-}
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
 		}
+
+		params.IfMatch = &IfMatch
+
 	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
 		}
 	}
-	return nil
-}
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
+	siw.Handler.SetStock(c, name, params)
 }
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+// AllocateStock operation middleware
+func (siw *ServerInterfaceWrapper) AllocateStock(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return nil, err
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AllocateStockParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
 		if err != nil {
-			return err
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
 		}
-		c.Server = newBaseURL.String()
-		return nil
 	}
+
+	siw.Handler.AllocateStock(c, name, params)
 }
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// GetAllStocksWithResponse request
-	GetAllStocksWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAllStocksResponse, error)
+// GetStockHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetStockHistory(c *gin.Context) {
 
-	// CreateOrUpdateStockWithBodyWithResponse request with any body
-	CreateOrUpdateStockWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateOrUpdateStockResponse, error)
+	var err error
 
-	CreateOrUpdateStockWithResponse(ctx context.Context, body CreateOrUpdateStockJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateOrUpdateStockResponse, error)
+	// ------------- Path parameter "name" -------------
+	var name string
 
-	// GetStockByNameWithResponse request
-	GetStockByNameWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*GetStockByNameResponse, error)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type GetAllStocksResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *struct {
-		union json.RawMessage
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStockHistoryParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
 	}
-	JSON500 *ErrorResponse
-}
 
-// Status returns HTTPResponse.Status
-func (r GetAllStocksResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetAllStocksResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
-	return 0
-}
 
-type CreateOrUpdateStockResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *Stock
-	JSON400      *ErrorResponse
-	JSON500      *ErrorResponse
+	siw.Handler.GetStockHistory(c, name, params)
 }
 
-// Status returns HTTPResponse.Status
-func (r CreateOrUpdateStockResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+// GetStockLocations operation middleware
+func (siw *ServerInterfaceWrapper) GetStockLocations(c *gin.Context) {
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r CreateOrUpdateStockResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
 	}
-	return 0
-}
 
-type GetStockByNameResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *struct {
-		union json.RawMessage
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
-	JSON500 *ErrorResponse
+
+	siw.Handler.GetStockLocations(c, name)
 }
 
-// Status returns HTTPResponse.Status
-func (r GetStockByNameResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// UnlinkStockProduct operation middleware
+func (siw *ServerInterfaceWrapper) UnlinkStockProduct(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetStockByNameResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
-	return 0
+
+	siw.Handler.UnlinkStockProduct(c, name)
 }
 
-// GetAllStocksWithResponse request returning *GetAllStocksResponse
-func (c *ClientWithResponses) GetAllStocksWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAllStocksResponse, error) {
-	rsp, err := c.GetAllStocks(ctx, reqEditors...)
+// LinkStockProduct operation middleware
+func (siw *ServerInterfaceWrapper) LinkStockProduct(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return nil, err
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
 	}
-	return ParseGetAllStocksResponse(rsp)
-}
 
-// CreateOrUpdateStockWithBodyWithResponse request with arbitrary body returning *CreateOrUpdateStockResponse
-func (c *ClientWithResponses) CreateOrUpdateStockWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateOrUpdateStockResponse, error) {
-	rsp, err := c.CreateOrUpdateStockWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
-	return ParseCreateOrUpdateStockResponse(rsp)
+
+	siw.Handler.LinkStockProduct(c, name)
 }
 
-func (c *ClientWithResponses) CreateOrUpdateStockWithResponse(ctx context.Context, body CreateOrUpdateStockJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateOrUpdateStockResponse, error) {
-	rsp, err := c.CreateOrUpdateStock(ctx, body, reqEditors...)
+// CreateReservation operation middleware
+func (siw *ServerInterfaceWrapper) CreateReservation(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return nil, err
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
 	}
-	return ParseCreateOrUpdateStockResponse(rsp)
-}
 
-// GetStockByNameWithResponse request returning *GetStockByNameResponse
-func (c *ClientWithResponses) GetStockByNameWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*GetStockByNameResponse, error) {
-	rsp, err := c.GetStockByName(ctx, name, reqEditors...)
-	if err != nil {
-		return nil, err
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
-	return ParseGetStockByNameResponse(rsp)
+
+	siw.Handler.CreateReservation(c, name)
 }
 
-// ParseGetAllStocksResponse parses an HTTP response from a GetAllStocksWithResponse call
-func ParseGetAllStocksResponse(rsp *http.Response) (*GetAllStocksResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+// RestoreStock operation middleware
+func (siw *ServerInterfaceWrapper) RestoreStock(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return nil, err
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	response := &GetAllStocksResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RestoreStockParams
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest struct {
-			union json.RawMessage
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
 		}
-		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest ErrorResponse
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
 		}
-		response.JSON500 = &dest
+
+		params.IfMatch = &IfMatch
 
 	}
 
-	return response, nil
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RestoreStock(c, name, params)
 }
 
-// ParseCreateOrUpdateStockResponse parses an HTTP response from a CreateOrUpdateStockWithResponse call
-func ParseCreateOrUpdateStockResponse(rsp *http.Response) (*CreateOrUpdateStockResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+// GetStockThresholds operation middleware
+func (siw *ServerInterfaceWrapper) GetStockThresholds(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return nil, err
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	response := &CreateOrUpdateStockResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Stock
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
+	siw.Handler.GetStockThresholds(c, name)
+}
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest ErrorResponse
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON400 = &dest
+// PutStockThresholds operation middleware
+func (siw *ServerInterfaceWrapper) PutStockThresholds(c *gin.Context) {
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest ErrorResponse
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON500 = &dest
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
 
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	return response, nil
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutStockThresholds(c, name)
 }
 
-// ParseGetStockByNameResponse parses an HTTP response from a GetStockByNameWithResponse call
-func ParseGetStockByNameResponse(rsp *http.Response) (*GetStockByNameResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+// BatchCreateOrUpdateStocks operation middleware
+func (siw *ServerInterfaceWrapper) BatchCreateOrUpdateStocks(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params BatchCreateOrUpdateStocksParams
+
+	// ------------- Optional query parameter "atomic" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "atomic", c.Request.URL.Query(), &params.Atomic)
 	if err != nil {
-		return nil, err
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter atomic: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	response := &GetStockByNameResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+	headers := c.Request.Header
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest struct {
-			union json.RawMessage
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Idempotency-Key, got %d", n), http.StatusBadRequest)
+			return
 		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Idempotency-Key: %w", err), http.StatusBadRequest)
+			return
 		}
-		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest ErrorResponse
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
 		}
-		response.JSON500 = &dest
+	}
 
+	siw.Handler.BatchCreateOrUpdateStocks(c, params)
+}
+
+// TransferStock operation middleware
+func (siw *ServerInterfaceWrapper) TransferStock(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
 
-	return response, nil
+	siw.Handler.TransferStock(c)
 }
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// 全ての在庫を取得
-	// (GET /stocks)
-	GetAllStocks(c *gin.Context)
-	// 在庫を登録または更新
-	// (POST /stocks)
-	CreateOrUpdateStock(c *gin.Context)
-	// 指定した名前の在庫を取得
-	// (GET /stocks/{name})
-	GetStockByName(c *gin.Context, name string)
+// GetWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooks(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetWebhooks(c)
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandler       func(*gin.Context, error, int)
+// CreateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhook(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateWebhook(c)
 }
 
-type MiddlewareFunc func(c *gin.Context)
+// DeleteWebhook operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhook(c *gin.Context) {
 
-// GetAllStocks operation middleware
-func (siw *ServerInterfaceWrapper) GetAllStocks(c *gin.Context) {
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id WebhookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -596,11 +9501,22 @@ func (siw *ServerInterfaceWrapper) GetAllStocks(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetAllStocks(c)
+	siw.Handler.DeleteWebhook(c, id)
 }
 
-// CreateOrUpdateStock operation middleware
-func (siw *ServerInterfaceWrapper) CreateOrUpdateStock(c *gin.Context) {
+// GetWebhook operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhook(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id WebhookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -609,20 +9525,39 @@ func (siw *ServerInterfaceWrapper) CreateOrUpdateStock(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.CreateOrUpdateStock(c)
+	siw.Handler.GetWebhook(c, id)
 }
 
-// GetStockByName operation middleware
-func (siw *ServerInterfaceWrapper) GetStockByName(c *gin.Context) {
+// GetWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhookDeliveries(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "name" -------------
-	var name string
+	// ------------- Path parameter "id" -------------
+	var id WebhookId
 
-	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWebhookDeliveriesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -633,7 +9568,7 @@ func (siw *ServerInterfaceWrapper) GetStockByName(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetStockByName(c, name)
+	siw.Handler.GetWebhookDeliveries(c, id, params)
 }
 
 // GinServerOptions provides options for the Gin server.
@@ -663,31 +9598,243 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.GET(options.BaseURL+"/alerts", wrapper.GetAlerts)
+	router.POST(options.BaseURL+"/alerts/:id/acknowledge", wrapper.AcknowledgeAlert)
+	router.GET(options.BaseURL+"/locations", wrapper.GetLocations)
+	router.POST(options.BaseURL+"/locations", wrapper.CreateLocation)
+	router.GET(options.BaseURL+"/locations/:code/stocks", wrapper.GetLocationStocks)
+	router.GET(options.BaseURL+"/locations/:code/stocks/:name", wrapper.GetStockLevel)
+	router.PUT(options.BaseURL+"/locations/:code/stocks/:name", wrapper.SetStockLevel)
+	router.POST(options.BaseURL+"/orders", wrapper.CreateOrder)
+	router.GET(options.BaseURL+"/orders/:id", wrapper.GetOrder)
+	router.POST(options.BaseURL+"/outbox/relay", wrapper.RelayOutbox)
+	router.GET(options.BaseURL+"/products", wrapper.GetProducts)
+	router.POST(options.BaseURL+"/products", wrapper.CreateProduct)
+	router.GET(options.BaseURL+"/products/:id", wrapper.GetProduct)
+	router.PATCH(options.BaseURL+"/products/:id", wrapper.UpdateProduct)
+	router.GET(options.BaseURL+"/reservations/:id", wrapper.GetReservation)
+	router.POST(options.BaseURL+"/reservations/:id/commit", wrapper.CommitReservation)
+	router.POST(options.BaseURL+"/reservations/:id/release", wrapper.ReleaseReservation)
 	router.GET(options.BaseURL+"/stocks", wrapper.GetAllStocks)
 	router.POST(options.BaseURL+"/stocks", wrapper.CreateOrUpdateStock)
+	router.GET(options.BaseURL+"/stocks/by-barcode/:code", wrapper.GetStockByBarcode)
+	router.GET(options.BaseURL+"/stocks/export.csv", wrapper.ExportStocks)
+	router.POST(options.BaseURL+"/stocks/import", wrapper.ImportStocks)
+	router.DELETE(options.BaseURL+"/stocks/:name", wrapper.DeleteStock)
 	router.GET(options.BaseURL+"/stocks/:name", wrapper.GetStockByName)
+	router.PATCH(options.BaseURL+"/stocks/:name", wrapper.AdjustStock)
+	router.PUT(options.BaseURL+"/stocks/:name", wrapper.SetStock)
+	router.POST(options.BaseURL+"/stocks/:name/allocate", wrapper.AllocateStock)
+	router.GET(options.BaseURL+"/stocks/:name/history", wrapper.GetStockHistory)
+	router.GET(options.BaseURL+"/stocks/:name/locations", wrapper.GetStockLocations)
+	router.DELETE(options.BaseURL+"/stocks/:name/product", wrapper.UnlinkStockProduct)
+	router.PUT(options.BaseURL+"/stocks/:name/product", wrapper.LinkStockProduct)
+	router.POST(options.BaseURL+"/stocks/:name/reservations", wrapper.CreateReservation)
+	router.POST(options.BaseURL+"/stocks/:name/restore", wrapper.RestoreStock)
+	router.GET(options.BaseURL+"/stocks/:name/thresholds", wrapper.GetStockThresholds)
+	router.PUT(options.BaseURL+"/stocks/:name/thresholds", wrapper.PutStockThresholds)
+	router.POST(options.BaseURL+"/stocks:batch", wrapper.BatchCreateOrUpdateStocks)
+	router.POST(options.BaseURL+"/transfers", wrapper.TransferStock)
+	router.GET(options.BaseURL+"/webhooks", wrapper.GetWebhooks)
+	router.POST(options.BaseURL+"/webhooks", wrapper.CreateWebhook)
+	router.DELETE(options.BaseURL+"/webhooks/:id", wrapper.DeleteWebhook)
+	router.GET(options.BaseURL+"/webhooks/:id", wrapper.GetWebhook)
+	router.GET(options.BaseURL+"/webhooks/:id/deliveries", wrapper.GetWebhookDeliveries)
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+RWXW8bRRT9K9HA4yp2gLz4iRQqlAdoVcRT5Yfp+sbd4p3ZzowjrGil7m5LAk1o1K80",
-	"bVAoBRRa2S2fSmlpfsx01/RfoDu7a3vjdQo0ipD65JFn9t5zzz33zCwRm7seZ8CUJLUlIu2z4FKzPO56",
-	"qvM+VfQUSI8zCfinJ7gHQjlgjrggJW2aDfiMul4LSI3oaFlHT3W4p4PVuHsr3trRwYYOnungjg6vEYuo",
-	"jofnpBIOaxLfH/zDz5wDWxHfIseF4GJyXsBtXDRA2sLxlMMZZg53dPQjJo/u6ijS4RMDZJdYpfCiTbN4",
-	"nHz1ff/324OPSxEKON92BDRI7XSWvV4C+2PF7U/H4VKXt5lK8S7QdkuR2oy1D3u8tRP/8UAHveTGoxfL",
-	"V0Yhz1QHqRymoAkCczHqwjgFgzDx+lr8xVqhcurh78uqM3EnFncKzrdBqkOvcfZwSjxDGWX0FWuUo8Kb",
-	"kFtH93X4WEcro+lPD2nAnqXwM9p9a7g5O9jL8Pp1izgKXMPkmwIWSI28URlOZiUby0oqsOHEUCFoh/hY",
-	"n8MWOH5uc6aobToBLnVayELb87hQ72ZAp23ukgGEuZPzUzr8TUdfm8FZwehlRfd7d/vrn2PpwbYOAx30",
-	"puZOziPVjjLcG2xTH1JGm+ACU9n2IgiZxpmZrk5XMTz3gFHPITXytvnLIh5VZ03xFWk6gMsmqJIRH5te",
-	"HTzobz55sfqzDm7ocFUHP+jgog4vx5d2zLqXwk+iS/E3P+nw6l9713M/2tQXQmLgCIrx5xukRj4ANddq",
-	"pUIgKJtUCwbSW9VqTjGkYsfmOrb5unJOIsTcQ3HFGZxYMLp4aVOHokOlHHR83Jf9ulFAkamkey/e3Y33",
-	"tvrd60j67L/EfiCEgj2XJDeCeqqjddOj3FjxnGy7LhUdlFWxQTq8Gl+5GT/bQEnRpsQ5zcRQ9y3icVki",
-	"h+TmI9PMi4MYmRYuBKbD2zp4mGx8G3dvjeZJ7vyafzhJBe8JoApOiE+8BlWQjl3qISDVMd7oHBqXBVv1",
-	"i06lRBv8V9TgP7CT8f7lE5VTaAhLVtbjL7dRS+8cpZae764l3Xs6uG889yHKKXXe/6Wo9+mwyGCZtH0r",
-	"N73KElqyP9H7ktXluHc7t7nt9Pr7TwZn+n6s8xHeAGi+grqgQEhjVfvM38wkhgovj9+8Dh5B8x7eJywN",
-	"WhSxNdKD/Vdz/ehM9rXx1lwqG+M6OdBnTRQQi+VawGd1+J2OfhmFQCzSFvjKqCzOmIdMFrT86ZRcW3v+",
-	"51b2NMgUk2X36/7fAQAA//+WMY+vjgwAAA==",
+	"H4sIAAAAAAAC/+x9e1MT2b7oV+nK3VX7nzAkiM5o1a66+JgzzDjqFT17nzt62E3SQO/Ja3c6jty5VKU7",
+	"PIKGDYMCoiiiKBkYg47ObBSUD9N0Ev7yK5xar+7V3as7Hd4jqZoaIXR6/dZvrd/79WMgkoynkgkhIacD",
+	"p34M9Ap8VJDgj+eu8D3g36iQjkhiShaTicCpgD5b1N8tV+cLmlLScuNabl1TV7XcopZ7rakT1fmipsxw",
+	"4KtaVi0/eFOeelmeUTVlmWvvbvqWlyO9nJa7p+VyWi4LvqsslwvDeum+pkxrygdNmQkEA+lIrxDnwdLC",
+	"TT6eigmBU4FrgWPXAoFgQO5LgV/TsiQmegL9/f3BQIqX+LggY6jbo0I8lZSFRKTvG6HPCb+WW9JyeS33",
+	"s6YuIMj08YKm3NOzC5o6QYCZ0dTbmlIED6srmlrU1LfgW+oEF+b0B4805bGm/KQPP6+MDxmQa1lVU1/A",
+	"Ta1wLa1ceUbdmrqzufFQf3FPUyY1tYCeu5YIBAMigAUhOxAMJPg42BUFexMAnkZFnL95Xkj0yL2BUy3H",
+	"jzsREQy0d0P8Orf8H+eucJqyqI9N6R+mIbhzxgmZyJ/TH7/Rx/NaVkFHrCmFzbUpeMpWJCiL+sJI+cEb",
+	"sqfnmjIAf3jJtYZbOHALNu4aWPHYLb4Qlm0ytpWIxDJR4awQE2Qh6tydLGUETlNKBvjV5anK+JA+cmtr",
+	"ZoHAOIf3pKo0bASyf2YEqc8ETEQrdkbxkjR8UaGbz8TkwKluPpYWjGPoSiZjAp+AAJ9PRngA25lkVGDd",
+	"vxea+gqSzb8x2SglTX0NPsmNfFzPl6efwCMpsZ5c4TAAH9dHCPQpXu41gY+AVYMBSfhnRpQAvgB+vDF8",
+	"SUpGMxG5nYFcfXJIv6Nw7WfZi4lRz6W6k1KclyFG5ROtJvWKCVnoESS4+GUhLUg3IMJYAGy+y1feDGwX",
+	"AOde/yp09SaT37OWwn8Cd2nvNtxPHofMqi36j0xajgsJ+bLwz4yQlsGHKSmZEiRZFOAjfCyW/KEzIfTw",
+	"snhDYF3BWuSwtFGefKN/AAwbUUF58qWmFKq/PtaUZU1ZgqzuDuB26kS1+EofW6EJxH7BwXoxmXcTDPDd",
+	"Jf3JdHn1wcf1fIjbXHumL0yh62qw86ZjTtQAvPLpZML55sr4UOXuK5pG2s5+fbXjyrfnLlzpvHyurePi",
+	"hQ7A4KrFF5ByMMlrqqopJdvKgSgf53uEAIuBmuf6Hd6kAdN14/lk1z+EiAzApQ8vnYmxzi6ezCRk54aY",
+	"R0JD+TkLPS5431J+rtwtYhYO8V4b0+g2s08QwDQ+qo+MWtDGp8C/DqwFAylJuCEmM+nOGpsdGXXbbDi0",
+	"g8uwnbOFuw/aj9i5lSA5QObpxwSJdeSR7xPJH2JCtEeIdvIMdFSevKsujaLjKk8/K8+ogOfPLpHPCeEq",
+	"K5VZpTL5DN1fg7FEeVloksU48ywsi3f11Vj8zujm+9lqdtDP+s6lXE5bU58A3Sq3jtSlavHeVuFXtCDg",
+	"MIr9FjgPPiIJvGwgz9/Gxagv7hsMfC8mWEJm9TZU6p5CKKchlANAIVRKlWJpa/5RIBgQEpk4uDySkJSi",
+	"gtSZSorwiqT5bkHu60zLycj3gesM2PzSmuOLcq8kpHuTsej2sExvhC16aZqAgg0TBkQSvb5x3JbTcaWK",
+	"S4AIGYJMkJCNIcpCHP7wJ0noDpwK/K9m0wZpxtKxGb4p0G+swUsS3wfxKdyUOyMZKZ2UnIgp/zIPNaf7",
+	"2C4BqtUy/Pm9lluGNz2L+C71zKL3ZbdhCu+DvfsY1v3cxbkL3ejrk5oyqr+/A/Rp9XZ58uXW8BjN3I4F",
+	"A3ExIcbBLQzXPE5PxmUC6SK20BNCtAaYyhwTTCfV+dk0LQ01peR8M1Mm8jd4McZ3xQRfL1+f1N/f0cdW",
+	"qrn3mrLkXKJ1T2UlWwqZyKaozNwW6wRP8xLQ8s9JUlK6LKRTyUSaQW8RpvlRfvG0+nxML0xhboEteFO1",
+	"ag2FOFoKEAuR2FHZBbtG1XoyFG451nr8xOdfnGxlMTIBAGo15y8kuRSyOrhePs3JvWKa60LbYisZ8NlO",
+	"kcULrVvQlOXN1Wx1+A3WiKABg1T6j+t58muh+vy2pixoym3E9cl2S5qyYZO4HrYLfZxok0GEdvapyZHe",
+	"L3kxlpEE91Nj4KoLek0A0+S6eTEmRE9xYiIq3OTCpzgodjgxzZm2qgN5EiRz/5wXAtouC3HMHxw8mLlz",
+	"1y1Tb/LPDW89rpSmbQoy4N75cf3WnMVdQR2ZqVEeZxGygVrbWguvypPT9neqRSRgmdoGwD7bs2Txk5S2",
+	"Bkf1/LQ+NKgppc33o5X3JRrKkBe3oY8/wSd4FhxpmZczaXfedBfqAqXKb+PlR7Mf1/MtoRCHMZhVAJ2X",
+	"F2arxXVjr/DTkxztPymv5jVlA/yhpZUDDglAY8uQ5HJgs8qypuY19VZ5dkkfGy3fe6xlleOAg6i/wUcR",
+	"aeL3206pJRSqrZZAVBuaCd6x611zvWdyMi5GKI8AZc4imrLg3MUg2WUyCgbSmUhEEKK21VtqS3e0HfoF",
+	"xj5MQFlYOhdPyX1neZl350BxIZ3GCpx5CbXcMDzJDU0p6C/u6bNF4iN4oKl3mLLOuba3vHIhT/N25ubh",
+	"pVtDalsgyAQvNwN/eFv+17PK7/c9yNg3E2uPp5KSfI5AV5Ndt0GexsUzaZnrEjg+wZFjZJBwTExYMX3M",
+	"H1Pwp2LAtwdr7s2NarCuT5EN7Y2Q+jqlTIJNUy5neabjPzl9sLj5/g4wWpZeaMpG9cO6pirAC6UM+OO+",
+	"8N3+yZA+PgYJxrGWRMw7Pgr9vYLMtOWk5A/1rnw5+QNr3Uwi0ssnetywm0lF3VDf736OyR9YDgmEfHOL",
+	"6FgDZJEADcz1Okx9fWxKU2+BA1Q2PHxZbGFMLr7zgmhKqTpfqEwu6WP//riep6NFMOpU4MJ2QbJTQcrw",
+	"YRlfO16TG2MqI/o8wned3qT2RDrT3S1GRCEhdwCdzp1Pepg8lbEPkDPXtnQ+r0NB8sWBRWoDSCsN7MAX",
+	"4sPvKCELm2WkVp8r5VeqYbI4d98S8q3O41M1V6tlnJHoj1+DrGY8qHr7VXnypf5iWssqf276M/h/559h",
+	"kOJEK1eeGtZfTAMf+9Cg3S5LpvnvwXVP8bIsSGCt//6uren/8k3/L9R0srPp+o/h4InW/j+xkOvDBycJ",
+	"fPRiItZHgiA+z7o6X6wsvNPHRz+u55HPBQZhV6gdW3ehLyxu3VvSsyP6u+WaAs/V+CKncl5kOWWIQ8Q/",
+	"bzdOuZZxZL6aBda3yRsCCCAw2TbbWEGRV7sD929NbeALlti6HZN8TIwI7r7cTr5bFlyX9GDwraHablzW",
+	"K5H327dz23fYyRL+OMEEToy6vaiyuKbfnkTxPx/+5BhF7fXQtTVsgQN6e80w2QEVdBg4rJJVJSEiiCk5",
+	"iJi4zH8vBDnecBkGOcmM13ZGkvG4KAc56BEPYh8EfEROSoKWVa4loI0wBwLXl9qunPkKhOnonAMUHAJG",
+	"qjWscy1h3ZQBgIcoYHqHaIJxGujonL15Cu0ZJyEjC8VQESREtRaIarrMCQ/4SgRY62MYZPgB/9yJvJLp",
+	"Pt9uOGIHfneQaOLpd6fTY/w6Tk20sLB6EVxJV5OGyZU238+W8+N1ciXWnSu/Lpanhpl3Cym+/k8SbuO8",
+	"mBBqChp41dDLa145862+PXIMJco7ILH3jnN3bRruzzUEY5yAbYf3/lV58xKYGFkVp4QRUMGHyoo+nq+U",
+	"pm1Xtf5TjIuJdvSlcC3dAQLqusGO3qQk8z3C3rhU/Cn0aQyD/xtNoPbpVqaXYGEC5y6xXH6yJHZlZPxb",
+	"NCqC3fOxS9RTSHO1cYG1tfLAGLifrx6Vs4s0Tn4MJCWxB2YFbT2cKD8tBVi2OAljOND+dduF5nNtF5rC",
+	"x7SsAn74gjOl49VLZ5raOFpFAOmEOUVTF7GrNTcMEy1zKOSLXbcKsArId1c49HoOJDUujJTHHoC/K89J",
+	"CqKZ1FNH8AbEpnqwXDK/1i1lRHmvLIeomE7F+L7OGhaEZR+a8pum3NPUW5p6R1PuurNq2tPmUPBcQKOY",
+	"Wvr7jM3dd+l8UygUZi2YSYiyJWErkIqkA/YLp4/e23xvN4e4VCRt19/Rl52rpKI7xLiN8MAWbYfgQXns",
+	"YP++B+mNGKF/ToQ3UJMRGS/2wMJVeAg74UJefMSTKGuSj+MBfIdd76sPX/5lIcb3ufmNXcTO1uDo5sY8",
+	"5E31RtxSma6YmO4Vou5vnYYZfwswAPAaafeba7+zk4zsB2y8/Tpzq4bB419dQmmrTKWpPlN5W0qpcDMl",
+	"SkKa+cLy7Ih+6215dm5rZnxHWi6dmWvsL3CsOxxpiZ4Umr7gW7uaWiMnok0nhZbupjDf0nUs0ho9Lpzo",
+	"3mND1y0sigCGV+2tlhvCASP1LZXcBZy3NwQYxQeWrYzjaTGBT8MfEWLhPaEAIV/yb0caLmEMq+XIaqrv",
+	"1I08k0x0x8SIvDc6IGXrgxyDRFLm3DbrA+2Gd5qBf2NJguHtx+so7NSdhUVgnWGmYNW0eGQ51pkWIslE",
+	"1AsPJgVO3QFif3Hi4zrQ9izy/2QoxFUWJ+jlT4B4eZy/iQD44kRrKOQNkP+csA6BRB3qRBhJuQYIc3UO",
+	"GjCGdgQjMRz2JiKyS7lfSQkE0nY7YFE7CFV3nAKet/dBY601HHTbPwIUKGwjt8sFRVPmYZrOSO3sbu+8",
+	"PedJfVzPI8i4JuyBFKK2hT73o77jNCmmZGTWDSHJq2VVW1HQX2x1FjANSR9fBiYW5R7YzdDJNtPzDfvY",
+	"py5M0OutOqCkJnRWJmdjpYPWPhSrBWNfM1v5jRQSempB9Rk5rmYNpItzN4SE7FUhtEjUTpPx0conjFtb",
+	"nc25WWjBP0W83hJsCHOba78DvXjlg74xS9vpVtqM8igA4hIp8h+4pyI6zDCFd4K+EYjxDoe43mqPyEQN",
+	"174Pw4SZIUqdDEoD/VsTPsqms0JMvCFIfbaq1KJZFKosgpLIodHqz8+hH3BRU1V9YURTx4C3gaRAuRil",
+	"yUgkI0l1VjOgD8yEDeh9+4ykaATx71R1IvwdR11YORwsTRQ+YgUwiG6YK1WcF24IMS+R4S1M6cvhjFK7",
+	"XhcfTM6HC8SXS5kKMnkpIgYu2L6Peve5/64SeGHqcNmah+833B0wFnHHIB1032k83gvEuq6SnJT5GINl",
+	"DhZhxUMNfgmFc75azNfSgNj3Dy0erJE0APeKBbarwm7NmPd0etaAjXqTKzS+7Aaf6mQNvXc72pFb5ldd",
+	"OsEVUg7FuLC+QGIoBYhUB+arz6fouBIi25pqorUUzWn7zLwrvy5W1LeGvoEKf2Gxb0nPLmyuPdtcvU0V",
+	"ARfd68qwQkKbcolMDKvwrs5yujbOiZ7SiD5YxEjKqga4BKyStSXD9oDodzvONO0ycTk3eFxvkT4X5gwO",
+	"q+eHbLaHzeEd+K/4l5n/+uvxXpMNnvrOJAbAENCNwXyoP2j+8bjxN3xr+6/3B3fPu77DUPz2ZEdNseEh",
+	"K65IfCLdzYrl+9U7uqVk3PKUV8INeNi1lBnp6wh5+OfBnFta1IkdZXIDYeBTgZCTdQGcd83j8iumIEIh",
+	"gJQnk0YcDZPXmfoQGzR03s43csxMDAzmmLK73jQs38fGBiJfGwiXU/Z9ECx0Y1unVjbM9nwVAjCVO8HH",
+	"DJ9n9fU6TPNnW8l0ebXBTHbN6PHMtHAtGfcRARYiksCitfe/6uOjoPzw/YamDOmDr8qzI1ujv0HRtrZV",
+	"+BV6dkta7hcoUx4CHKhvIesFviNbXxrrZoKBHyRRFkzAgNEjxdyiYeimcb2ynErDTOGrl8/DdIJp1PcI",
+	"V8kAr8QaLOdah38iRV3gwyUI3gou+QICBApEoB+M4C0AvWFlK6uQ8JtZDGReZwjCqebmVDL9Gf70s0gy",
+	"3gzuY7qZpJUYp5CRxJp3H+zbeu087jwx75mRWSGeklnlc4atr04Ajx4oT5lD+9QfPNrNtgUusRlDQBPk",
+	"lqgSOmYNpLe5h3DFdKHQiGT+2XdnBaiTYKSyPXlAL1kmW4IRFtL+IiUkomKix+5Oraf1RYrviyX5qC91",
+	"BHn3kKsTqoCdbrEr50noG7OVF3dtESxQWIw+VwrWUibWwdC8xGVdEyErmKI/DGrKvJEuZFxR9DkVwcTf",
+	"ZNcGspjkD4hQOn0eNMuVRL2CumyWq2UeEBXzNEiwZsTTRs1sv0sU/VWsIxnN9t7D2G6C2pUHZth1Dfhg",
+	"6sZHTX3deLETpH5YIN2dRBUvCZlHwQchzosxsMtMKpWU5P9NCQOzu1bbpXZUOfwQiSfkbWYk6ZfmYeO9",
+	"Esz5gNX9bZfaryWuJYiSMcGd7Uvw8eTZ0yAZjkqAQ83qbn9cz3dcuXjmm86OKxcvn/tLFD4c7fq4PmL0",
+	"zer4P+eh7ES2CYfpPTcFJKE6UXnwpvyvZ6iGsfxgFXZaATwNcurXUEg+hJIWKDrXEh/X87i2ja6a07KK",
+	"rXtBeWG28uYJ1YOvaDQwqLwZ31y7pyk/gb/ScZesghKO4dvsyqWef0a11SgiTRTEIJQiZ4YzVrjjobC9",
+	"c5+WVa8lyPJFZjc61GMDFNoqoxxGmLMW13oCxqsDwYAsylBNgGyZ+5ZP8D0wpxscZyAYuCFIaXTu4c9C",
+	"n4WgQz0lJPiUCDJN4EewvKkXXuxms8NMD0tBI3fD4uIoT72EUA1sPR7SlGXb/h30XdAUFeiyhL1zFHsA",
+	"+OPIj5a+ls8RhoA+YfZgpPEAiNZogRf4D0FuQ1uxNrf8zr4jgAxwemb7pqxC94ACf0N/wHX8SsmyfShl",
+	"yam7dUM02DWjCSKEgJI++FcaBtTihBmUcHBTuhml4YOpG2DSK8C9C6B9Ycq3Q6eNsV4eE+OibHm7gYzj",
+	"dHpGS+3kDMf9hC3SaEjo2+UCj/FH9+1eNxUdSBwtoRBhzjjKCYxZEbmcm/+BQ3Hm+2p2aYLiGDJ+R4Gw",
+	"/mEa6auAdlt3cWFrMwHG4puro+UXTzVliUMXWMsq8OyonGuMu/5g4Ph+QsbsjYGgaN1HKBzFUDiwnx/W",
+	"1AKU+OlMPM5LfWzWqZRAm53niwZTCwQDMt+TpltkgZdgrtz8oxjtb6YYA9RWkunaLeSsDGzZwp5JBSQ4",
+	"TVYJJOjvo47Y3NiQ6XqyRRCJJ9rCCpCvs1k9/1BTlun+eWY7u6FBvfRWUyc05SHkUB805YNDkDq4fJuJ",
+	"C0hFtZi9Dft716J0z/kF6zri8zhgXkHjGCAYArKfRGk5Y7rTCjAqG5xqW5yKwUKApu7GryzBZ6xIOvQz",
+	"M3i9h8RiKVr3IV8bd8P7bjBj9+5SjIrC9wcNWWW9Cmeg3+K8mfWAE5ZOJ6N9u34PEAKsrL3fcf/Ce7Su",
+	"PbgMXdoHza+tx48AObl/gGAsmFoEKe9vUON2qFGdQAh1IUILd27+EVRH9Teb8Wim0e+RrwPd+yAxg236",
+	"u7fh/7ieN7vwW9N/Vrhm4i+yfI7zCaHzD+mDB+ZaIFSNsiCcWifrBpiPNFumG+yVKR22lDocPxq2tC2v",
+	"8PAa1O529P4qywzSPswqc3j/oNi5Gxx51vU8mi2wCC1dE6F/KA3P5PZeGp6HcGn+ETCI/lqOZaWAHQAM",
+	"IJZtYUFDNHAhhtudxbZN7rDbLNuZOcnwKlD1Rf5GrewPp/THJQ+YKZGgLbkmDR7V4FGuGeS1DdFMfWou",
+	"emn56X19dBXiFTeE1pTFzdVbJHZJa41I9UWDe1z42SJyjBpXmlJ7l61G0SJWazcGq88VouXe8tJRO/6g",
+	"zG73DX97Wa4v+3+/2Cwq/T2UHoDDz+/31UthAMKqcMVctiGEGkKIlheIuj10ZVh6knaP4lUXhlFBlNlX",
+	"jVLFuTAHx2+U8CxO4Pz5HYJvihjrrBlDXoQ5swzTGFS5Olr9/TV9YIamvfl+Ejxpedd9MI8UjDpgZbwE",
+	"gkwfL2oouDd83tKvbp+dvGhfjPuEk4qUEmpBA9xOR5zX2/i4yd/Nsn+Km+4mi2f3+3PlZ4gcGiz9SLF0",
+	"TK/qBKJXinVjVk3zbZiO4erQoBOxuPazwJdNuEHRZOe+3BaEbXqmNtANU3c+5HUvPQ/u3PLFU311FWWB",
+	"7ztjIofTSBjYAdk4zG4r2WTkruTNZgn0vXNXeiyF4LjzNAn9uCs6HHo5jOxAcxwORbkDhoyDPOJ5nEds",
+	"7S8CjbMzqDwKZCuDX9vILDnAxNQJ2IDb9kYU6+LOxJKZKKyDSHPhz+DEN+7rjosXOP39E319jGpwYpK3",
+	"AWWh8vM7qmr4g+XprEJ9cw4FgFhqFlWptMJdvHrl9MW/dV66evp8e8dX5y5zlYmX+pMcQCGc+wUrCbok",
+	"MdojUHGHbjEmQHZtTP41F7B+wxxnR5Y6959gePDpq2BwcIlGLbi8MGXZWtmEgc7e11dXgW1Xul+endNL",
+	"c5ARovn3Ux/X8/B+dCJMQdAKNGZQ5TUzRRz4U5QZTQF6Fo1AymmMCssGHDhkd1QEqz3U1MLm2rOtmVH7",
+	"JpUVFH/Uf1rXlNf68Dv4Xcah48tr+W5BHxrVlNfkYWhHGjOkSRwTp+ZnFX1sGhy08gZgX4zC5jnDo9UF",
+	"QHPQDh2AInkMXHdlEvzK1MJhx8mLELF7mXNDN7ZksBa0ZzrlpmV/l4YEs4pmy9FnD0qQ8CWYMajSdpeY",
+	"xAhqUOBGju0fg6a2UrAO7Ya62Z4oWzWw6y0zYCUP+LVOvFokjcE/raSoTqB30ZIHPoklD93Xlh1+QiUa",
+	"6gRQ1Q5XGcMlAnstBZBSOGEF1ZCmvgFHopSM+hcfmf9GW9wDyP4/kikLdA/oP2K+QkM5rpFNS4jPPUvS",
+	"7I5dI0kS35U9cqGRt++z98yybCND0glIxzdXTcpzjGou0LHCBkH6JkhGoiRFh7TWYHf0uInouuOs+Hvt",
+	"0cB+SJhDLF0OoEzEmGLe8Pdsn4JqSDIwvdnbM6rlJjX1KZmIPWKoqNTIMawcm1MgOGtDM7OBB5o2YwzH",
+	"rbwvacoonOCSp6uxcchhxaLzg1jZmFoZXCS2H7G84UOgKY06wEFGbAHuuRFBg9bLAN2TFs6/eVZ+AYx2",
+	"Z7NTtr6PZmDsFj/ZMxUBgbnfuRRefAxNQzzqwTUPnnaYNJbNtSnKKl1sKDDbZb/w1rsrMNTsifqjVZb+",
+	"GL7iVPSUl3pZF/XdvVaHaDAPWxQKo7uhlWyHLKwX1qGb0NTgRiDNaGiNe3QK9e/XlCVjncqTd5h0sgo9",
+	"sgiAYIayQA29/u+SpkyjNBp3QjoDIfg0aAnh5oDSyI3WPoYkwp9YmqPYKe3jeh49BntOLdtGWWBP/24K",
+	"U69JRF4cgtxEmEZMQMcIR3nDubXq4tPy3Q/Gr1Z3/EgjueVIJbfY+CO6KvXyRzzIqx4GiS4hYpD0YBqc",
+	"qQ/ayi6X82ue2sVltOynwRUxVR4oV9xzG6HB1hps7SDYGrob3mytRr17+eE8GiYEIjbDb6ytlye4dFIC",
+	"kbDS1uMhENPGNe4rqBgGJmsPwz/BpBp7yk6Y0x88YrUwXsEhNrgyCpCDoTk4XLzdaLM9as0OOxvfXUGp",
+	"KniLbDcb8NBtvt+g7kiNLnwxtyp52/0l+NNHRstTbxHuXUK2KUnoFm/WF6I23r+VK+r5Ic/3g2vOi4l0",
+	"nStQmWubq7e3ZsbBuAoyKYJ0Qs6ijqWsZeNiwmz/7ljYK+5tWfnWNlbmb25vZbQI6kJM0qSW0agxQ2k2",
+	"+xCa6QdGhkf+8pdnuGPHjp10B46MJkqLiYgQYDbi8hxT5JgiufpcU96WH2xAx3BJU2F/7qyqD+a3Hr8A",
+	"qX1N4PLjTqFwqMfWDO5wARP2bpmj6xngAuJxafUInzBbPeJfm+wTTZuMn6ipTMFAE/Xb9eCRT8oI1lT5",
+	"2tG8wbO44f2Odb5kQrjY7aprWkr+zNEo/UHvx8/FU3LfWV7mzW9c9+WWOiQe7oZbylc3Na/8DzK6hcr+",
+	"sB0+6bBraCHIZ047N4Ai8uIeXSBG2DAt6tujQjyVlIVEpK/pG8E2so8p8LMKyV+FbBLNWsgt4VxsdQHN",
+	"MCDtHR2KjTqhD41uZRVs/1HlcZvvClvDQFzotx5XStP0/APvIjIUferAgw/qswLbu7+FUUk/3MNE1TdC",
+	"356F1A68Otk98ca8W2h2KLJYg4FegSeFi+eu8D1uq+DHmuEz/cGAgVC56bIAxuuzBqQig8VsB+ZxnUyd",
+	"dJkDmPKUFv1HNn0I6/QOyldKNsiAU2D4eWV8aHP1Bc1Z/NY+t4Zb9m9X7d1NkJJh2QW4YbCowhhVbY4f",
+	"G8dqB64UKRKjjvZ7tOwn4I5zKFQmX+JCEMdsO2RpNWKj9fYunWByMJbMNR0CzV19TV28BNoW4eZFri6C",
+	"r9suNJ9ru9AUPqZlFfDDF1pWuXrpTFMbR106I+INoz8kaovnE+BqlodUJHyZzCcYM7ZhfEufu62pCqlB",
+	"cjoWtJyiqYtk+tAwvPE53J9/YbZaXEcLEhhXTGMnRBk7yhyHtsXBOhToQjDANRcjtvQiGsYAHQpFcotv",
+	"gUCY03PiGOvCtYZa/VWPk9ZNp/tOo+Op3ef5LVRXnkKSR4lGliP5uJ7/givPgwyfcAv8wchsDh8Dv9uG",
+	"EwZaT4bCLcdaj5/4/IuTrewSSwyYe5FlipdlQQJf/O/vQk0nr//4Rf+f/j/+MdwSDB/r/xPDfL1+EPLf",
+	"khe4TYG/2xIXn70PfmGjvlJ5XoG+ESMhxYVUYBOE8ounu+2U3zbkVg8kye5BdGSR0ISBGLRGOMmAvR5o",
+	"N1UPv/sywDMaWrjC2eiicuS6qNju/KJ7r0FbehOW2sLNVFKSP4ukb7iXd5HR0yaW0Sghuxw9dzMixMDn",
+	"3NUrXzZ9wWHf5uwv1aVHQJIChX5aU8a2pm5j36Ca15QhYNka8vT0xW9piWoKzfw0dNzzcSHIIQ9fkEPR",
+	"CSEa5PgbvAjH8AY508/HoSgAxOUKMYRQZ3sjfRbVNi/DeJk5PIG7dLHjCmkp1izGUyhqsWjOTlIVL5l7",
+	"DmJ1mw1u63e8ycJNuRkfoYcR5zr3GpxnQz+uQz8mBOC8WjWUZHSV3BMAyIyu8QHU4MLUPpXn1hZ9o+V7",
+	"j2nig12JKg9KUESbbincqiirAMoBeikmHrgKoKkCaYy3iCIKOL00P00NBjcrShEtEoaAYPTVSsmAN6tQ",
+	"/ZOqSwswIIACdAVNeYm7JeHHH6BWSWBnk0v62L8hWxil51H6UoHb417kaD0BPgqnVuETLrHS0SifW1ZJ",
+	"CzLjeWXRfF6doNoc4sEPzDASUoFZoQ8+GqUiH+i3tOAvkAEUaUsHAhtyUR4sniHjq8o1KvV1SpkEG9Zu",
+	"PpY2x9Z2JZMxgU/U6lDIZmCmAQHZPrq41xJoLHkwHLqWAIxBCB6DR85gd/vnC0Q3zL26mx66hwwD0MMd",
+	"4fEvzhOyHQmYjfuLlruj5Z7BEawjux3DqAU+vNzqBJo2SMSfXY8mxFxC0XZI5DjyDjpoAE6c5mhqNu5Z",
+	"I+/kqCmuiFtCzw6lsBLdKltDiprtsNE4bvdRixNWryvVVsSammrUNZE40wDLSwPg3ZpZgF1p0Rfh25SC",
+	"EZxxFi+VS7et7WaNHD9L7o1SAA8qT539A/33CUQao0toxx5WRtunXUy725E26D+a5FRwWwOn2BAfUN6f",
+	"W76zxY2AADSczfsapvB5qf7QgYaGdeLTOkE3kR0i9yxgIszQSCZDOmxuUH/8qo4hAaf7LiBu4c2CMFM9",
+	"UBZ0cDkue5LasgNnc4O6arTpo5M7rARSKyuFXcldefymPD6q5dYqbx7oC6+03BrEymuo7S+TJNElTfkZ",
+	"JImoeYNMsBP4yXR59QFqslcZH6rcfUW5AYvgP1UFyjZr9Kj98RW62x3Xdvbrqx1XvgXt6S6fa+u4eKED",
+	"5EOCpkivtdwjvfAO4ueWW+O7awk+Fkv+0JkQenhZvCHYUmEBNwd9rGCkq7q0UZ58gwbUU5srVH99bPT3",
+	"24Yi1Bb9RyYt+1KEEASHSRHa/SQZhI64kJAPKFOGBsDNxEQHcbiCZttOU6H1Qge1GR3nlunA0iHQZvdd",
+	"a/VD/g2V9ZNXWXHvQngZXEQoa+QMa6IMEpeLtmRJi+zOgqRLZnsTN7qgWvmjRvTAL8O1hMJMaRSBiZad",
+	"yUSsD3v2XDqu0ONrytNPgCsWrzuDfOGkVcqDegcGdAj+hN+eCTxPDzS1bbs5jSeaP9aUnyhce5QLUMiu",
+	"zx198FL5MMzWcZcOeHTGLqSughV2s+NdDcCNeRGfiCqx70KZIikg+WjfgDu7un2kUl4bYt2/WHfKXsdk",
+	"H3cXfzOPG8p7lMx72OXQYW9GcKkRPKD2dPVBpTQNrVhLdqa1yB60tTZGZTZxTD8rfGzEewqQTXhnFTd9",
+	"l3QxX6Hm1LlXdZCG+/7EPYWAT9ncjZFRUQdl7lIAuAaEqbP4pCTVJ2jHtifSme5uMSIKCaKy+R+A1BBy",
+	"n3y4hSJlf1KtV0zLSanPV0M5h0zDc11QgNkQTyjwjMauGAWPzF78Bzj5HxLPV3jzh8M23Z0y7+N0lXfL",
+	"0Wi9/23yhgBcuuQ8G2XXnxpvsyezeIW5nEzOnNdZM7G8xjBQMMEqXy3mnb4v0j+G8QaQX4fIEzFFI8Xc",
+	"KBxDma6MtdUJw1vvwkHd5+Mbmz4Q9rb3M++N/R22ufeN4faNxEKarpx0reef1TPo3snRcAWNNe/Q1oI8",
+	"ERMT30NSce1DfjCMgJFSV118evhS6hr0eiTptWhM+iFFjT+hfnTWTDbriISM7JH5i1+YewTAUDfoSnE8",
+	"s8BciYwqcJSKPzYTiWskv50/PHS/R21W8MYOXbcVcooNN9rOOLGtHLrBmBuMmeKjZn+Nn1AJm3ddMVaZ",
+	"LP1Da7Q9RsCQEj+zkplqPI6ZL/lkxaLMLYzAZA5Y02brlAwzCoBnDGRM2gI+9OJgFqbyLw38N2csUh1e",
+	"0m9PVu4PAMkh3EwBfoc6fOB+HY7VYEdmS9GHSzsuz5bM7B6/e5eouEeyg9pjXaIjvF8dpXEQ76iPv/kj",
+	"BFMa8ufoGfK2FtUoucdPi2pKBslJySOFgJWmMmfKP1aPfUZOC0oVxFAv6ysfyOmsoJp2I4/PHo+rbVtc",
+	"RjvwF+Q3wZ05hJV9+5HA9uFnfTC3G9bAYWG/jRDyJxpmMcuSmfxmxl+kRe6VhHRvMhb1CLWQc6vMvCu/",
+	"LlZA0/yiXhrRB4tuXMhtLj58ZhTwOpDNtcIlMjHY9KdGUeAVE8hPMzRCbbARG/kEO1Bb7j0jdsDHBMmH",
+	"Y9KbBB3VdIi6rGmUNgpETlJHFqOZ6kfq7GBrSdKJJquQXkNUaxItq3iEVFE+JdgBDgwv0kmMNFSba882",
+	"V28DNrb6AM0AMLP+1Sfo2FHPQFKfSLVTYNrLlzKHiI3skYPVzkH22bnqzcDQ1TxkVvLH9TzMnbUSJ52K",
+	"TtOXUjBJD9TT3tIXkDU0YLTUaTDlPzRTdqSWG0zZ1JpOdZF6bBdr0NZmU52wdAPkYDoKaApefT6mjwwb",
+	"I3idFRDYL2lp0I/Y21b2vr66qpmlEnehP3EZdRDH4zBXs1s5Wucu6AuvypPTxuhs3KnNmMiN17FwUlIX",
+	"Z1uoxKVlXs6kYRTsybvq0ih+qzIGfaWTsLcJKOWWk3Ex4l3HxugP6acjHNVDLYwGN4GOcNQe56i6O9gU",
+	"jj3p4ECHMnhIrNPgmjGmLtQUXM52YDtCsEfHOXS6u144t3ujH0RZiKfrmwEBEzHb0ffCIZyKSX439sNL",
+	"Et+331IW3giPkgBI/HqpsPkOzEbDpAfPwdYdjiIRw2qEM6pI8YjRdm3XO8XV2INHQbyFlSiLuLMlkTcG",
+	"zPYd0RNoodNTH0S9prP6u+dUL0YoyanQCC4q/n0QBoVAuz3Kf7oIe5WHQlylWNqafwRXWNyD8bQQW1/y",
+	"YiwjCV7Cz4abGg5JfNTAqwvkhJ05LmkKavo0d4THM/jF/L4oP76B2VaAdnM1W779i6mH+JvaIEt8It2N",
+	"HaNsTQiZedAfVULVcmBQAf4QQIcvnT95BPtBDkDFZr489dY77RetAjU6Z/+amiYq/jaKldLl9pbmaGZv",
+	"V0hmA4DG7JFcNEsStP4zbF4ytdG9KO8KRi3x1++FwUjWOKBsHLI8MyEHYuqox1NZd9NeH33Q/VdoAjeA",
+	"chaxNiKvR2yogP3mbk3doRNy0LXxSJv+QejqTSY9hgQjSWXMKeP+ir4Aa83az3LMqgt98FV5dmRr9Dfg",
+	"8jT/hNkwK+7wVwLGHjJCvMZ5MS37cfs3yOjIkJFxp72GVxqU4j6+kuBvQcvNwBZ8YN2twdHNjXmghZnD",
+	"uqZtjYywFws3ESrAmqY5MqwHGhXEM199vQ5be5uHYVlOncDLfRjUlHljtpWpmmUV4BqendNLc3AkAAoK",
+	"T2lKgfu64+IFOBIE+tDsU7es6MzNwoFCTwFH+OrbtjNNHV+1tRw/wVXe/6qPgzpT7u9/a8JobeoQexK8",
+	"nJGEU1y6l285fuIv1zKh0LFI+MRW9tfy5Ev4m/B3qztIWdzKKpsb81Y4Wm7e5DbXnsG2ziVUraipA1vZ",
+	"++BJ00A1LfByAboIc+N4DJK6BGaJKIv60Gj15+dQ0QVIwc43gj7IV18yezKaX1QnIJYXAMslfiqOukr6",
+	"hwIsuy1ZL8SKdV+GbpoWIpIAp5FXZpXK5DObY61ydw43WcoqaBi1Y7T6IpoJULsEDrm6MKh7pPWSt+9z",
+	"AqFlWaYkO3RhkauXz3PmzC4r8yBODzywq9F9/8iKJiI82BKJVuWafxSjnh33TR5VtLA70o7aM8ENNWA2",
+	"eUd9A5Pw99qj224lfxA0a3D19rP7bpyah9Wo8mhwAsQJHF3jLbopNuPcbKzdpdnQfkhuZ0ZWgws0uMCR",
+	"5wIeFqpDH2iOCjHxhiCJgrurx6YMePcmsthBIMylgKHKYc6mwKJ3ktyGAmfkDxx0gyOMyLMmWnbAFxu9",
+	"inZbDOBz6bvE9wh/CJGgZRV4kqYph29qQ1Y0ZMWBuzXtdp6X6ADvALN62RPnc2gM72v6aAPBQEaKBU4F",
+	"mm+EoZKIX8v2j5bvjG6+n+XaLrWbTAfHu/uD7K/Yasus37VUlTnfUH5dLE8NW7+SlGCdkfNhZnMqPTui",
+	"v1v+uD5Ct6WxvtAMqzC2YO1uYf2iUZLtsXVrEnmRTsu2vgynULq+yiqaDeZleYdxE1jIGaGO3/quZEbu",
+	"St60YRl+BmYh/c8AKyEcLjo0AQA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
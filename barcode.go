@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errInvalidBarcode    = errors.New("barcode must be a 13 digit JAN/EAN-13, 8 digit EAN-8 or 12 digit UPC-A code")
+	errBarcodeCheckDigit = errors.New("barcode check digit is invalid")
+	errAmbiguousBarcode  = errors.New("multiple stocks are linked to the product with this barcode")
+)
+
+// normalizeBarcode はバーコードのチェックデジットを検証し、保存や検索に使う形式に変換します。
+// JAN/EAN-13 と EAN-8 はそのまま、UPC-A は先頭に 0 を付けて EAN-13 に変換します。
+func normalizeBarcode(code string) (string, error) {
+	code = strings.TrimSpace(code)
+	if len(code) != 8 && len(code) != 12 && len(code) != 13 {
+		return "", errInvalidBarcode
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return "", errInvalidBarcode
+		}
+	}
+	if checkDigit(code[:len(code)-1]) != code[len(code)-1] {
+		return "", errBarcodeCheckDigit
+	}
+	if len(code) == 12 {
+		// UPC-A は国コード 0 の EAN-13 と同じ
+		code = "0" + code
+	}
+	return code, nil
+}
+
+// checkDigit は GS1 のモジュラス 10 ウェイト 3-1 でチェックデジットを計算します。
+// EAN-13、EAN-8、UPC-A のいずれも、チェックデジットの直前の桁から重み 3、1 を交互に掛けます。
+func checkDigit(digits string) byte {
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// getStockByBarcodeHandler は GET /stocks/by-barcode/:code のリクエストを処理します。
+// バーコードから商品を検索し、その商品に紐付く在庫を商品を埋め込んで返します。
+// 名前での取得（getStocksHandler）と異なり、一致する在庫がない場合は 404 を返します。
+func getStockByBarcodeHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		code, err := normalizeBarcode(c.Param("code"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "code": c.Param("code")})
+			return
+		}
+
+		product, err := getProductByBarcode(ctx, db, code)
+		if errors.Is(err, errProductNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No product has this barcode", "code": code})
+			return
+		}
+		if err != nil {
+			writeServerError(c, err)
+			return
+		}
+
+		stock, err := getStockByProduct(ctx, db, product.ID)
+		switch {
+		case errors.Is(err, errStockNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "No stock is linked to the product with this barcode", "code": code, "product_id": product.ID})
+			return
+		case errors.Is(err, errAmbiguousBarcode):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "code": code, "product_id": product.ID})
+			return
+		case err != nil:
+			writeServerError(c, err)
+			return
+		}
+
+		stock.Product = &product
+		c.Header("ETag", stockETag(stock.Version))
+		c.JSON(http.StatusOK, stock)
+	}
+}
+
+// getProductByBarcode は正規化したバーコードで商品を取得します。
+func getProductByBarcode(ctx context.Context, db Querier, code string) (Product, error) {
+	var product Product
+	err := scanProduct(db.QueryRowContext(ctx, "SELECT "+productColumns+" FROM products p WHERE p.barcode = ?", code), &product)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Product{}, errProductNotFound
+	}
+	return product, err
+}
+
+// getStockByProduct は商品に紐付く論理削除されていない在庫を取得します。
+// 紐付く在庫がない場合は errStockNotFound を、複数ある場合は errAmbiguousBarcode を返します。
+func getStockByProduct(ctx context.Context, db Storer, productID int64) (Stock, error) {
+	rows, err := db.QueryContext(ctx, "SELECT name FROM stocks WHERE product_id = ? AND deleted_at IS NULL ORDER BY name LIMIT 2", productID)
+	if err != nil {
+		return Stock{}, err
+	}
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return Stock{}, err
+		}
+		names = append(names, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return Stock{}, err
+	}
+
+	switch len(names) {
+	case 0:
+		return Stock{}, errStockNotFound
+	case 1:
+		stock, err := getStock(ctx, db, names[0])
+		if errors.Is(err, sql.ErrNoRows) {
+			// 検索した後に論理削除された場合
+			return Stock{}, errStockNotFound
+		}
+		return stock, err
+	default:
+		return Stock{}, errAmbiguousBarcode
+	}
+}
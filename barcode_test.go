@@ -0,0 +1,148 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeBarcode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		code     string
+		expected string
+		err      error
+	}{
+		{name: "JAN/EAN-13", code: "4901234567894", expected: "4901234567894"},
+		{name: "EAN-8", code: "49012347", expected: "49012347"},
+		{name: "UPC-AはEAN-13に変換する", code: "036000291452", expected: "0036000291452"},
+		{name: "前後の空白は無視する", code: " 4901234567894\n", expected: "4901234567894"},
+		{name: "チェックデジットが一致しない", code: "4901234567890", err: errBarcodeCheckDigit},
+		{name: "UPC-Aのチェックデジットが一致しない", code: "036000291453", err: errBarcodeCheckDigit},
+		{name: "12桁はUPC-Aとしてチェックデジットを検証する", code: "490123456789", err: errBarcodeCheckDigit},
+		{name: "対応していない桁数", code: "4901234", err: errInvalidBarcode},
+		{name: "数字以外を含む", code: "49012345678X4", err: errInvalidBarcode},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, err := normalizeBarcode(tc.code)
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.expected, code)
+		})
+	}
+}
+
+func TestGetStockByBarcodeHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	stockColumns := []string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}
+
+	testCases := []struct {
+		name         string
+		code         string
+		mockSetup    func(mock sqlmock.Sqlmock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "UPC-AをEAN-13に変換して在庫を返す",
+			code: "036000291452",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM products p WHERE p.barcode = \\?").
+					WithArgs("0036000291452").
+					WillReturnRows(sqlmock.NewRows(productColumnNames).
+						AddRow(5, "TIS-001", "ティッシュ", "0036000291452", "box", "", nil, now, now))
+				mock.ExpectQuery("SELECT name FROM stocks WHERE product_id = \\? AND deleted_at IS NULL ORDER BY name LIMIT 2").
+					WithArgs(int64(5)).
+					WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("tissue"))
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s").
+					WithArgs(sqlmock.AnyArg(), "tissue").
+					WillReturnRows(sqlmock.NewRows(stockColumns).AddRow("tissue", 12, 0, 4, nil, nil))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"name":"tissue","amount":12,"reserved":0,"available":12,"product":{"id":5,"sku":"TIS-001","display_name":"ティッシュ","barcode":"0036000291452","unit":"box"`,
+		},
+		{
+			name:         "チェックデジットが一致しない場合は400",
+			code:         "4901234567890",
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"code":"4901234567890","error":"barcode check digit is invalid"}`,
+		},
+		{
+			name: "バーコードに一致する商品がない場合は404",
+			code: "4901234567894",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM products p WHERE p.barcode = \\?").
+					WithArgs("4901234567894").
+					WillReturnRows(sqlmock.NewRows(productColumnNames))
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"code":"4901234567894","error":"No product has this barcode"}`,
+		},
+		{
+			name: "商品に在庫が紐付いていない場合は404",
+			code: "4901234567894",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM products p WHERE p.barcode = \\?").
+					WithArgs("4901234567894").
+					WillReturnRows(sqlmock.NewRows(productColumnNames).
+						AddRow(3, "APL-001", "りんご", "4901234567894", "pcs", "", nil, now, now))
+				mock.ExpectQuery("SELECT name FROM stocks WHERE product_id = \\?").
+					WithArgs(int64(3)).
+					WillReturnRows(sqlmock.NewRows([]string{"name"}))
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: `"error":"No stock is linked to the product with this barcode"`,
+		},
+		{
+			name: "複数の在庫が紐付いている場合は409",
+			code: "4901234567894",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM products p WHERE p.barcode = \\?").
+					WithArgs("4901234567894").
+					WillReturnRows(sqlmock.NewRows(productColumnNames).
+						AddRow(3, "APL-001", "りんご", "4901234567894", "pcs", "", nil, now, now))
+				mock.ExpectQuery("SELECT name FROM stocks WHERE product_id = \\?").
+					WithArgs(int64(3)).
+					WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("apple").AddRow("apple-b"))
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: "multiple stocks are linked",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			router := gin.New()
+			require.NoError(t, setupRoutes(router, &SQLDB{DB: db}))
+
+			req, _ := http.NewRequest(http.MethodGet, "/v1/stocks/by-barcode/"+tc.code, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			responseBody := w.Body.String()
+			t.Logf("テストケース: %s", tc.name)
+			t.Logf("レスポンスボディ: %s", responseBody)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Contains(t, responseBody, tc.expectedBody)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxBatchItems = 1000
+
+var (
+	errBatchItemFailed = errors.New("batch item failed")
+	// errBatchTooLarge は保存先が 1 回で加算できる在庫の数を超えたことを表します。
+	errBatchTooLarge = errors.New("too many stocks to add atomically")
+)
+
+// batchItemError は一括登録で失敗した在庫 1 件のエラーです。Index はリクエストの配列の位置です。
+type batchItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *batchItemError) Error() string {
+	return fmt.Sprintf("index %d: %v", e.Index, e.Err)
+}
+
+func (e *batchItemError) Unwrap() error {
+	return e.Err
+}
+
+// BatchItemResult は一括登録の在庫 1 件分の結果です。
+type BatchItemResult struct {
+	Index  int    `json:"index"`
+	Name   string `json:"name"`
+	Status int    `json:"status"`
+	Amount *int   `json:"amount,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BatchResult は一括登録のレスポンスです。
+type BatchResult struct {
+	Atomic    bool              `json:"atomic"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Results   []BatchItemResult `json:"results"`
+}
+
+// batchStocksHandler は POST /stocks:batch のリクエストを処理します。
+// 通常は在庫ごとに加算し、在庫ごとの結果を返します（一部が失敗しても他の在庫は加算されます）。
+// atomic=true の場合は全ての在庫を一度に加算し、1 件でも失敗すれば何も加算しません。
+func batchStocksHandler(stocks StockRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var items []Stock
+		if err := c.ShouldBindJSON(&items); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+		if len(items) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "At least one item is required"})
+			return
+		}
+		if len(items) > maxBatchItems {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("At most %d items are allowed", maxBatchItems)})
+			return
+		}
+
+		atomic := c.Query("atomic") == "true"
+		meta := movementMetaFromContext(c, movementReceipt)
+
+		// POST /stocks と同じ規則で検証する
+		results := make([]BatchItemResult, len(items))
+		invalid := false
+		for i := range items {
+			results[i] = BatchItemResult{Index: i, Name: items[i].Name}
+			if err := validateStockRequest(&items[i]); err != nil {
+				results[i].Status = http.StatusBadRequest
+				results[i].Error = err.Error()
+				invalid = true
+			}
+		}
+
+		if !atomic {
+			for i, item := range items {
+				if results[i].Status != 0 {
+					continue
+				}
+				stock, err := stocks.Upsert(ctx, item.Name, item.Amount, nil, meta)
+				results[i].setOutcome(stock.Amount, err)
+			}
+			c.JSON(http.StatusOK, newBatchResult(false, results))
+			return
+		}
+
+		if invalid {
+			c.JSON(http.StatusBadRequest, newBatchResult(true, results))
+			return
+		}
+
+		status, err := addStocksAtomically(ctx, stocks, items, results, meta)
+		if err != nil {
+			c.JSON(status, gin.H{"error": err.Error(), "results": results})
+			return
+		}
+		c.JSON(http.StatusOK, newBatchResult(true, results))
+	}
+}
+
+// addStocksAtomically は全ての在庫を一度に加算し、results に結果を設定します。
+// 失敗した場合はレスポンスのステータスコードとエラーを返します。
+func addStocksAtomically(ctx context.Context, stocks StockRepository, items []Stock, results []BatchItemResult, meta MovementMeta) (int, error) {
+	amounts, err := stocks.UpsertAll(ctx, items, meta)
+	if err == nil {
+		for i := range results {
+			results[i].setOutcome(amounts[i], nil)
+		}
+		return http.StatusOK, nil
+	}
+
+	// 何も加算されていないため、失敗した在庫以外は not applied とする
+	failed := -1
+	var itemErr *batchItemError
+	if errors.As(err, &itemErr) {
+		failed = itemErr.Index
+		results[failed].setOutcome(0, itemErr.Err)
+	}
+	for i := range results {
+		if i != failed {
+			results[i].Status = http.StatusFailedDependency
+			results[i].Error = "not applied"
+		}
+	}
+	switch {
+	case errors.Is(err, errBatchTooLarge):
+		return http.StatusBadRequest, err
+	case failed < 0:
+		return serverErrorStatus(err), err
+	}
+	return results[failed].Status, fmt.Errorf("%w: index %d: %v", errBatchItemFailed, failed, itemErr.Err)
+}
+
+// setOutcome は在庫 1 件の加算結果を設定します。
+func (r *BatchItemResult) setOutcome(amount int, err error) {
+	switch {
+	case err == nil:
+		r.Status = http.StatusOK
+		r.Amount = &amount
+	case errors.Is(err, errStockDeleted):
+		r.Status = http.StatusConflict
+		r.Error = err.Error()
+	default:
+		r.Status = serverErrorStatus(err)
+		r.Error = err.Error()
+	}
+}
+
+func newBatchResult(atomic bool, results []BatchItemResult) BatchResult {
+	result := BatchResult{Atomic: atomic, Results: results}
+	for _, r := range results {
+		if r.Status == http.StatusOK {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+	}
+	return result
+}
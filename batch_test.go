@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expectAddStock は addStock が発行するクエリの期待値を設定します。
+func expectAddStock(mock sqlmock.Sqlmock, name string, amount, amountAfter int) {
+	mock.ExpectExec("INSERT INTO stocks").
+		WithArgs(name, amount, amount).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectCurrentAmount(mock, name, amountAfter)
+	expectRecordMovement(mock, name, amount, amountAfter, "receipt")
+}
+
+// expectUpsertStock は StockRepository.Upsert が在庫 1 件を加算するトランザクションの期待値を設定します。
+func expectUpsertStock(mock sqlmock.Sqlmock, name string, amount, amountAfter int) {
+	mock.ExpectBegin()
+	expectAddStock(mock, name, amount, amountAfter)
+	mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s (.+) WHERE s.name = \\?").
+		WithArgs(sqlmock.AnyArg(), name).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}).
+			AddRow(name, amountAfter, 0, 1, nil, nil))
+	mock.ExpectCommit()
+}
+
+func TestBatchStocksHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name         string
+		path         string
+		requestBody  string
+		mockSetup    func(mock sqlmock.Sqlmock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:        "在庫ごとに加算する",
+			path:        "/v1/stocks:batch",
+			requestBody: `[{"name":"banana","amount":10},{"name":"apple"}]`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectUpsertStock(mock, "banana", 10, 15)
+				expectUpsertStock(mock, "apple", 1, 1)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"atomic":false,"succeeded":2,"failed":0,"results":[{"index":0,"name":"banana","status":200,"amount":15},{"index":1,"name":"apple","status":200,"amount":1}]}`,
+		},
+		{
+			name:        "一部が失敗しても他の在庫は加算する",
+			path:        "/v1/stocks:batch",
+			requestBody: `[{"amount":3},{"name":"grape","amount":2},{"name":"apple","amount":5}]`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO stocks").
+					WithArgs("grape", 2, 2).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectQuery("SELECT amount, deleted_at IS NOT NULL FROM stocks WHERE name = \\?$").
+					WithArgs("grape").
+					WillReturnRows(sqlmock.NewRows([]string{"amount", "deleted"}).AddRow(4, true))
+				mock.ExpectRollback()
+				expectUpsertStock(mock, "apple", 5, 5)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"atomic":false,"succeeded":1,"failed":2,"results":[{"index":0,"name":"","status":400,"error":"Name is required"},{"index":1,"name":"grape","status":409,"error":"stock is deleted"},{"index":2,"name":"apple","status":200,"amount":5}]}`,
+		},
+		{
+			name:        "atomicの場合は名前順に1つのトランザクションで加算する",
+			path:        "/v1/stocks:batch?atomic=true",
+			requestBody: `[{"name":"banana","amount":10},{"name":"apple","amount":2}]`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectAddStock(mock, "apple", 2, 2)
+				expectAddStock(mock, "banana", 10, 10)
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"atomic":true,"succeeded":2,"failed":0,"results":[{"index":0,"name":"banana","status":200,"amount":10},{"index":1,"name":"apple","status":200,"amount":2}]}`,
+		},
+		{
+			name:        "atomicで1件でも失敗した場合は全てロールバックする",
+			path:        "/v1/stocks:batch?atomic=true",
+			requestBody: `[{"name":"grape","amount":1},{"name":"apple","amount":2}]`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectAddStock(mock, "apple", 2, 2)
+				mock.ExpectExec("INSERT INTO stocks").
+					WithArgs("grape", 1, 1).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectQuery("SELECT amount, deleted_at IS NOT NULL FROM stocks WHERE name = \\?$").
+					WithArgs("grape").
+					WillReturnRows(sqlmock.NewRows([]string{"amount", "deleted"}).AddRow(3, true))
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: `"results":[{"index":0,"name":"grape","status":409,"error":"stock is deleted"},{"index":1,"name":"apple","status":424,"error":"not applied"}]`,
+		},
+		{
+			name:         "atomicで検証エラーがある場合は何も加算せず400",
+			path:         "/v1/stocks:batch?atomic=true",
+			requestBody:  `[{"name":"apple"},{"amount":2}]`,
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"index":1,"name":"","status":400,"error":"Name is required"}`,
+		},
+		{
+			name:         "空の配列は400",
+			path:         "/v1/stocks:batch",
+			requestBody:  `[]`,
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "At least one item is required",
+		},
+		{
+			name:         "配列でない場合は400",
+			path:         "/v1/stocks:batch",
+			requestBody:  `{"name":"apple"}`,
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "Invalid request body",
+		},
+		{
+			name:         "存在しないカスタムメソッドは404",
+			path:         "/v1/stocks:merge",
+			requestBody:  `[{"name":"apple"}]`,
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "Not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			router := gin.New()
+			require.NoError(t, setupRoutes(router, &SQLDB{DB: db}))
+
+			req, _ := http.NewRequest(http.MethodPost, tc.path, bytes.NewBufferString(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			responseBody := w.Body.String()
+			t.Logf("テストケース: %s", tc.name)
+			t.Logf("レスポンスボディ: %s", responseBody)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Contains(t, responseBody, tc.expectedBody)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
@@ -1,41 +1,99 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/joho/godotenv"
+	_ "github.com/lib/pq"
 )
 
-// Storer is an interface for accessing the database.
-type Storer interface {
+// Querier is an interface for executing queries.
+// *sql.Tx also satisfies this interface, so helpers can run inside or outside a transaction.
+type Querier interface {
 	Exec(query string, args ...interface{}) (sql.Result, error)
 	QueryRow(query string, args ...interface{}) *sql.Row
 	Query(query string, args ...interface{}) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// Storer is an interface for accessing the database.
+type Storer interface {
+	Querier
+	Begin() (*sql.Tx, error)
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+	// WithTx は fn を 1 つのトランザクションで実行し、fn がエラーを返すかパニックした場合はロールバックします。
+	WithTx(ctx context.Context, fn func(tx Querier) error) error
 	Close() error // Close メソッドを追加
 
 }
 
+// データベースのドライバー名
+const (
+	driverMySQL    = "mysql"
+	driverPostgres = "postgres"
+	driverSQLite   = "sqlite"
+)
+
 // SQLDB は sql.DB をラップし、Storer インターフェースを実装します。
+// Driver が driverPostgres または driverSQLite の場合、MySQL 向けに書かれたクエリを書き換えてから実行します。
 type SQLDB struct {
-	DB *sql.DB
+	DB     *sql.DB
+	Driver string // 空の場合は MySQL
+}
+
+// rebind は Driver に合わせてクエリを書き換えます。
+func (s *SQLDB) rebind(query string) string {
+	switch s.Driver {
+	case driverPostgres:
+		return rebindPostgres(query)
+	case driverSQLite:
+		return rebindSQLite(query)
+	}
+	return query
 }
 
 func (s *SQLDB) Exec(query string, args ...interface{}) (sql.Result, error) {
-	return s.DB.Exec(query, args...)
+	return s.DB.Exec(s.rebind(query), args...)
 }
 
 func (s *SQLDB) QueryRow(query string, args ...interface{}) *sql.Row {
-	return s.DB.QueryRow(query, args...)
+	return s.DB.QueryRow(s.rebind(query), args...)
 }
 
 func (s *SQLDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
-	return s.DB.Query(query, args...)
+	return s.DB.Query(s.rebind(query), args...)
+}
+
+func (s *SQLDB) Begin() (*sql.Tx, error) {
+	return s.DB.Begin()
+}
+
+func (s *SQLDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return s.DB.ExecContext(ctx, s.rebind(query), args...)
+}
+
+func (s *SQLDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return s.DB.QueryRowContext(ctx, s.rebind(query), args...)
+}
+
+func (s *SQLDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return s.DB.QueryContext(ctx, s.rebind(query), args...)
+}
+
+func (s *SQLDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return s.DB.BeginTx(ctx, opts)
 }
 
 // Close メソッドを実装
@@ -43,12 +101,191 @@ func (s *SQLDB) Close() error {
 	return s.DB.Close()
 }
 
+// WithTx はトランザクション内で fn を実行します。
+// fn がエラーを返すかパニックした場合はロールバックし、そうでなければコミットします。
+// fn の中で afterCommit に登録した処理は、コミットに成功した場合のみ実行します。
+// ctx がキャンセルされると database/sql がトランザクションをロールバックします。
+func (s *SQLDB) WithTx(ctx context.Context, fn func(tx Querier) error) error {
+	sqlTx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	tx := &txQuerier{Tx: sqlTx}
+	defer func() {
+		if p := recover(); p != nil {
+			rollback(tx.Tx)
+			panic(p)
+		}
+	}()
+	var q Querier = tx
+	if s.Driver == driverPostgres || s.Driver == driverSQLite {
+		q = rebindQuerier{Querier: tx, rebind: s.rebind, driver: s.Driver}
+	}
+	if err := fn(q); err != nil {
+		rollback(tx.Tx)
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	tx.runAfterCommit()
+	return nil
+}
+
+func rollback(tx *sql.Tx) {
+	if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		log.Printf("Failed to rollback transaction: %v", err)
+	}
+}
+
+// txQuerier は SQLDB.WithTx が fn に渡すトランザクションです。
+type txQuerier struct {
+	*sql.Tx
+	commitHooks
+}
+
+// commitHooks はコミット後に実行する処理を保持します。
+type commitHooks struct {
+	hooks []func()
+}
+
+func (h *commitHooks) addAfterCommit(hook func()) {
+	h.hooks = append(h.hooks, hook)
+}
+
+func (h *commitHooks) runAfterCommit() {
+	for _, hook := range h.hooks {
+		hook()
+	}
+}
+
+// afterCommit は tx がコミットされた後に実行する処理を登録します。
+// tx が WithTx のトランザクションでない場合、変更は既に確定しているため直ちに実行します。
+func afterCommit(tx Querier, hook func()) {
+	if t, ok := tx.(interface{ addAfterCommit(func()) }); ok {
+		t.addAfterCommit(hook)
+		return
+	}
+	hook()
+}
+
+// rebindQuerier は rebind でクエリを書き換えてから実行するトランザクションです。
+// MySQL と共通のヘルパーを PostgreSQL や SQLite のトランザクション内で呼び出すために使います。
+type rebindQuerier struct {
+	Querier
+	rebind func(query string) string
+	driver string
+}
+
+func (q rebindQuerier) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return q.Querier.Exec(q.rebind(query), args...)
+}
+
+func (q rebindQuerier) QueryRow(query string, args ...interface{}) *sql.Row {
+	return q.Querier.QueryRow(q.rebind(query), args...)
+}
+
+func (q rebindQuerier) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return q.Querier.Query(q.rebind(query), args...)
+}
+
+func (q rebindQuerier) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return q.Querier.ExecContext(ctx, q.rebind(query), args...)
+}
+
+func (q rebindQuerier) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return q.Querier.QueryRowContext(ctx, q.rebind(query), args...)
+}
+
+func (q rebindQuerier) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return q.Querier.QueryContext(ctx, q.rebind(query), args...)
+}
+
+func (q rebindQuerier) addAfterCommit(hook func()) {
+	afterCommit(q.Querier, hook)
+}
+
+// queryDriver は q が接続しているデータベースのドライバー名を返します。
+// SQLDB と WithTx のトランザクション以外（テストのモックなど）は MySQL として扱います。
+// ON DUPLICATE KEY UPDATE のように rebind で書き換えられない構文を、ドライバーごとに使い分けるために使います。
+func queryDriver(q Querier) string {
+	switch q := q.(type) {
+	case *SQLDB:
+		if q.Driver != "" {
+			return q.Driver
+		}
+	case rebindQuerier:
+		return q.driver
+	}
+	return driverMySQL
+}
+
+// insertReturningID は INSERT 文を実行し、採番された id 列の値を返します。
+// lib/pq は LastInsertId に対応していないため、PostgreSQL では RETURNING id で取得します。
+func insertReturningID(ctx context.Context, db Querier, query string, args ...interface{}) (int64, error) {
+	if queryDriver(db) == driverPostgres {
+		var id int64
+		err := db.QueryRowContext(ctx, query+" RETURNING id", args...).Scan(&id)
+		return id, err
+	}
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// rebindPostgres は ? プレースホルダを PostgreSQL の $1, $2, ... に置き換えます。
+// 単一引用符で囲まれた文字列リテラル内の ? は置き換えません。$n で書かれたクエリはそのまま返します。
+func rebindPostgres(query string) string {
+	if !strings.Contains(query, "?") {
+		return query
+	}
+	var (
+		b      strings.Builder
+		n      int
+		quoted bool
+	)
+	for _, r := range query {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case r == '?' && !quoted:
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
 // MockStore implements Storer interface for testing
 type MockStore struct {
 	ExecFunc     func(query string, args ...interface{}) (sql.Result, error)
 	QueryRowFunc func(query string, args ...interface{}) *sql.Row
 	QueryFunc    func(query string, args ...interface{}) (*sql.Rows, error)
+	BeginFunc    func() (*sql.Tx, error)
 	CloseFunc    func() error
+
+	// Context 付きのメソッドは、設定されていなければ ctx が終了していないことを確認してから
+	// 対応する Context なしの関数を呼び出します。
+	ExecContextFunc     func(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContextFunc func(ctx context.Context, query string, args ...interface{}) *sql.Row
+	QueryContextFunc    func(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	BeginTxFunc         func(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+
+	// WithTxFunc が設定されていなければ、WithTx は fn に MockStore 自身を渡して実行します。
+	// fn が成功した場合は CommitFunc、エラーを返すかパニックした場合は RollbackFunc を呼び出します。
+	WithTxFunc   func(ctx context.Context, fn func(tx Querier) error) error
+	CommitFunc   func() error
+	RollbackFunc func() error
+}
+
+// mockTx は MockStore.WithTx が fn に渡すトランザクションです。
+type mockTx struct {
+	*MockStore
+	commitHooks
 }
 
 func (m *MockStore) Exec(query string, args ...interface{}) (sql.Result, error) {
@@ -72,6 +309,85 @@ func (m *MockStore) Query(query string, args ...interface{}) (*sql.Rows, error)
 	return nil, nil
 }
 
+func (m *MockStore) Begin() (*sql.Tx, error) {
+	if m.BeginFunc != nil {
+		return m.BeginFunc()
+	}
+	return nil, errors.New("MockStore: transactions are not supported")
+}
+
+func (m *MockStore) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if m.ExecContextFunc != nil {
+		return m.ExecContextFunc(ctx, query, args...)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.Exec(query, args...)
+}
+
+func (m *MockStore) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if m.QueryRowContextFunc != nil {
+		return m.QueryRowContextFunc(ctx, query, args...)
+	}
+	return m.QueryRow(query, args...)
+}
+
+func (m *MockStore) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if m.QueryContextFunc != nil {
+		return m.QueryContextFunc(ctx, query, args...)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.Query(query, args...)
+}
+
+func (m *MockStore) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	if m.BeginTxFunc != nil {
+		return m.BeginTxFunc(ctx, opts)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.Begin()
+}
+
+func (m *MockStore) WithTx(ctx context.Context, fn func(tx Querier) error) error {
+	if m.WithTxFunc != nil {
+		return m.WithTxFunc(ctx, fn)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	tx := &mockTx{MockStore: m}
+	defer func() {
+		if p := recover(); p != nil {
+			m.rollback()
+			panic(p)
+		}
+	}()
+	if err := fn(tx); err != nil {
+		m.rollback()
+		return err
+	}
+	if m.CommitFunc != nil {
+		if err := m.CommitFunc(); err != nil {
+			return err
+		}
+	}
+	tx.runAfterCommit()
+	return nil
+}
+
+func (m *MockStore) rollback() {
+	if m.RollbackFunc != nil {
+		if err := m.RollbackFunc(); err != nil {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}
+}
+
 func (m *MockStore) Close() error {
 	if m.CloseFunc != nil {
 		return m.CloseFunc()
@@ -128,34 +444,37 @@ func connectDB() (Storer, error) {
 			log.Printf("Warning: .env file not found, using environment variables")
 		}
 
-		// 環境変数から読み込み
-		dbUser := getEnv("MYSQL_USER", "root")
-		dbPassword := getEnv("MYSQL_PASSWORD", "root")
-		dbHost := getEnv("DB_HOST", "localhost")
-		dbPort := getEnv("DB_PORT", "3306")
-		dbName := getEnv("MYSQL_DATABASE", "stock_db")
+		driver, err := databaseDriver()
+		if err != nil {
+			dbConnErr = err
+			return
+		}
+		dsn := databaseDSN(driver)
 
 		// Lambda用の接続設定（一般的な最適値）
 		maxRetries := 3
 		currentRetry := 0
 
 		for currentRetry < maxRetries {
-			// 接続文字列を構築
-			dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true",
-				dbUser, dbPassword, dbHost, dbPort, dbName)
-
-			db, err := sqlOpenFunc("mysql", dsn)
+			db, err := sqlOpenFunc(driver, dsn)
 			if err == nil {
 				// 接続テスト
 				err = db.Ping()
 				if err == nil {
+					if driver == driverSQLite {
+						if err := createSQLiteSchema(db); err != nil {
+							dbConnErr = err
+							return
+						}
+					}
+
 					// Lambda向けに接続プールを最適化
 					db.SetMaxOpenConns(5)
 					db.SetMaxIdleConns(5)
 					db.SetConnMaxLifetime(5 * time.Minute)
 
-					log.Printf("Successfully connected to database %s on %s", dbName, dbHost)
-					dbConn = &SQLDB{DB: db}
+					log.Printf("Successfully connected to %s database", driver)
+					dbConn = &SQLDB{DB: db, Driver: driver}
 					return
 				}
 			}
@@ -172,6 +491,47 @@ func connectDB() (Storer, error) {
 	return dbConn, dbConnErr
 }
 
+// databaseDriver は接続するデータベースのドライバー名を返します。
+// DB_DRIVER（mysql, postgres, sqlite）が指定されていればそれを使い、指定がなければ DATABASE_URL のスキームが
+// postgres:// または postgresql:// の場合に PostgreSQL、それ以外は MySQL を選びます。
+func databaseDriver() (string, error) {
+	switch driver := os.Getenv("DB_DRIVER"); driver {
+	case driverMySQL, driverPostgres, driverSQLite:
+		return driver, nil
+	case "":
+	default:
+		return "", fmt.Errorf("unsupported DB_DRIVER: %s", driver)
+	}
+
+	url := os.Getenv("DATABASE_URL")
+	if strings.HasPrefix(url, "postgres://") || strings.HasPrefix(url, "postgresql://") {
+		return driverPostgres, nil
+	}
+	return driverMySQL, nil
+}
+
+// databaseDSN はドライバーに合わせた接続文字列を返します。
+// PostgreSQL は DATABASE_URL を優先し、指定がなければ POSTGRES_* と DB_HOST, DB_PORT から組み立てます。
+// SQLite は SQLITE_PATH のファイルを使います。
+// MySQL は MYSQL_* と DB_HOST, DB_PORT から組み立てます。
+func databaseDSN(driver string) string {
+	if driver == driverSQLite {
+		return sqliteDSN(getEnv("SQLITE_PATH", "stock.db"))
+	}
+	dbHost := getEnv("DB_HOST", "localhost")
+	if driver == driverPostgres {
+		if url := os.Getenv("DATABASE_URL"); url != "" {
+			return url
+		}
+		return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
+			getEnv("POSTGRES_USER", "postgres"), getEnv("POSTGRES_PASSWORD", "postgres"),
+			dbHost, getEnv("DB_PORT", "5432"), getEnv("POSTGRES_DB", "stock_db"))
+	}
+	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true",
+		getEnv("MYSQL_USER", "root"), getEnv("MYSQL_PASSWORD", "root"),
+		dbHost, getEnv("DB_PORT", "3306"), getEnv("MYSQL_DATABASE", "stock_db"))
+}
+
 // 環境変数取得ヘルパー
 func getEnv(key, fallback string) string {
 	value := os.Getenv(key)
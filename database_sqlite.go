@@ -0,0 +1,36 @@
+package main
+
+import (
+	"database/sql"
+	_ "embed"
+	"strings"
+
+	_ "modernc.org/sqlite"
+)
+
+// sqliteSchema は SQLite のスキーマ定義です。接続時に作成されていないテーブルを作成します。
+//
+//go:embed schema_sqlite.sql
+var sqliteSchema string
+
+// sqliteDSN は SQLite のデータベースファイルへの接続文字列を返します。
+// 書き込みのトランザクションは BEGIN IMMEDIATE で開始し、他の書き込みを待たせることで FOR UPDATE の代わりにします。
+// 時刻は文字列のまま比較できるように SQLite の形式で保存します。
+func sqliteDSN(path string) string {
+	return path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_txlock=immediate&_time_format=sqlite"
+}
+
+// createSQLiteSchema は SQLite にテーブルが存在しなければ作成します。
+// Docker なしでローカル実行やテストができるように、MySQL のように事前にスキーマを流し込む必要はありません。
+func createSQLiteSchema(db *sql.DB) error {
+	_, err := db.Exec(sqliteSchema)
+	return err
+}
+
+// rebindSQLite は MySQL 向けのクエリを SQLite で実行できるように書き換えます。
+// SQLite は行ロックを持たないため、FOR UPDATE と SKIP LOCKED を取り除きます。
+// トランザクションは BEGIN IMMEDIATE で開始するため、同時に 1 つしか書き込めません。
+func rebindSQLite(query string) string {
+	query = strings.Replace(query, " FOR UPDATE SKIP LOCKED", "", 1)
+	return strings.Replace(query, " FOR UPDATE", "", 1)
+}
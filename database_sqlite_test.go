@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSQLiteDB は一時ディレクトリに SQLite のデータベースを作成し、スキーマを作成した SQLDB を返します。
+func newSQLiteDB(t *testing.T) *SQLDB {
+	t.Helper()
+	db, err := sql.Open(driverSQLite, sqliteDSN(filepath.Join(t.TempDir(), "stock.db")))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, createSQLiteSchema(db))
+	return &SQLDB{DB: db, Driver: driverSQLite}
+}
+
+func TestSQLiteAPI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	require.NoError(t, setupRoutes(router, newSQLiteDB(t)))
+
+	// 各ステップは前のステップの結果に依存するため、順に実行する
+	steps := []struct {
+		name         string
+		method       string
+		path         string
+		requestBody  string
+		headers      map[string]string
+		expectedCode int
+		expectedBody string
+	}{
+		{name: "在庫を登録する", method: http.MethodPost, path: "/v1/stocks", requestBody: `{"name":"apple","amount":5}`,
+			expectedCode: http.StatusOK, expectedBody: `"name":"apple","amount":5`},
+		{name: "既存の在庫に加算する", method: http.MethodPost, path: "/v1/stocks", requestBody: `{"name":"apple","amount":3}`,
+			expectedCode: http.StatusOK, expectedBody: `"amount":8`},
+		{name: "在庫を取得する", method: http.MethodGet, path: "/v1/stocks/apple",
+			expectedCode: http.StatusOK, expectedBody: `"name":"apple","amount":8,"reserved":0,"available":8`},
+		{name: "存在しない在庫はメッセージを返す", method: http.MethodGet, path: "/v1/stocks/durian",
+			expectedCode: http.StatusOK, expectedBody: "データが存在しません"},
+		{name: "在庫数を指定して作成する", method: http.MethodPut, path: "/v1/stocks/banana", requestBody: `{"amount":3}`,
+			expectedCode: http.StatusCreated, expectedBody: `"name":"banana","amount":3`},
+		{name: "create_onlyで既存の在庫は409", method: http.MethodPut, path: "/v1/stocks/banana?create_only=true", requestBody: `{"amount":1}`,
+			expectedCode: http.StatusConflict, expectedBody: "stock already exists"},
+		{name: "If-Matchが一致しなければ412", method: http.MethodPatch, path: "/v1/stocks/banana", requestBody: `{"delta":-1,"reason":"damage"}`,
+			headers: map[string]string{"If-Match": `"9"`}, expectedCode: http.StatusPreconditionFailed},
+		{name: "在庫を調整する", method: http.MethodPatch, path: "/v1/stocks/banana", requestBody: `{"delta":-1,"reason":"damage"}`,
+			headers: map[string]string{"If-Match": `"1"`}, expectedCode: http.StatusOK, expectedBody: `"previous_amount":3,"amount":2`},
+		{name: "引当予約を作成する", method: http.MethodPost, path: "/v1/stocks/apple/reservations", requestBody: `{"amount":2,"ttl_seconds":60}`,
+			expectedCode: http.StatusCreated, expectedBody: `"amount":2`},
+		{name: "予約の数量は引当可能数から差し引く", method: http.MethodGet, path: "/v1/stocks/apple",
+			expectedCode: http.StatusOK, expectedBody: `"amount":8,"reserved":2,"available":6`},
+		{name: "在庫を引き当てる", method: http.MethodPost, path: "/v1/stocks/apple/allocate", requestBody: `{"amount":6}`,
+			expectedCode: http.StatusOK},
+		{name: "引当可能数を超える引き当ては409", method: http.MethodPost, path: "/v1/stocks/apple/allocate", requestBody: `{"amount":1}`,
+			expectedCode: http.StatusConflict},
+		{name: "在庫移動の履歴を取得する", method: http.MethodGet, path: "/v1/stocks/apple/history",
+			expectedCode: http.StatusOK, expectedBody: `"reason":"allocation"`},
+		{name: "商品を登録する", method: http.MethodPost, path: "/v1/products", requestBody: `{"sku":"APL-001","display_name":"りんご","attributes":{"color":"red"}}`,
+			expectedCode: http.StatusCreated, expectedBody: `"id":1`},
+		{name: "同じSKUの商品は409", method: http.MethodPost, path: "/v1/products", requestBody: `{"sku":"APL-001","display_name":"りんご"}`,
+			expectedCode: http.StatusConflict},
+		{name: "在庫を論理削除する", method: http.MethodDelete, path: "/v1/stocks/banana",
+			expectedCode: http.StatusNoContent},
+		{name: "論理削除された在庫は一覧に含めない", method: http.MethodGet, path: "/v1/stocks?sort=-amount",
+			expectedCode: http.StatusOK, expectedBody: `{"stocks":[{"name":"apple","amount":2,"reserved":2,"available":0`},
+		{name: "論理削除された在庫を元に戻す", method: http.MethodPost, path: "/v1/stocks/banana/restore",
+			expectedCode: http.StatusOK, expectedBody: `"name":"banana","amount":2`},
+		{name: "在庫ごとに一括で加算する", method: http.MethodPost, path: "/v1/stocks:batch", requestBody: `[{"name":"cherry","amount":4},{"name":"banana","amount":1}]`,
+			expectedCode: http.StatusOK, expectedBody: `"succeeded":2,"failed":0,"results":[{"index":0,"name":"cherry","status":200,"amount":4},{"index":1,"name":"banana","status":200,"amount":3}]`},
+		{name: "atomicで1つのトランザクションで一括で加算する", method: http.MethodPost, path: "/v1/stocks:batch?atomic=true", requestBody: `[{"name":"cherry","amount":1},{"name":"banana","amount":2}]`,
+			expectedCode: http.StatusOK, expectedBody: `{"atomic":true,"succeeded":2,"failed":0,"results":[{"index":0,"name":"cherry","status":200,"amount":5},{"index":1,"name":"banana","status":200,"amount":5}]}`},
+		{name: "ロケーションを登録する", method: http.MethodPost, path: "/v1/locations", requestBody: `{"code":"tokyo","name":"東京倉庫"}`,
+			expectedCode: http.StatusCreated, expectedBody: `"code":"tokyo"`},
+		{name: "ロケーションの在庫数を設定する", method: http.MethodPut, path: "/v1/locations/tokyo/stocks/apple", requestBody: `{"amount":4}`,
+			expectedCode: http.StatusOK, expectedBody: `{"name":"apple","location":"tokyo","amount":4}`},
+		{name: "ロケーションの在庫数を上書きする", method: http.MethodPut, path: "/v1/locations/tokyo/stocks/apple", requestBody: `{"amount":6}`,
+			expectedCode: http.StatusOK, expectedBody: `{"name":"apple","location":"tokyo","amount":6}`},
+		{name: "ロケーション間で在庫を移動する", method: http.MethodPost, path: "/v1/transfers", requestBody: `{"name":"apple","from":"tokyo","to":"default","amount":3}`,
+			expectedCode: http.StatusOK, expectedBody: `"from_amount":3,"to_amount":5`},
+		{name: "移動元の在庫数を超える移動は409", method: http.MethodPost, path: "/v1/transfers", requestBody: `{"name":"apple","from":"tokyo","to":"default","amount":4}`,
+			expectedCode: http.StatusConflict},
+		{name: "全てのロケーションの在庫数を集計する", method: http.MethodGet, path: "/v1/stocks/apple/locations",
+			expectedCode: http.StatusOK, expectedBody: `"total":8`},
+	}
+
+	for _, step := range steps {
+		if !t.Run(step.name, func(t *testing.T) {
+			req, _ := http.NewRequest(step.method, step.path, bytes.NewBufferString(step.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for k, v := range step.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, step.expectedCode, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), step.expectedBody)
+		}) {
+			return
+		}
+	}
+}
+
+func TestSQLiteIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	require.NoError(t, setupRoutes(router, newSQLiteDB(t)))
+
+	post := func() *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/v1/stocks", bytes.NewBufferString(`{"name":"apple","amount":5}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "key-1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := post()
+	assert.Equal(t, http.StatusOK, first.Code)
+	second := post()
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Contains(t, second.Body.String(), `"amount":5`)
+}
+
+func TestSQLiteListStocksEscapesWildcards(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	require.NoError(t, setupRoutes(router, newSQLiteDB(t)))
+
+	for _, body := range []string{`{"name":"50%_off","amount":1}`, `{"name":"500off","amount":1}`, `{"name":"a!b","amount":1}`} {
+		req, _ := http.NewRequest(http.MethodPost, "/v1/stocks", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+
+	testCases := []struct {
+		name         string
+		query        string
+		expectedBody string
+	}{
+		{name: "%と_は文字として部分一致する", query: "contains=0%25_", expectedBody: `{"stocks":[{"name":"50%_off"`},
+		{name: "_は任意の1文字に一致しない", query: "prefix=50_", expectedBody: "データが存在しません"},
+		{name: "エスケープ文字も文字として前方一致する", query: "prefix=a!", expectedBody: `{"stocks":[{"name":"a!b"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/v1/stocks?"+tc.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tc.expectedBody)
+			assert.NotContains(t, w.Body.String(), "500off")
+		})
+	}
+}
+
+func TestSQLiteWebhookDelivery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	receiver := &webhookReceiver{statuses: []int{http.StatusServiceUnavailable}}
+	server := httptest.NewTLSServer(receiver)
+	defer server.Close()
+
+	db := newSQLiteDB(t)
+	dispatcher := newWebhookDispatcher(db)
+	dispatcher.client = server.Client()
+	router := gin.New()
+	require.NoError(t, setupRoutes(router, db))
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	// ループバックのアドレスは API では登録できないため、テスト用のサーバーを直接登録する
+	_, err := db.Exec("INSERT INTO webhooks (url, event_types, secret, created_at) VALUES (?, ?, ?, ?)", server.URL, eventStockChanged, "s3cret", time.Now())
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, do(http.MethodPost, "/v1/stocks", `{"name":"apple","amount":5}`).Code)
+	require.Equal(t, http.StatusOK, do(http.MethodPost, "/v1/stocks", `{"name":"apple","amount":2}`).Code)
+
+	// リクエストの処理中には送信せず、配信待ちとして記録する
+	assert.Empty(t, receiver.requests)
+	assert.Contains(t, do(http.MethodGet, "/v1/webhooks/1/deliveries", "").Body.String(), `"status":"pending","attempts":0`)
+
+	// 最初のイベントの送信に失敗すると、後続のイベントも再試行の日時まで送信しない
+	ctx := context.Background()
+	delivered, err := dispatcher.DeliverPending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.Len(t, receiver.requests, 1)
+
+	delivered, _, err = dispatcher.deliverBatch(ctx, time.Now().Add(dispatcher.baseDelay))
+	require.NoError(t, err)
+	assert.Equal(t, 2, delivered)
+	if assert.Len(t, receiver.requests, 3) {
+		var first, second StockEvent
+		require.NoError(t, json.Unmarshal(receiver.bodies[1], &first))
+		require.NoError(t, json.Unmarshal(receiver.bodies[2], &second))
+		assert.Equal(t, []int{5, 7}, []int{first.Data.Amount, second.Data.Amount})
+	}
+	body := do(http.MethodGet, "/v1/webhooks/1/deliveries", "").Body.String()
+	assert.Contains(t, body, `"status":"succeeded","attempts":1,"response_status":200`)
+	assert.Contains(t, body, `"status":"succeeded","attempts":2,"response_status":200`)
+}
+
+func TestSQLiteStocksUpdatedAt(t *testing.T) {
+	db := newSQLiteDB(t)
+
+	past := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	_, err := db.Exec("INSERT INTO stocks (name, amount, updated_at) VALUES (?, ?, ?)", "apple", 5, past)
+	require.NoError(t, err)
+
+	// updated_at を指定せずに更新すると、トリガーが現在時刻を設定する
+	_, err = db.Exec("UPDATE stocks SET amount = amount - 1, version = version + 1 WHERE name = ?", "apple")
+	require.NoError(t, err)
+	var updatedAt time.Time
+	require.NoError(t, db.QueryRow("SELECT updated_at FROM stocks WHERE name = ?", "apple").Scan(&updatedAt))
+	assert.True(t, updatedAt.After(past), "updated_at が更新されていません: %v", updatedAt)
+
+	// updated_at を指定した場合はその値を使う
+	_, err = db.Exec("UPDATE stocks SET amount = 1, updated_at = ? WHERE name = ?", past, "apple")
+	require.NoError(t, err)
+	require.NoError(t, db.QueryRow("SELECT updated_at FROM stocks WHERE name = ?", "apple").Scan(&updatedAt))
+	assert.True(t, updatedAt.Equal(past), "updated_at が指定した値ではありません: %v", updatedAt)
+}
+
+func TestRebindSQLite(t *testing.T) {
+	assert.Equal(t, "SELECT version FROM stocks WHERE name = ?", rebindSQLite("SELECT version FROM stocks WHERE name = ? FOR UPDATE"))
+	assert.Equal(t, "SELECT id, payload FROM outbox_events WHERE published_at IS NULL ORDER BY id LIMIT ?",
+		rebindSQLite("SELECT id, payload FROM outbox_events WHERE published_at IS NULL ORDER BY id LIMIT ? FOR UPDATE SKIP LOCKED"))
+}
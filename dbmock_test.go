@@ -56,3 +56,52 @@ func SetupSQLOpenFunc(db *sql.DB) func(driverName, dataSourceName string) (*sql.
 		return db, nil
 	}
 }
+
+// expectLockStock は lockStock が発行するクエリの期待値を設定します。
+func expectLockStock(mock sqlmock.Sqlmock, name string, amount, reserved int) {
+	mock.ExpectQuery("SELECT amount, version FROM stocks WHERE name = \\? AND deleted_at IS NULL FOR UPDATE").
+		WithArgs(name).
+		WillReturnRows(sqlmock.NewRows([]string{"amount", "version"}).AddRow(amount, 1))
+	mock.ExpectQuery("SELECT COALESCE\\(SUM\\(amount\\), 0\\) FROM stock_reservations").
+		WithArgs(name, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"reserved"}).AddRow(reserved))
+}
+
+// expectCurrentAmount は currentAmount が発行するクエリの期待値を設定します。
+func expectCurrentAmount(mock sqlmock.Sqlmock, name string, amount int) {
+	mock.ExpectQuery("SELECT amount, deleted_at IS NOT NULL FROM stocks WHERE name = \\?$").
+		WithArgs(name).
+		WillReturnRows(sqlmock.NewRows([]string{"amount", "deleted"}).AddRow(amount, false))
+}
+
+// expectRecordMovement は recordMovement が発行するクエリの期待値を設定します。
+func expectRecordMovement(mock sqlmock.Sqlmock, name string, delta, amountAfter int, reason string) {
+	expectRecordLocationMovement(mock, name, defaultLocation, delta, amountAfter, reason)
+}
+
+// expectRecordLocationMovement は recordLocationMovement が発行するクエリの期待値を設定します。
+func expectRecordLocationMovement(mock sqlmock.Sqlmock, name, location string, delta, amountAfter int, reason string) {
+	mock.ExpectExec("INSERT INTO stock_movements").
+		WithArgs(name, location, delta, amountAfter, reason, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), outboxEventType(reason), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO webhook_deliveries (.+) SELECT (.+) FROM webhooks").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+// expectNoStockProduct は getStockProduct が発行するクエリの期待値を、商品が紐付いていない在庫として設定します。
+func expectNoStockProduct(mock sqlmock.Sqlmock, name string) {
+	mock.ExpectQuery("SELECT (.+) FROM stocks s JOIN products p ON p.id = s.product_id WHERE s.name = \\?").
+		WithArgs(name).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+}
+
+// expectThresholds は checkLowStock が発行するしきい値のクエリの期待値を設定します。
+// reorderPoint と safetyStock に nil を指定すると、しきい値が設定されていない在庫になります。
+func expectThresholds(mock sqlmock.Sqlmock, name string, reorderPoint, safetyStock interface{}) {
+	mock.ExpectQuery("SELECT reorder_point, safety_stock FROM stocks WHERE name = \\?$").
+		WithArgs(name).
+		WillReturnRows(sqlmock.NewRows([]string{"reorder_point", "safety_stock"}).AddRow(reorderPoint, safetyStock))
+}
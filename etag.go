@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errPreconditionFailed = errors.New("precondition failed")
+
+// versionMatch は If-Match ヘッダーで指定された条件です。
+// ヘッダーが指定されていない場合は nil を使います。
+type versionMatch struct {
+	Any      bool
+	Versions []int64
+}
+
+// stockETag は在庫行のバージョンから ETag を作成します。
+func stockETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ifMatchFromContext は If-Match ヘッダーを解析します。
+// ヘッダーがない場合は nil を返します。
+// 弱い ETag（W/"..."）や、このAPIが発行した形式でない ETag はどのバージョンにも一致しません。
+func ifMatchFromContext(c *gin.Context) *versionMatch {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		return nil
+	}
+
+	match := &versionMatch{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			match.Any = true
+			continue
+		}
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		if version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64); err == nil {
+			match.Versions = append(match.Versions, version)
+		}
+	}
+	return match
+}
+
+// matches は現在のバージョンが条件に一致するかを返します。
+func (m *versionMatch) matches(version int64) bool {
+	if m == nil || m.Any {
+		return true
+	}
+	for _, v := range m.Versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// checkVersion はトランザクション内で在庫行をロックし、If-Match の条件を満たすか確認します。
+// 条件がない場合は何もしません。在庫が存在しない場合も条件を満たさないものとして扱います。
+func checkVersion(ctx context.Context, tx Querier, name string, match *versionMatch) error {
+	if match == nil {
+		return nil
+	}
+
+	var version int64
+	err := tx.QueryRowContext(ctx, "SELECT version FROM stocks WHERE name = ? FOR UPDATE", name).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errPreconditionFailed
+	}
+	if err != nil {
+		return err
+	}
+	if !match.matches(version) {
+		return errPreconditionFailed
+	}
+	return nil
+}
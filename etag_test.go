@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIfMatchFromContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name     string
+		header   string
+		version  int64
+		expected bool
+	}{
+		{name: "ヘッダーなしは常に一致", header: "", version: 3, expected: true},
+		{name: "同じバージョンは一致", header: `"3"`, version: 3, expected: true},
+		{name: "異なるバージョンは不一致", header: `"2"`, version: 3, expected: false},
+		{name: "複数指定のいずれかに一致", header: `"1", "3"`, version: 3, expected: true},
+		{name: "アスタリスクは一致", header: "*", version: 3, expected: true},
+		{name: "弱いETagは不一致", header: `W/"3"`, version: 3, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request, _ = http.NewRequest(http.MethodPost, "/stocks", nil)
+			if tc.header != "" {
+				c.Request.Header.Set("If-Match", tc.header)
+			}
+
+			assert.Equal(t, tc.expected, ifMatchFromContext(c).matches(tc.version))
+		})
+	}
+}
+
+func TestStockHandlersIfMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name         string
+		method       string
+		path         string
+		requestBody  string
+		ifMatch      string
+		mockSetup    func(mock sqlmock.Sqlmock)
+		expectedCode int
+		expectedETag string
+	}{
+		{
+			name:   "GETはバージョンをETagとして返す",
+			method: http.MethodGet,
+			path:   "/stocks/apple",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+), s.version, s.updated_at, s.deleted_at FROM stocks s (.+) WHERE s.name = \\?").
+					WithArgs(sqlmock.AnyArg(), "apple").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}).
+						AddRow("apple", 10, 0, 7, nil, nil))
+				expectNoStockProduct(mock, "apple")
+			},
+			expectedCode: http.StatusOK,
+			expectedETag: `"7"`,
+		},
+		{
+			name:        "If-Matchが一致すれば登録して新しいETagを返す",
+			method:      http.MethodPost,
+			path:        "/stocks",
+			requestBody: `{"name":"apple","amount":5}`,
+			ifMatch:     `"7"`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT version FROM stocks WHERE name = \\? FOR UPDATE").
+					WithArgs("apple").
+					WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(7))
+				mock.ExpectExec("INSERT INTO stocks (.+) version = version \\+ 1").
+					WithArgs("apple", 5, 5).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				expectCurrentAmount(mock, "apple", 15)
+				expectRecordMovement(mock, "apple", 5, 15, "receipt")
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+), s.version, s.updated_at, s.deleted_at FROM stocks s (.+) WHERE s.name = \\?").
+					WithArgs(sqlmock.AnyArg(), "apple").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}).
+						AddRow("apple", 15, 0, 8, nil, nil))
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusOK,
+			expectedETag: `"8"`,
+		},
+		{
+			name:        "If-Matchが一致しなければ412",
+			method:      http.MethodPost,
+			path:        "/stocks",
+			requestBody: `{"name":"apple","amount":5}`,
+			ifMatch:     `"6"`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT version FROM stocks WHERE name = \\? FOR UPDATE").
+					WithArgs("apple").
+					WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(7))
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:        "引き当てもIf-Matchが一致しなければ412",
+			method:      http.MethodPost,
+			path:        "/stocks/apple/allocate",
+			requestBody: `{"amount":1}`,
+			ifMatch:     `"6"`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT version FROM stocks WHERE name = \\? FOR UPDATE").
+					WithArgs("apple").
+					WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(7))
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			mockStorer := &SQLDB{DB: db}
+			router := gin.Default()
+			router.GET("/stocks/:name", getStocksHandler(newMySQLStockRepository(mockStorer)))
+			router.POST("/stocks", postStocksHandler(newMySQLStockRepository(mockStorer)))
+			router.POST("/stocks/:name/allocate", allocateStockHandler(newMySQLStockRepository(mockStorer)))
+
+			req, _ := http.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			t.Logf("テストケース: %s", tc.name)
+			t.Logf("レスポンスボディ: %s", w.Body.String())
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Equal(t, tc.expectedETag, w.Header().Get("ETag"))
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
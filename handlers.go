@@ -1,8 +1,10 @@
 package main
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -10,145 +12,251 @@ import (
 // StockRequest はリクエストボディの構造体です。
 
 type Stock struct {
-	Name   string `json:"name"`
-	Amount int    `json:"amount"`
+	Name      string     `json:"name"`
+	Amount    int        `json:"amount"`
+	Reserved  int        `json:"reserved"`
+	Available int        `json:"available"`
+	Version   int64      `json:"-"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Product は在庫に紐付く商品で、GET /stocks/:name でのみ設定します。
+	Product *Product `json:"product,omitempty"`
+}
+
+// serverErrorStatus はサーバー側のエラーに対応するステータスコードを返します。
+// リクエストの期限を過ぎて DB の処理が中断された場合は 504、それ以外は 500 です。
+func serverErrorStatus(err error) int {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
+
+// writeServerError はサーバー側のエラーをレスポンスに書き込みます。
+// ドライバーによっては期限切れを別のエラーで返すため、リクエストの Context も確認します。
+func writeServerError(c *gin.Context, err error) {
+	if serverErrorStatus(err) == http.StatusGatewayTimeout || errors.Is(c.Request.Context().Err(), context.DeadlineExceeded) {
+		c.JSON(http.StatusGatewayTimeout, gin.H{"error": "Request timed out"})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// rowScanner は *sql.Row と *sql.Rows の共通インターフェースです。
+type rowScanner interface {
+	Scan(dest ...interface{}) error
 }
 
 // getStocksHandler は GET /stocks/:name のリクエストを処理します。
-func getStocksHandler(db Storer) gin.HandlerFunc {
+func getStocksHandler(stocks StockRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx := c.Request.Context()
 		name := c.Param("name")
-		stocks, err := getStocks(db, name)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
-		fmt.Println(stocks)
-		if stocks == nil {
+		stock, err := stocks.Get(ctx, name, c.Query("include_deleted") == "true")
+		if errors.Is(err, errStockNotFound) {
 			// データが存在しない場合の処理
 			// メッセージにデーが存在しませんと返す
 			c.JSON(http.StatusOK, gin.H{"message": "データが存在しません"})
-
-		} else {
-			// データが存在する場合の処理
-
-			c.JSON(http.StatusOK, stocks)
+			return
+		}
+		if err != nil {
+			writeServerError(c, err)
+			return
 		}
+
+		// データが存在する場合の処理
+		// If-Match で使うため、行のバージョンを ETag として返す
+		c.Header("ETag", stockETag(stock.Version))
+		c.JSON(http.StatusOK, []Stock{stock})
 	}
 }
 
-func getAllStocksHandler(db Storer) gin.HandlerFunc {
+// getAllStocksHandler は GET /stocks のリクエストを処理します。
+// 条件に一致する在庫を sort の順に limit 件ずつ返し、続きがある場合は next_cursor を返します。
+func getAllStocksHandler(stocks StockRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		stocks, err := getAllStocks(db)
+		ctx := c.Request.Context()
+		opts, err := parseStockListOptions(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		fmt.Println(stocks)
-		if stocks == nil {
+		limit := opts.Limit
+		opts.Limit = limit + 1
+
+		list, err := stocks.List(ctx, opts)
+		if errors.Is(err, errInvalidCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			writeServerError(c, err)
+			return
+		}
+		if list == nil && opts.After == nil {
 			// データが存在しない場合の処理
 			// メッセージにデーが存在しませんと返す
 			c.JSON(http.StatusOK, gin.H{"message": "データが存在しません"})
-		} else {
-			// データが存在する場合の処理
-			c.JSON(http.StatusOK, stocks)
+			return
 		}
 
+		// データが存在する場合の処理
+		page := StockPage{Stocks: list}
+		if len(list) > limit {
+			// 1 件多く取得できた場合は次のページがある
+			page.Stocks = list[:limit]
+			page.NextCursor = nextStockCursor(stocks, opts, page.Stocks)
+		}
+		if page.Stocks == nil {
+			page.Stocks = []Stock{}
+		}
+		c.JSON(http.StatusOK, page)
 	}
 }
 
-func getStocks(db Storer, name string) ([]Stock, error) {
-	rows, err := db.Query("SELECT * FROM stocks WHERE name = ?", name)
-	if err != nil {
-		return nil, err
-	}
-	if rows == nil {
-		fmt.Println("rows is nil after db.Query") // ログを追加
-		return []Stock{}, nil                     // 空のスライスと nil エラーを返す
-	}
-	defer func() {
-		if rows != nil {
-			err := rows.Close()
-			if err != nil {
-				fmt.Println("Error closing rows:", err) // ログを追加
-			}
-		}
-	}()
-
-	var stocks []Stock
-	for rows.Next() {
-		var stock Stock
-		if err := rows.Scan(&stock.Name, &stock.Amount); err != nil {
-			return nil, err
-		}
-		stocks = append(stocks, stock)
-	}
-	return stocks, nil
-}
-
 // postStocksHandler は POST /stocks のリクエストを処理します。
-func postStocksHandler(db Storer) gin.HandlerFunc {
+func postStocksHandler(stocks StockRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx := c.Request.Context()
 		var stockReq Stock
 		if err := c.ShouldBindJSON(&stockReq); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
 			return
 		}
 
-		if stockReq.Name == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Name is required"})
+		if err := validateStockRequest(&stockReq); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-
-		if stockReq.Amount == 0 {
-			stockReq.Amount = 1
+		stock, err := stocks.Upsert(ctx, stockReq.Name, stockReq.Amount, ifMatchFromContext(c), movementMetaFromContext(c, movementReceipt))
+		if errors.Is(err, errStockDeleted) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "name": stockReq.Name})
+			return
 		}
-		fmt.Println(stockReq)
-		err := updateStock(db, stockReq)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			fmt.Println("エラーだちょ")
+		if errors.Is(err, errPreconditionFailed) {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
 			return
 		}
-
-		stock, err := getStock(db, stockReq.Name)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			writeServerError(c, err)
 			return
 		}
 
+		c.Header("ETag", stockETag(stock.Version))
 		c.JSON(http.StatusOK, stock)
 	}
 }
 
-func updateStock(db Storer, stockReq Stock) error {
-	_, err := db.Exec("INSERT INTO stocks (name, amount) VALUES (?, ?) ON DUPLICATE KEY UPDATE amount = amount + ?", stockReq.Name, stockReq.Amount, stockReq.Amount)
-	fmt.Println("stockReq.Name : ", stockReq.Name)
-	fmt.Println("stockReq.Amount : ", stockReq.Amount)
-	fmt.Println(err)
-	return err
+// validateStockRequest は POST /stocks の在庫 1 件分のリクエストを検証します。
+// 数量が指定されていない（0 の）場合は 1 を設定します。
+func validateStockRequest(stockReq *Stock) error {
+	if stockReq.Name == "" {
+		return errors.New("Name is required")
+	}
+	if stockReq.Amount == 0 {
+		stockReq.Amount = 1
+	}
+	return nil
 }
-func getStock(db Storer, name string) (Stock, error) {
-	var stock Stock
-	err := db.QueryRow("SELECT * FROM stocks WHERE name = ?", name).Scan(&stock.Name, &stock.Amount)
-	return stock, err
+
+// SetStockRequest は PUT /stocks/:name のリクエストボディです。
+// 0 を指定できるように、数量はポインタで受け取ります。
+type SetStockRequest struct {
+	Amount *int `json:"amount"`
 }
 
-// getAllStocks はすべての在庫を取得します。
-func getAllStocks(db Storer) ([]Stock, error) {
-	rows, err := db.Query("SELECT * FROM stocks")
-	if err != nil {
-		return nil, err
+// putStockHandler は PUT /stocks/:name のリクエストを処理します。
+// 棚卸しの結果などで在庫数を指定した値に置き換えます。在庫が存在しない場合は作成します。
+// create_only=true の場合は、既に存在する在庫を変更せずに 409 を返します。
+func putStockHandler(stocks StockRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		name := c.Param("name")
+
+		var req SetStockRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+		if req.Amount == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Amount is required"})
+			return
+		}
+		if *req.Amount < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must not be negative"})
+			return
+		}
+		createOnly := c.Query("create_only") == "true"
+
+		stock, created, err := stocks.Set(ctx, name, *req.Amount, createOnly, ifMatchFromContext(c), movementMetaFromContext(c, movementStocktake))
+		switch {
+		case errors.Is(err, errStockExists), errors.Is(err, errStockDeleted):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "name": name})
+			return
+		case errors.Is(err, errPreconditionFailed):
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			writeServerError(c, err)
+			return
+		}
+
+		status := http.StatusOK
+		if created {
+			status = http.StatusCreated
+		}
+		c.Header("ETag", stockETag(stock.Version))
+		c.JSON(status, stock)
+	}
+}
+
+// deleteStockHandler は DELETE /stocks/:name のリクエストを処理します。
+// 在庫は論理削除され、在庫移動の履歴は残ります。
+func deleteStockHandler(stocks StockRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		name := c.Param("name")
+
+		err := stocks.Delete(ctx, name, ifMatchFromContext(c), movementMetaFromContext(c, movementDelete))
+		switch {
+		case errors.Is(err, errStockNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, errStockReserved):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "name": name})
+			return
+		case errors.Is(err, errPreconditionFailed):
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			writeServerError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
 	}
-	defer rows.Close()
+}
 
-	var stocks []Stock
-	for rows.Next() {
-		var stock Stock
-		if err := rows.Scan(&stock.Name, &stock.Amount); err != nil {
-			return nil, err
+// restoreStockHandler は POST /stocks/:name/restore のリクエストを処理します。
+func restoreStockHandler(stocks StockRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		name := c.Param("name")
+
+		stock, err := stocks.Restore(ctx, name, ifMatchFromContext(c), movementMetaFromContext(c, movementRestore))
+		switch {
+		case errors.Is(err, errStockNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, errPreconditionFailed):
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			writeServerError(c, err)
+			return
 		}
-		stocks = append(stocks, stock)
+
+		c.Header("ETag", stockETag(stock.Version))
+		c.JSON(http.StatusOK, stock)
 	}
-	return stocks, nil
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // SwaggerファイルUIのテスト
@@ -104,7 +105,7 @@ func TestAPIAgainstSwagger(t *testing.T) {
 	// テスト用のルーターを設定
 	r := gin.New()
 	fmt.Println("r,db ", r, db)
-	setupRoutes(r, db) // 実際のルートを設定
+	require.NoError(t, setupRoutes(r, db)) // 実際のルートを設定
 
 	// 登録されているルートを表示（デバッグ用）
 	routes := r.Routes()
@@ -2,13 +2,16 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetStocksHandler(t *testing.T) {
@@ -25,9 +28,9 @@ func TestGetStocksHandler(t *testing.T) {
 			name:      "データが存在しない場合",
 			stockName: "apple",
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("SELECT \\* FROM stocks WHERE name = \\?").
-					WithArgs("apple").
-					WillReturnRows(sqlmock.NewRows([]string{"name", "amount"}))
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s (.+) WHERE s.name = \\?").
+					WithArgs(sqlmock.AnyArg(), "apple").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}))
 			},
 			expectedCode: http.StatusOK,
 			expectedBody: "データが存在しません",
@@ -36,10 +39,11 @@ func TestGetStocksHandler(t *testing.T) {
 			name:      "データが存在する場合",
 			stockName: "banana",
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("SELECT \\* FROM stocks WHERE name = \\?").
-					WithArgs("banana").
-					WillReturnRows(sqlmock.NewRows([]string{"name", "amount"}).
-						AddRow("banana", 10))
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s (.+) WHERE s.name = \\?").
+					WithArgs(sqlmock.AnyArg(), "banana").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}).
+						AddRow("banana", 10, 0, 1, nil, nil))
+				expectNoStockProduct(mock, "banana")
 			},
 			expectedCode: http.StatusOK,
 			expectedBody: `"name":"banana","amount":10`,
@@ -62,7 +66,7 @@ func TestGetStocksHandler(t *testing.T) {
 			tc.mockSetup(mock)
 
 			router := gin.Default()
-			router.GET("/stocks/:name", getStocksHandler(mockStorer))
+			router.GET("/stocks/:name", getStocksHandler(newMySQLStockRepository(mockStorer)))
 
 			req, _ := http.NewRequest(http.MethodGet, "/stocks/"+tc.stockName, nil)
 			w := httptest.NewRecorder()
@@ -97,8 +101,11 @@ func TestGetStocksHandler(t *testing.T) {
 func TestGetAllStocksHandler(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
+	stockColumns := []string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}
+
 	testCases := []struct {
 		name         string
+		query        string
 		mockSetup    func(mock sqlmock.Sqlmock)
 		expectedCode int
 		expectedBody string
@@ -106,13 +113,46 @@ func TestGetAllStocksHandler(t *testing.T) {
 		{
 			name: "データが存在しない場合",
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("SELECT \\* FROM stocks").
-					WillReturnRows(sqlmock.NewRows([]string{"name", "amount"}))
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s").
+					WithArgs(sqlmock.AnyArg(), defaultStockPageLimit+1).
+					WillReturnRows(sqlmock.NewRows(stockColumns))
 			},
 			expectedCode: http.StatusOK,
 			expectedBody: "データが存在しません",
 		},
-		// 必要に応じて他のケースを追加
+		{
+			name:  "次のページがある場合はnext_cursorを返す",
+			query: "?limit=2",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s (.+) ORDER BY s.name LIMIT \\?").
+					WithArgs(sqlmock.AnyArg(), 3).
+					WillReturnRows(sqlmock.NewRows(stockColumns).
+						AddRow("apple", 10, 0, 1, nil, nil).
+						AddRow("banana", 5, 0, 1, nil, nil).
+						AddRow("cherry", 3, 0, 1, nil, nil))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"next_cursor":"` + encodeCursor("banana") + `"`,
+		},
+		{
+			name:  "カーソルより後の在庫を返す",
+			query: "?limit=2&cursor=" + encodeCursor("banana"),
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s (.+) AND s.name > \\? (.+) ORDER BY s.name LIMIT \\?").
+					WithArgs(sqlmock.AnyArg(), "banana", 3).
+					WillReturnRows(sqlmock.NewRows(stockColumns).
+						AddRow("cherry", 3, 0, 1, nil, nil))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"stocks":[{"name":"cherry","amount":3,"reserved":0,"available":3}]}`,
+		},
+		{
+			name:         "limitが上限を超える場合は400",
+			query:        "?limit=501",
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "limit must be between 1 and 500",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -131,9 +171,9 @@ func TestGetAllStocksHandler(t *testing.T) {
 			tc.mockSetup(mock)
 
 			router := gin.Default()
-			router.GET("/stocks", getAllStocksHandler(mockStorer))
+			router.GET("/stocks", getAllStocksHandler(newMySQLStockRepository(mockStorer)))
 
-			req, _ := http.NewRequest(http.MethodGet, "/stocks", nil)
+			req, _ := http.NewRequest(http.MethodGet, "/stocks"+tc.query, nil)
 			w := httptest.NewRecorder()
 			router.ServeHTTP(w, req)
 
@@ -177,14 +217,17 @@ func TestPostStocksHandler(t *testing.T) {
 			name:        "正常な登録",
 			requestBody: `{"name":"banana","amount":10}`,
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
 				mock.ExpectExec("INSERT INTO stocks").
 					WithArgs("banana", 10, 10).
 					WillReturnResult(sqlmock.NewResult(1, 1))
-
-				mock.ExpectQuery("SELECT \\* FROM stocks WHERE name = \\?").
-					WithArgs("banana").
-					WillReturnRows(sqlmock.NewRows([]string{"name", "amount"}).
-						AddRow("banana", 10))
+				expectCurrentAmount(mock, "banana", 10)
+				expectRecordMovement(mock, "banana", 10, 10, "receipt")
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s (.+) WHERE s.name = \\?").
+					WithArgs(sqlmock.AnyArg(), "banana").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}).
+						AddRow("banana", 10, 0, 1, nil, nil))
+				mock.ExpectCommit()
 			},
 			expectedCode: http.StatusOK,
 			expectedBody: `"name":"banana"`,
@@ -194,14 +237,17 @@ func TestPostStocksHandler(t *testing.T) {
 			requestBody: `{"name":"apple"}`,
 			mockSetup: func(mock sqlmock.Sqlmock) {
 				// amountに1がセットされていることを検証
+				mock.ExpectBegin()
 				mock.ExpectExec("INSERT INTO stocks").
 					WithArgs("apple", 1, 1).
 					WillReturnResult(sqlmock.NewResult(1, 1))
-
-				mock.ExpectQuery("SELECT \\* FROM stocks WHERE name = \\?").
-					WithArgs("apple").
-					WillReturnRows(sqlmock.NewRows([]string{"name", "amount"}).
-						AddRow("apple", 1))
+				expectCurrentAmount(mock, "apple", 1)
+				expectRecordMovement(mock, "apple", 1, 1, "receipt")
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s (.+) WHERE s.name = \\?").
+					WithArgs(sqlmock.AnyArg(), "apple").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}).
+						AddRow("apple", 1, 0, 1, nil, nil))
+				mock.ExpectCommit()
 			},
 			expectedCode: http.StatusOK,
 			expectedBody: `"name":"apple","amount":1`,
@@ -226,7 +272,7 @@ func TestPostStocksHandler(t *testing.T) {
 			tc.mockSetup(mock)
 
 			router := gin.Default()
-			router.POST("/stocks", postStocksHandler(mockStorer))
+			router.POST("/stocks", postStocksHandler(newMySQLStockRepository(mockStorer)))
 
 			body := bytes.NewBufferString(tc.requestBody)
 			req, _ := http.NewRequest(http.MethodPost, "/stocks", body)
@@ -262,3 +308,332 @@ func TestPostStocksHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestPutStockHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name         string
+		path         string
+		requestBody  string
+		mockSetup    func(mock sqlmock.Sqlmock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:        "既存の在庫数を指定した値に置き換える",
+			path:        "/stocks/apple",
+			requestBody: `{"amount":40}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT amount, deleted_at IS NOT NULL FROM stocks WHERE name = \\? FOR UPDATE").
+					WithArgs("apple").
+					WillReturnRows(sqlmock.NewRows([]string{"amount", "deleted"}).AddRow(100, false))
+				mock.ExpectExec("UPDATE stocks SET amount = \\?, version = version \\+ 1 WHERE name = \\?").
+					WithArgs(40, "apple").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRecordMovement(mock, "apple", -60, 40, "stocktake")
+				expectThresholds(mock, "apple", nil, nil)
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s (.+) WHERE s.name = \\?").
+					WithArgs(sqlmock.AnyArg(), "apple").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}).
+						AddRow("apple", 40, 0, 2, nil, nil))
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"name":"apple","amount":40`,
+		},
+		{
+			name:        "存在しない在庫は作成して201",
+			path:        "/stocks/grape",
+			requestBody: `{"amount":0}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT amount, deleted_at IS NOT NULL FROM stocks WHERE name = \\? FOR UPDATE").
+					WithArgs("grape").
+					WillReturnRows(sqlmock.NewRows([]string{"amount", "deleted"}))
+				mock.ExpectExec("INSERT INTO stocks \\(name, amount\\) VALUES \\(\\?, \\?\\)$").
+					WithArgs("grape", 0).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectRecordMovement(mock, "grape", 0, 0, "stocktake")
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s (.+) WHERE s.name = \\?").
+					WithArgs(sqlmock.AnyArg(), "grape").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}).
+						AddRow("grape", 0, 0, 1, nil, nil))
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusCreated,
+			expectedBody: `"name":"grape","amount":0`,
+		},
+		{
+			name:        "create_onlyで既に存在する場合は409",
+			path:        "/stocks/apple?create_only=true",
+			requestBody: `{"amount":10}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT amount, deleted_at IS NOT NULL FROM stocks WHERE name = \\? FOR UPDATE").
+					WithArgs("apple").
+					WillReturnRows(sqlmock.NewRows([]string{"amount", "deleted"}).AddRow(100, false))
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: "stock already exists",
+		},
+		{
+			name:         "amountが未指定の場合は400",
+			path:         "/stocks/apple",
+			requestBody:  `{}`,
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "Amount is required",
+		},
+		{
+			name:         "amountが負の場合は400",
+			path:         "/stocks/apple",
+			requestBody:  `{"amount":-1}`,
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "Amount must not be negative",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			router := gin.Default()
+			router.PUT("/stocks/:name", putStockHandler(newMySQLStockRepository(&SQLDB{DB: db})))
+
+			req, _ := http.NewRequest(http.MethodPut, tc.path, bytes.NewBufferString(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			responseBody := w.Body.String()
+			t.Logf("テストケース: %s", tc.name)
+			t.Logf("レスポンスボディ: %s", responseBody)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Contains(t, responseBody, tc.expectedBody)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
+
+func TestSoftDeleteHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	stockColumns := []string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}
+
+	testCases := []struct {
+		name         string
+		method       string
+		path         string
+		requestBody  string
+		mockSetup    func(mock sqlmock.Sqlmock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:   "在庫を論理削除して履歴に記録する",
+			method: http.MethodDelete,
+			path:   "/stocks/apple",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockStock(mock, "apple", 10, 0)
+				mock.ExpectExec("UPDATE stocks SET deleted_at = \\?, version = version \\+ 1 WHERE name = \\?").
+					WithArgs(sqlmock.AnyArg(), "apple").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRecordMovement(mock, "apple", 0, 10, "delete")
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:   "有効な引当予約が残っている場合は削除できない",
+			method: http.MethodDelete,
+			path:   "/stocks/apple",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockStock(mock, "apple", 10, 3)
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: "stock has active reservations",
+		},
+		{
+			name:   "削除済みの在庫は404",
+			method: http.MethodDelete,
+			path:   "/stocks/apple",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT amount, version FROM stocks WHERE name = \\? AND deleted_at IS NULL FOR UPDATE").
+					WithArgs("apple").
+					WillReturnRows(sqlmock.NewRows([]string{"amount", "version"}))
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "stock not found",
+		},
+		{
+			name:   "削除済みの在庫を元に戻す",
+			method: http.MethodPost,
+			path:   "/stocks/apple/restore",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT amount, deleted_at IS NOT NULL FROM stocks WHERE name = \\? FOR UPDATE").
+					WithArgs("apple").
+					WillReturnRows(sqlmock.NewRows([]string{"amount", "deleted"}).AddRow(10, true))
+				mock.ExpectExec("UPDATE stocks SET deleted_at = NULL, version = version \\+ 1 WHERE name = \\?").
+					WithArgs("apple").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRecordMovement(mock, "apple", 0, 10, "restore")
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s (.+) WHERE s.name = \\? AND s.deleted_at IS NULL").
+					WithArgs(sqlmock.AnyArg(), "apple").
+					WillReturnRows(sqlmock.NewRows(stockColumns).AddRow("apple", 10, 0, 3, nil, nil))
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"name":"apple","amount":10`,
+		},
+		{
+			name:   "include_deletedを指定すると削除済みの在庫も返す",
+			method: http.MethodGet,
+			path:   "/stocks/apple?include_deleted=true",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s (.+) WHERE s.name = \\? GROUP BY").
+					WithArgs(sqlmock.AnyArg(), "apple").
+					WillReturnRows(sqlmock.NewRows(stockColumns).
+						AddRow("apple", 10, 0, 3, nil, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)))
+				expectNoStockProduct(mock, "apple")
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"deleted_at":"2025-01-02T03:04:05Z"`,
+		},
+		{
+			name:        "削除済みの在庫には加算できない",
+			method:      http.MethodPost,
+			path:        "/stocks",
+			requestBody: `{"name":"apple","amount":5}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO stocks").
+					WithArgs("apple", 5, 5).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectQuery("SELECT amount, deleted_at IS NOT NULL FROM stocks WHERE name = \\?$").
+					WithArgs("apple").
+					WillReturnRows(sqlmock.NewRows([]string{"amount", "deleted"}).AddRow(15, true))
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: "stock is deleted",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			mockStorer := &SQLDB{DB: db}
+			router := gin.Default()
+			router.GET("/stocks/:name", getStocksHandler(newMySQLStockRepository(mockStorer)))
+			router.POST("/stocks", postStocksHandler(newMySQLStockRepository(mockStorer)))
+			router.DELETE("/stocks/:name", deleteStockHandler(newMySQLStockRepository(mockStorer)))
+			router.POST("/stocks/:name/restore", restoreStockHandler(newMySQLStockRepository(mockStorer)))
+
+			req, _ := http.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			responseBody := w.Body.String()
+			t.Logf("テストケース: %s", tc.name)
+			t.Logf("レスポンスボディ: %s", responseBody)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Contains(t, responseBody, tc.expectedBody)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
+
+func TestRequestDeadline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name        string
+		method      string
+		path        string
+		requestBody string
+		timeout     time.Duration
+		mockSetup   func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:      "期限を過ぎたリクエストはDBに問い合わせずに504",
+			method:    http.MethodGet,
+			path:      "/v1/stocks/apple",
+			timeout:   -time.Second,
+			mockSetup: func(mock sqlmock.Sqlmock) {},
+		},
+		{
+			name:    "クエリの実行中に期限を過ぎた場合は504",
+			method:  http.MethodGet,
+			path:    "/v1/stocks/apple",
+			timeout: 50 * time.Millisecond,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s (.+) WHERE s.name = \\?").
+					WithArgs(sqlmock.AnyArg(), "apple").
+					WillDelayFor(time.Second).
+					WillReturnRows(sqlmock.NewRows([]string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}))
+			},
+		},
+		{
+			name:        "トランザクションの途中で期限を過ぎた場合は504",
+			method:      http.MethodPost,
+			path:        "/v1/stocks",
+			requestBody: `{"name":"apple","amount":1}`,
+			timeout:     50 * time.Millisecond,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO stocks").
+					WithArgs("apple", 1, 1).
+					WillDelayFor(time.Second).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			router := gin.New()
+			require.NoError(t, setupRoutes(router, &SQLDB{DB: db}))
+
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+			req, _ := http.NewRequestWithContext(ctx, tc.method, tc.path, bytes.NewBufferString(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+			assert.JSONEq(t, `{"error":"Request timed out"}`, w.Body.String())
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
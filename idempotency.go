@@ -0,0 +1,285 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	maxIdempotencyKeyLen = 255
+	idempotencyKeyTTL    = 24 * time.Hour
+	// idempotencyLeaseTTL は処理中のキーを保持する期間です。
+	// 在庫を変更する前に Lambda がタイムアウトした場合も、この期間が過ぎればリトライできます。
+	// 在庫を変更したトランザクションでは期限を idempotencyKeyTTL まで延ばすため、変更後にレスポンスを保存できなくてもリトライで再実行しません。
+	idempotencyLeaseTTL = time.Minute
+	// idempotencyKeyContextKey は処理中の Idempotency-Key を gin.Context に保存するキーです。
+	idempotencyKeyContextKey = "idempotencyKey"
+)
+
+// replayedHeaders は保存したレスポンスを再送する際に復元するヘッダーです。
+var replayedHeaders = []string{"Content-Type", "ETag"}
+
+// idempotencyRecord は Idempotency-Key ごとに保存したリクエストとレスポンスです。
+// StatusCode が 0 の場合は、最初のリクエストがまだ処理中であることを表します。
+// 処理中のレコードは idempotencyLeaseTTL、保存したレスポンスは idempotencyKeyTTL の間だけ有効です。
+type idempotencyRecord struct {
+	Fingerprint string
+	StatusCode  int
+	Headers     map[string]string
+	Body        []byte
+}
+
+// idempotencyStore は Idempotency-Key ごとのレコードを保存する先です。
+// 在庫を変更したトランザクションでキーを保持する（holdIdempotencyKey）ため、在庫と同じ保存先のものを使います。
+type idempotencyStore interface {
+	// ClaimIdempotencyKey はキーを処理中として idempotencyLeaseTTL の間だけ登録します。
+	// 既に登録済みのキーであれば、保存されているレコードを返します（created は false）。
+	ClaimIdempotencyKey(ctx context.Context, key, fingerprint string, now time.Time) (record idempotencyRecord, created bool, err error)
+	// ReleaseIdempotencyKey は処理中のキーを削除し、同じキーでリトライできるようにします。
+	// 在庫を変更したトランザクションで保持したキーは削除しません。
+	ReleaseIdempotencyKey(ctx context.Context, key string)
+	// SaveIdempotentResponse は処理済みのレスポンスを保存し、キーの有効期限を idempotencyKeyTTL に延ばします。
+	SaveIdempotentResponse(ctx context.Context, key string, status int, headers map[string]string, body []byte, now time.Time) error
+}
+
+// idempotencyStoreFor は stocks と同じ保存先の idempotencyStore を返します。
+// stocks が idempotencyStore を実装していなければ、db の SQL データベースの idempotency_keys テーブルを使います。
+func idempotencyStoreFor(db Storer, stocks StockRepository) idempotencyStore {
+	if store, ok := stocks.(idempotencyStore); ok {
+		return store
+	}
+	return sqlIdempotencyStore{db: db}
+}
+
+// sqlIdempotencyStore は SQL の idempotency_keys テーブルを使う idempotencyStore です。
+type sqlIdempotencyStore struct {
+	db Storer
+}
+
+func (s sqlIdempotencyStore) ClaimIdempotencyKey(ctx context.Context, key, fingerprint string, now time.Time) (idempotencyRecord, bool, error) {
+	return claimIdempotencyKey(ctx, s.db, key, fingerprint, now)
+}
+
+func (s sqlIdempotencyStore) ReleaseIdempotencyKey(ctx context.Context, key string) {
+	releaseIdempotencyKey(ctx, s.db, key)
+}
+
+func (s sqlIdempotencyStore) SaveIdempotentResponse(ctx context.Context, key string, status int, headers map[string]string, body []byte, now time.Time) error {
+	return saveIdempotentResponse(ctx, s.db, key, status, headers, body, now)
+}
+
+// responseRecorder はハンドラーが書き込んだレスポンスボディを保存するために複製します。
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// idempotencyMiddleware は Idempotency-Key ヘッダー付きのリクエストを 1 回だけ処理します。
+// 同じキーでリトライされた場合は、ハンドラーを実行せずに最初のレスポンスを再送します。
+// 同じキーを異なるリクエストボディで再利用した場合は 422、最初のリクエストが処理中の場合は 409 を返します。
+func idempotencyMiddleware(store idempotencyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		key := c.GetHeader(idempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key is too long"})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		fingerprint := requestFingerprint(c.Request.Method, c.Request.URL.Path, c.Request.URL.RawQuery, body)
+
+		record, created, err := store.ClaimIdempotencyKey(ctx, key, fingerprint, time.Now())
+		if err != nil {
+			writeServerError(c, err)
+			c.Abort()
+			return
+		}
+		if !created {
+			switch {
+			case record.Fingerprint != fingerprint:
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "Idempotency-Key was already used with a different request"})
+			case record.StatusCode == 0:
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "A request with this Idempotency-Key is still in progress"})
+			default:
+				for name, value := range record.Headers {
+					c.Header(name, value)
+				}
+				c.Header("Idempotent-Replayed", "true")
+				c.Data(record.StatusCode, record.Headers["Content-Type"], record.Body)
+				c.Abort()
+			}
+			return
+		}
+
+		// 期限切れで 504 を返した場合もキーを解放できるよう、キャンセルを引き継がない Context を使う
+		releaseCtx := context.WithoutCancel(ctx)
+		defer func() {
+			if r := recover(); r != nil {
+				// ハンドラーがパニックした場合もリトライできるよう、キーを解放してから Recovery に任せる
+				store.ReleaseIdempotencyKey(releaseCtx, key)
+				panic(r)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Set(idempotencyKeyContextKey, key)
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			// サーバーエラーはリトライで成功する可能性があるため、在庫を変更していなければキーを解放する
+			store.ReleaseIdempotencyKey(releaseCtx, key)
+			return
+		}
+
+		headers := make(map[string]string, len(replayedHeaders))
+		for _, name := range replayedHeaders {
+			if value := recorder.Header().Get(name); value != "" {
+				headers[name] = value
+			}
+		}
+		if err := store.SaveIdempotentResponse(releaseCtx, key, status, headers, recorder.body.Bytes(), time.Now()); err != nil {
+			// 在庫を変更したトランザクションでキーを保持しているため、同じキーのリトライは再実行せずに 409 を返す
+			log.Printf("Failed to save response for idempotency key %q: %v", key, err)
+		}
+	}
+}
+
+// releaseIdempotencyKey は処理中のキーを削除し、同じキーでリトライできるようにします。
+// holdIdempotencyKey で保持したキー（在庫を変更済みのリクエスト）は削除しません。
+func releaseIdempotencyKey(ctx context.Context, db Storer, key string) {
+	if _, err := db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE idempotency_key = ? AND expires_at <= ?", key, time.Now().Add(idempotencyLeaseTTL)); err != nil {
+		log.Printf("Failed to release idempotency key %q: %v", key, err)
+	}
+}
+
+// holdIdempotencyKey は処理中のキーの期限を idempotencyKeyTTL まで延ばします。
+// 在庫を変更したのと同じトランザクション内で呼び出し、変更がコミットされたキーをリトライで再実行しないようにします。
+func holdIdempotencyKey(ctx context.Context, tx Querier, key string, now time.Time) error {
+	_, err := tx.ExecContext(ctx, "UPDATE idempotency_keys SET expires_at = ? WHERE idempotency_key = ? AND status_code IS NULL",
+		now.Add(idempotencyKeyTTL), key)
+	return err
+}
+
+// requestFingerprint はリクエストのメソッド・パス・クエリ文字列・ボディからハッシュを作成します。
+// create_only=true のようにクエリで動作が変わるため、クエリ文字列もキーの再利用の判定に含めます。
+func requestFingerprint(method, path, rawQuery string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write([]byte(rawQuery))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// claimIdempotencyKey はキーを処理中として idempotencyLeaseTTL の間だけ登録します。
+// 既に登録済みのキーであれば、保存されているレコードを返します（created は false）。
+// 有効期限が切れたレコードは削除してから登録し直します。
+func claimIdempotencyKey(ctx context.Context, db Storer, key, fingerprint string, now time.Time) (idempotencyRecord, bool, error) {
+	if _, err := db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE idempotency_key = ? AND expires_at <= ?", key, now); err != nil {
+		return idempotencyRecord{}, false, err
+	}
+
+	_, err := db.ExecContext(ctx, "INSERT INTO idempotency_keys (idempotency_key, fingerprint, created_at, expires_at) VALUES (?, ?, ?, ?)",
+		key, fingerprint, now, now.Add(idempotencyLeaseTTL))
+	if err == nil {
+		return idempotencyRecord{Fingerprint: fingerprint}, true, nil
+	}
+	if !isDuplicateKey(err) {
+		return idempotencyRecord{}, false, err
+	}
+
+	record, err := getIdempotencyRecord(ctx, db, key)
+	return record, false, err
+}
+
+// getIdempotencyRecord は保存済みのリクエストとレスポンスを取得します。
+func getIdempotencyRecord(ctx context.Context, db Querier, key string) (idempotencyRecord, error) {
+	var (
+		record     idempotencyRecord
+		statusCode sql.NullInt64
+		headers    sql.NullString
+		body       []byte
+	)
+	err := db.QueryRowContext(ctx, "SELECT fingerprint, status_code, response_headers, response_body FROM idempotency_keys WHERE idempotency_key = ?", key).
+		Scan(&record.Fingerprint, &statusCode, &headers, &body)
+	if err != nil {
+		return idempotencyRecord{}, err
+	}
+
+	record.StatusCode = int(statusCode.Int64)
+	record.Body = body
+	if headers.Valid {
+		if err := json.Unmarshal([]byte(headers.String), &record.Headers); err != nil {
+			return idempotencyRecord{}, err
+		}
+	}
+	return record, nil
+}
+
+// saveIdempotentResponse は処理済みのレスポンスを保存し、キーの有効期限を idempotencyKeyTTL に延ばします。
+func saveIdempotentResponse(ctx context.Context, db Querier, key string, status int, headers map[string]string, body []byte, now time.Time) error {
+	encoded, err := json.Marshal(headers)
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, "UPDATE idempotency_keys SET status_code = ?, response_headers = ?, response_body = ?, expires_at = ? WHERE idempotency_key = ?",
+		status, string(encoded), body, now.Add(idempotencyKeyTTL), key)
+	return err
+}
+
+// isDuplicateKey は主キーまたは一意キーの重複によるエラーかを判定します。
+// MySQL のエラー番号 1062、PostgreSQL の unique_violation（23505）、SQLite の主キー・一意制約違反を重複として扱います。
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1062
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "23505"
+	}
+	var sqliteErr *sqlite.Error
+	return errors.As(err, &sqliteErr) &&
+		(sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY || sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE)
+}
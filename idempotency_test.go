@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const requestBody = `{"name":"apple","amount":5}`
+	fingerprint := requestFingerprint(http.MethodPost, "/stocks", "", []byte(requestBody))
+	recordColumns := []string{"fingerprint", "status_code", "response_headers", "response_body"}
+	duplicateKeyErr := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+
+	expectClaim := func(mock sqlmock.Sqlmock, insertErr error) {
+		mock.ExpectExec("DELETE FROM idempotency_keys WHERE idempotency_key = \\? AND expires_at <= \\?").
+			WithArgs("key-1", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		insert := mock.ExpectExec("INSERT INTO idempotency_keys").
+			WithArgs("key-1", fingerprint, sqlmock.AnyArg(), sqlmock.AnyArg())
+		if insertErr != nil {
+			insert.WillReturnError(insertErr)
+		} else {
+			insert.WillReturnResult(sqlmock.NewResult(1, 1))
+		}
+	}
+
+	expectHoldIdempotencyKey := func(mock sqlmock.Sqlmock, key string) {
+		mock.ExpectExec("UPDATE idempotency_keys SET expires_at = \\? WHERE idempotency_key = \\? AND status_code IS NULL").
+			WithArgs(sqlmock.AnyArg(), key).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	testCases := []struct {
+		name           string
+		path           string
+		requestBody    string
+		handler        gin.HandlerFunc
+		mockSetup      func(mock sqlmock.Sqlmock)
+		expectedCode   int
+		expectedBody   string
+		expectReplayed bool
+		timeout        time.Duration
+	}{
+		{
+			name:        "初回のリクエストは処理してレスポンスを保存する",
+			requestBody: requestBody,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectClaim(mock, nil)
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO stocks").
+					WithArgs("apple", 5, 5).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectCurrentAmount(mock, "apple", 5)
+				expectRecordMovement(mock, "apple", 5, 5, "receipt")
+				expectHoldIdempotencyKey(mock, "key-1")
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s (.+) WHERE s.name = \\?").
+					WithArgs(sqlmock.AnyArg(), "apple").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}).
+						AddRow("apple", 5, 0, 1, nil, nil))
+				mock.ExpectCommit()
+				mock.ExpectExec("UPDATE idempotency_keys SET status_code = \\?, response_headers = \\?, response_body = \\?, expires_at = \\? WHERE idempotency_key = \\?").
+					WithArgs(http.StatusOK, `{"Content-Type":"application/json; charset=utf-8","ETag":"\"1\""}`, sqlmock.AnyArg(), sqlmock.AnyArg(), "key-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"name":"apple","amount":5`,
+		},
+		{
+			name:        "レスポンスの保存に失敗しても在庫を変更したキーは解放しない",
+			requestBody: requestBody,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectClaim(mock, nil)
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO stocks").
+					WithArgs("apple", 5, 5).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectCurrentAmount(mock, "apple", 5)
+				expectRecordMovement(mock, "apple", 5, 5, "receipt")
+				expectHoldIdempotencyKey(mock, "key-1")
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s (.+) WHERE s.name = \\?").
+					WithArgs(sqlmock.AnyArg(), "apple").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}).
+						AddRow("apple", 5, 0, 1, nil, nil))
+				mock.ExpectCommit()
+				mock.ExpectExec("UPDATE idempotency_keys SET status_code = \\?").
+					WillReturnError(errors.New("connection reset"))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"name":"apple","amount":5`,
+		},
+		{
+			name:        "同じキーのリトライには保存したレスポンスを再送する",
+			requestBody: requestBody,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectClaim(mock, duplicateKeyErr)
+				mock.ExpectQuery("SELECT fingerprint, status_code, response_headers, response_body FROM idempotency_keys WHERE idempotency_key = \\?").
+					WithArgs("key-1").
+					WillReturnRows(sqlmock.NewRows(recordColumns).
+						AddRow(fingerprint, 200, `{"Content-Type":"application/json; charset=utf-8"}`, []byte(`{"name":"apple","amount":5}`)))
+			},
+			expectedCode:   http.StatusOK,
+			expectedBody:   `{"name":"apple","amount":5}`,
+			expectReplayed: true,
+		},
+		{
+			name:        "同じキーを異なるボディで再利用した場合は422",
+			requestBody: `{"name":"apple","amount":50}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM idempotency_keys").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO idempotency_keys").
+					WillReturnError(duplicateKeyErr)
+				mock.ExpectQuery("SELECT fingerprint, status_code, response_headers, response_body FROM idempotency_keys").
+					WithArgs("key-1").
+					WillReturnRows(sqlmock.NewRows(recordColumns).
+						AddRow(fingerprint, 200, `{}`, []byte(`{}`)))
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: "different request",
+		},
+		{
+			name:        "同じキーを異なるクエリで再利用した場合は422",
+			path:        "/stocks?create_only=true",
+			requestBody: requestBody,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM idempotency_keys").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO idempotency_keys").
+					WillReturnError(duplicateKeyErr)
+				mock.ExpectQuery("SELECT fingerprint, status_code, response_headers, response_body FROM idempotency_keys").
+					WithArgs("key-1").
+					WillReturnRows(sqlmock.NewRows(recordColumns).
+						AddRow(fingerprint, 200, `{}`, []byte(`{}`)))
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: "different request",
+		},
+		{
+			name:        "最初のリクエストが処理中の場合は409",
+			requestBody: requestBody,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectClaim(mock, duplicateKeyErr)
+				mock.ExpectQuery("SELECT fingerprint, status_code, response_headers, response_body FROM idempotency_keys").
+					WithArgs("key-1").
+					WillReturnRows(sqlmock.NewRows(recordColumns).
+						AddRow(fingerprint, nil, nil, nil))
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: "still in progress",
+		},
+		{
+			name:        "サーバーエラーの場合はキーを解放する",
+			requestBody: requestBody,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectClaim(mock, nil)
+				mock.ExpectBegin().WillReturnError(errors.New("connection refused"))
+				mock.ExpectExec("DELETE FROM idempotency_keys WHERE idempotency_key = \\? AND expires_at <= \\?").
+					WithArgs("key-1", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: "connection refused",
+		},
+		{
+			name:        "期限切れで504を返した場合もキーを解放する",
+			requestBody: requestBody,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectClaim(mock, nil)
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO stocks").
+					WithArgs("apple", 5, 5).
+					WillDelayFor(time.Second).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("DELETE FROM idempotency_keys WHERE idempotency_key = \\? AND expires_at <= \\?").
+					WithArgs("key-1", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedCode: http.StatusGatewayTimeout,
+			expectedBody: "Request timed out",
+			timeout:      100 * time.Millisecond,
+		},
+		{
+			name:        "ハンドラーがパニックした場合もキーを解放する",
+			requestBody: requestBody,
+			handler: func(c *gin.Context) {
+				panic("unexpected")
+			},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectClaim(mock, nil)
+				mock.ExpectExec("DELETE FROM idempotency_keys WHERE idempotency_key = \\? AND expires_at <= \\?").
+					WithArgs("key-1", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			mockStorer := &SQLDB{DB: db}
+			router := gin.Default()
+			handler := tc.handler
+			if handler == nil {
+				handler = postStocksHandler(newMySQLStockRepository(mockStorer))
+			}
+			router.POST("/stocks", idempotencyMiddleware(sqlIdempotencyStore{db: mockStorer}), handler)
+
+			path := tc.path
+			if path == "" {
+				path = "/stocks"
+			}
+			req, _ := http.NewRequest(http.MethodPost, path, bytes.NewBufferString(tc.requestBody))
+			if tc.timeout != 0 {
+				ctx, cancel := context.WithTimeout(req.Context(), tc.timeout)
+				defer cancel()
+				req = req.WithContext(ctx)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Idempotency-Key", "key-1")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			responseBody := w.Body.String()
+			t.Logf("テストケース: %s", tc.name)
+			t.Logf("レスポンスボディ: %s", responseBody)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Contains(t, responseBody, tc.expectedBody)
+			if tc.expectReplayed {
+				assert.Equal(t, "true", w.Header().Get("Idempotent-Replayed"))
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
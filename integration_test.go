@@ -46,7 +46,10 @@ func setupTestEnvironment() (*api.ClientWithResponses, func(), error) {
 
 	// サーバー起動
 	router := setupRouter(config)
-	setupRoutes(router, db)
+	if err := setupRoutes(router, db); err != nil {
+		db.Close()
+		return nil, nil, err
+	}
 
 	// 非同期でサーバー起動
 	server := &http.Server{
@@ -114,7 +117,7 @@ func TestIntegrationGetAllStocks(t *testing.T) {
 	defer cleanup()
 
 	ctx := context.Background()
-	resp, err := client.GetAllStocksWithResponse(ctx)
+	resp, err := client.GetAllStocksWithResponse(ctx, &api.GetAllStocksParams{})
 	require.NoError(t, err)
 
 	assert.Equal(t, http.StatusOK, resp.StatusCode())
@@ -0,0 +1,498 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultLocation は既定のロケーション（倉庫）のコードです。
+// 既定のロケーションの在庫数は stocks テーブルの amount で、/v1/stocks の各エンドポイントはこの在庫数を操作します。
+// その他のロケーションの在庫数は stock_levels テーブルに保存します。
+const defaultLocation = "default"
+
+const (
+	defaultStockLevelLimit = 100
+	maxStockLevelLimit     = 500
+)
+
+var (
+	errLocationNotFound = errors.New("location not found")
+	errLocationExists   = errors.New("location already exists")
+)
+
+// locationCodePattern はロケーションのコードに使える文字列です。
+var locationCodePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// Location は在庫を保管するロケーション（倉庫）です。
+type Location struct {
+	Code      string     `json:"code"`
+	Name      string     `json:"name"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// StockLevel は 1 つのロケーションの在庫数です。
+type StockLevel struct {
+	Name      string     `json:"name"`
+	Location  string     `json:"location"`
+	Amount    int        `json:"amount"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// StockLevelPage は GET /locations/:code/stocks のレスポンスです。
+type StockLevelPage struct {
+	Location   string       `json:"location"`
+	Stocks     []StockLevel `json:"stocks"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+// StockLocations は GET /stocks/:name/locations のレスポンスで、全てのロケーションの在庫数を集計します。
+type StockLocations struct {
+	Name      string       `json:"name"`
+	Total     int          `json:"total"`
+	Locations []StockLevel `json:"locations"`
+}
+
+// TransferRequest は POST /transfers のリクエストボディです。
+type TransferRequest struct {
+	Name   string `json:"name"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Amount int    `json:"amount"`
+}
+
+// Transfer はロケーション間の移動の結果です。FromAmount と ToAmount は移動後の在庫数です。
+type Transfer struct {
+	Name       string `json:"name"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Amount     int    `json:"amount"`
+	FromAmount int    `json:"from_amount"`
+	ToAmount   int    `json:"to_amount"`
+}
+
+// getLocationsHandler は GET /locations のリクエストを処理します。
+func getLocationsHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		rows, err := db.QueryContext(ctx, "SELECT code, name, created_at FROM locations ORDER BY code")
+		if err != nil {
+			writeServerError(c, err)
+			return
+		}
+		defer rows.Close()
+
+		locations := []Location{}
+		for rows.Next() {
+			var (
+				location  Location
+				createdAt time.Time
+			)
+			if err := rows.Scan(&location.Code, &location.Name, &createdAt); err != nil {
+				writeServerError(c, err)
+				return
+			}
+			location.CreatedAt = &createdAt
+			locations = append(locations, location)
+		}
+		if err := rows.Err(); err != nil {
+			writeServerError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"locations": locations})
+	}
+}
+
+// createLocationHandler は POST /locations のリクエストを処理します。
+func createLocationHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var location Location
+		if err := c.ShouldBindJSON(&location); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+		if !locationCodePattern.MatchString(location.Code) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Code must be 1 to 64 letters, digits, '-' or '_'"})
+			return
+		}
+		if location.Name == "" {
+			location.Name = location.Code
+		}
+
+		now := time.Now()
+		_, err := db.ExecContext(ctx, "INSERT INTO locations (code, name, created_at) VALUES (?, ?, ?)", location.Code, location.Name, now)
+		switch {
+		case isDuplicateKey(err):
+			c.JSON(http.StatusConflict, gin.H{"error": errLocationExists.Error(), "code": location.Code})
+			return
+		case err != nil:
+			writeServerError(c, err)
+			return
+		}
+
+		location.CreatedAt = &now
+		c.JSON(http.StatusCreated, location)
+	}
+}
+
+// getLocationStocksHandler は GET /locations/:code/stocks のリクエストを処理します。
+// ロケーションの在庫を名前順に返します。
+func getLocationStocksHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		code := c.Param("code")
+
+		limit, err := parseLimit(c, defaultStockLevelLimit, maxStockLevelLimit)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var after string
+		if v := c.Query("cursor"); v != "" {
+			if after, err = decodeCursor(v); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		if err := checkLocation(ctx, db, code); err != nil {
+			writeLocationError(c, err)
+			return
+		}
+		levels, err := getStockLevels(ctx, db, code, after, limit+1)
+		if err != nil {
+			writeServerError(c, err)
+			return
+		}
+
+		page := StockLevelPage{Location: code, Stocks: levels}
+		if len(levels) > limit {
+			page.Stocks = levels[:limit]
+			page.NextCursor = encodeCursor(levels[limit-1].Name)
+		}
+		if page.Stocks == nil {
+			page.Stocks = []StockLevel{}
+		}
+		c.JSON(http.StatusOK, page)
+	}
+}
+
+// getStockLevelHandler は GET /locations/:code/stocks/:name のリクエストを処理します。
+// 在庫がそのロケーションにない場合は在庫数 0 を返します。
+func getStockLevelHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		code, name := c.Param("code"), c.Param("name")
+
+		if err := checkLocation(ctx, db, code); err != nil {
+			writeLocationError(c, err)
+			return
+		}
+		locations, err := getStockLocations(ctx, db, name)
+		if err != nil {
+			writeLocationError(c, err)
+			return
+		}
+
+		level := StockLevel{Name: name, Location: code}
+		for _, l := range locations.Locations {
+			if l.Location == code {
+				level = l
+			}
+		}
+		c.JSON(http.StatusOK, level)
+	}
+}
+
+// putStockLevelHandler は PUT /locations/:code/stocks/:name のリクエストを処理します。
+// ロケーションの在庫数を棚卸しの結果で上書きします。既定以外のロケーションでは、在庫が登録済みである必要があります。
+// 既定のロケーションの在庫数は PUT /stocks/:name と同じく stocks で上書きします。
+func putStockLevelHandler(db Storer, stocks StockRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		code, name := c.Param("code"), c.Param("name")
+
+		var req SetStockRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+		if req.Amount == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Amount is required"})
+			return
+		}
+		if *req.Amount < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must not be negative"})
+			return
+		}
+
+		meta := movementMetaFromContext(c, movementStocktake)
+		if code == defaultLocation {
+			stock, _, err := stocks.Set(ctx, name, *req.Amount, false, nil, meta)
+			if err != nil {
+				writeLocationError(c, err)
+				return
+			}
+			c.JSON(http.StatusOK, StockLevel{Name: name, Location: code, Amount: stock.Amount, UpdatedAt: stock.UpdatedAt})
+			return
+		}
+		if err := setStockLevel(ctx, db, name, code, *req.Amount, meta); err != nil {
+			writeLocationError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, StockLevel{Name: name, Location: code, Amount: *req.Amount})
+	}
+}
+
+// getStockLocationsHandler は GET /stocks/:name/locations のリクエストを処理します。
+func getStockLocationsHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		locations, err := getStockLocations(ctx, db, c.Param("name"))
+		if err != nil {
+			writeLocationError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, locations)
+	}
+}
+
+// transferStockHandler は POST /transfers のリクエストを処理します。
+// 移動元の減算と移動先の加算を 1 つのトランザクションで行います。
+func transferStockHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var req TransferRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+		switch {
+		case req.Name == "":
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Name is required"})
+			return
+		case req.From == "" || req.To == "":
+			c.JSON(http.StatusBadRequest, gin.H{"error": "From and to are required"})
+			return
+		case req.From == req.To:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "From and to must be different locations"})
+			return
+		case req.Amount <= 0:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must be greater than 0"})
+			return
+		}
+
+		transfer, err := transferStock(ctx, db, req, movementMetaFromContext(c, movementTransfer))
+		if err != nil {
+			writeLocationError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, transfer)
+	}
+}
+
+// writeLocationError はロケーションの操作のエラーをレスポンスに変換します。
+func writeLocationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errLocationNotFound), errors.Is(err, errStockNotFound), errors.Is(err, sql.ErrNoRows):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, errInsufficientStock), errors.Is(err, errStockDeleted):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		writeServerError(c, err)
+	}
+}
+
+// checkLocation はロケーションが登録されているか確認し、なければ errLocationNotFound を返します。
+// 既定のロケーションは常に存在します。
+func checkLocation(ctx context.Context, db Querier, code string) error {
+	if code == defaultLocation {
+		return nil
+	}
+	var found string
+	err := db.QueryRowContext(ctx, "SELECT code FROM locations WHERE code = ?", code).Scan(&found)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %s", errLocationNotFound, code)
+	}
+	return err
+}
+
+// getStockLevels はロケーションの在庫を名前順に取得します。after より後の名前の在庫のみを返します。
+func getStockLevels(ctx context.Context, db Querier, location, after string, limit int) ([]StockLevel, error) {
+	query := "SELECT l.name, l.amount, l.updated_at FROM stock_levels l JOIN stocks s ON s.name = l.name " +
+		"WHERE " + stockNotDeleted + " AND l.location = ? AND l.name > ? ORDER BY l.name LIMIT ?"
+	args := []interface{}{location, after, limit}
+	if location == defaultLocation {
+		query = "SELECT s.name, s.amount, s.updated_at FROM stocks s WHERE " + stockNotDeleted + " AND s.name > ? ORDER BY s.name LIMIT ?"
+		args = []interface{}{after, limit}
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var levels []StockLevel
+	for rows.Next() {
+		level := StockLevel{Location: location}
+		var updatedAt time.Time
+		if err := rows.Scan(&level.Name, &level.Amount, &updatedAt); err != nil {
+			return nil, err
+		}
+		level.UpdatedAt = &updatedAt
+		levels = append(levels, level)
+	}
+	return levels, rows.Err()
+}
+
+// getStockLocations は在庫の全てのロケーションの在庫数と合計を取得します。
+// 既定のロケーションを先頭に、その他のロケーションをコード順に返します。
+func getStockLocations(ctx context.Context, db Storer, name string) (StockLocations, error) {
+	stock, err := getStock(ctx, db, name)
+	if err != nil {
+		return StockLocations{}, err
+	}
+	result := StockLocations{
+		Name:      name,
+		Total:     stock.Amount,
+		Locations: []StockLevel{{Name: name, Location: defaultLocation, Amount: stock.Amount, UpdatedAt: stock.UpdatedAt}},
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT location, amount, updated_at FROM stock_levels WHERE name = ? ORDER BY location", name)
+	if err != nil {
+		return StockLocations{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		level := StockLevel{Name: name}
+		var updatedAt time.Time
+		if err := rows.Scan(&level.Location, &level.Amount, &updatedAt); err != nil {
+			return StockLocations{}, err
+		}
+		level.UpdatedAt = &updatedAt
+		result.Total += level.Amount
+		result.Locations = append(result.Locations, level)
+	}
+	return result, rows.Err()
+}
+
+// setStockLevel は既定以外のロケーションの在庫数を上書きし、同じトランザクションで在庫移動を記録します。
+func setStockLevel(ctx context.Context, db Storer, name, location string, amount int, meta MovementMeta) error {
+	now := time.Now()
+	return db.WithTx(ctx, func(tx Querier) error {
+		if err := checkLocation(ctx, tx, location); err != nil {
+			return err
+		}
+		// 在庫行を先にロックし、同じ在庫に対する他の操作と順序を揃える
+		if _, err := lockStock(ctx, tx, name, now); err != nil {
+			return err
+		}
+		previous, err := lockStockLevel(ctx, tx, name, location)
+		if err != nil {
+			return err
+		}
+		if err := writeStockLevel(ctx, tx, name, location, amount, now); err != nil {
+			return err
+		}
+		return recordLocationMovement(ctx, tx, name, location, amount-previous, amount, meta, now)
+	})
+}
+
+// transferStock はロケーション間で在庫を移動し、両方のロケーションの在庫移動を記録します。
+// 既定のロケーションから移動する場合は、引当予約の数量を除いた引当可能数までしか移動できず、
+// 在庫数がしきい値を下回った場合は同じトランザクションで在庫アラートを記録します。
+func transferStock(ctx context.Context, db Storer, req TransferRequest, meta MovementMeta) (Transfer, error) {
+	now := time.Now()
+	transfer := Transfer{Name: req.Name, From: req.From, To: req.To, Amount: req.Amount}
+	err := db.WithTx(ctx, func(tx Querier) error {
+		for _, location := range []string{req.From, req.To} {
+			if err := checkLocation(ctx, tx, location); err != nil {
+				return err
+			}
+		}
+
+		// 在庫行、stock_levels の行（ロケーションのコード順）の順にロックする
+		stock, err := lockStock(ctx, tx, req.Name, now)
+		if err != nil {
+			return err
+		}
+		amounts := map[string]int{defaultLocation: stock.Amount}
+		locations := []string{req.From, req.To}
+		sort.Strings(locations)
+		for _, location := range locations {
+			if location == defaultLocation {
+				continue
+			}
+			if amounts[location], err = lockStockLevel(ctx, tx, req.Name, location); err != nil {
+				return err
+			}
+		}
+
+		available := amounts[req.From]
+		if req.From == defaultLocation {
+			available = stock.Available
+		}
+		if available < req.Amount {
+			return fmt.Errorf("%w: %d available at %s", errInsufficientStock, available, req.From)
+		}
+
+		transfer.FromAmount = amounts[req.From] - req.Amount
+		transfer.ToAmount = amounts[req.To] + req.Amount
+		if err := writeStockLevel(ctx, tx, req.Name, req.From, transfer.FromAmount, now); err != nil {
+			return err
+		}
+		if err := writeStockLevel(ctx, tx, req.Name, req.To, transfer.ToAmount, now); err != nil {
+			return err
+		}
+		if err := recordLocationMovement(ctx, tx, req.Name, req.From, -req.Amount, transfer.FromAmount, meta, now); err != nil {
+			return err
+		}
+		if err := recordLocationMovement(ctx, tx, req.Name, req.To, req.Amount, transfer.ToAmount, meta, now); err != nil {
+			return err
+		}
+		if req.From != defaultLocation {
+			return nil
+		}
+		// しきい値は既定のロケーションの在庫数（stocks.amount）と比べる
+		return checkLowStock(ctx, tx, req.Name, amounts[defaultLocation], transfer.FromAmount, now)
+	})
+	return transfer, err
+}
+
+// lockStockLevel は既定以外のロケーションの在庫数の行をロックして読み込みます。行がない場合は 0 を返します。
+func lockStockLevel(ctx context.Context, tx Querier, name, location string) (int, error) {
+	var amount int
+	err := tx.QueryRowContext(ctx, "SELECT amount FROM stock_levels WHERE name = ? AND location = ? FOR UPDATE", name, location).Scan(&amount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return amount, err
+}
+
+// writeStockLevel はロケーションの在庫数を書き込みます。
+// 既定のロケーションは stocks テーブルの行を、その他のロケーションは stock_levels テーブルの行を更新します。
+// stock_levels の行の作成と更新は、PostgreSQL と SQLite では ON CONFLICT で行います。
+func writeStockLevel(ctx context.Context, tx Querier, name, location string, amount int, now time.Time) error {
+	if location == defaultLocation {
+		_, err := tx.ExecContext(ctx, "UPDATE stocks SET amount = ?, version = version + 1 WHERE name = ?", amount, name)
+		return err
+	}
+	query := "INSERT INTO stock_levels (name, location, amount, updated_at) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE amount = ?, updated_at = ?"
+	if queryDriver(tx) != driverMySQL {
+		query = "INSERT INTO stock_levels (name, location, amount, updated_at) VALUES (?, ?, ?, ?) ON CONFLICT (name, location) DO UPDATE SET amount = ?, updated_at = ?"
+	}
+	_, err := tx.ExecContext(ctx, query, name, location, amount, now, amount, now)
+	return err
+}
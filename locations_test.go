@@ -0,0 +1,353 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expectCheckLocation は checkLocation が発行するクエリの期待値を設定します。
+func expectCheckLocation(mock sqlmock.Sqlmock, code string, found bool) {
+	rows := sqlmock.NewRows([]string{"code"})
+	if found {
+		rows.AddRow(code)
+	}
+	mock.ExpectQuery("SELECT code FROM locations WHERE code = \\?").WithArgs(code).WillReturnRows(rows)
+}
+
+// expectLockStockLevel は lockStockLevel が発行するクエリの期待値を設定します。amount が負の場合は行がないものとします。
+func expectLockStockLevel(mock sqlmock.Sqlmock, name, location string, amount int) {
+	rows := sqlmock.NewRows([]string{"amount"})
+	if amount >= 0 {
+		rows.AddRow(amount)
+	}
+	mock.ExpectQuery("SELECT amount FROM stock_levels WHERE name = \\? AND location = \\? FOR UPDATE").
+		WithArgs(name, location).
+		WillReturnRows(rows)
+}
+
+func TestTransferStockHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name         string
+		requestBody  string
+		mockSetup    func(mock sqlmock.Sqlmock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:        "既定のロケーションから倉庫に移動し、しきい値を下回ればアラートを記録する",
+			requestBody: `{"name":"apple","from":"default","to":"osaka","amount":4}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectCheckLocation(mock, "osaka", true)
+				expectLockStock(mock, "apple", 10, 2)
+				expectLockStockLevel(mock, "apple", "osaka", -1)
+				mock.ExpectExec("UPDATE stocks SET amount = \\?, version = version \\+ 1 WHERE name = \\?").
+					WithArgs(6, "apple").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO stock_levels (.+) ON DUPLICATE KEY UPDATE").
+					WithArgs("apple", "osaka", 4, sqlmock.AnyArg(), 4, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectRecordLocationMovement(mock, "apple", "default", -4, 6, "transfer")
+				expectRecordLocationMovement(mock, "apple", "osaka", 4, 4, "transfer")
+				expectThresholds(mock, "apple", 8, nil)
+				mock.ExpectExec("INSERT INTO stock_alerts").
+					WithArgs("apple", alertReorderPoint, 8, 6, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"name":"apple","from":"default","to":"osaka","amount":4,"from_amount":6,"to_amount":4}`,
+		},
+		{
+			name:        "倉庫間の移動はロケーションのコード順にロックする",
+			requestBody: `{"name":"apple","from":"tokyo","to":"osaka","amount":3}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectCheckLocation(mock, "tokyo", true)
+				expectCheckLocation(mock, "osaka", true)
+				expectLockStock(mock, "apple", 10, 0)
+				expectLockStockLevel(mock, "apple", "osaka", 1)
+				expectLockStockLevel(mock, "apple", "tokyo", 5)
+				mock.ExpectExec("INSERT INTO stock_levels").
+					WithArgs("apple", "tokyo", 2, sqlmock.AnyArg(), 2, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("INSERT INTO stock_levels").
+					WithArgs("apple", "osaka", 4, sqlmock.AnyArg(), 4, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				expectRecordLocationMovement(mock, "apple", "tokyo", -3, 2, "transfer")
+				expectRecordLocationMovement(mock, "apple", "osaka", 3, 4, "transfer")
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"from_amount":2,"to_amount":4`,
+		},
+		{
+			name:        "既定のロケーションからは引当予約を除いた数までしか移動できない",
+			requestBody: `{"name":"apple","from":"default","to":"osaka","amount":9}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectCheckLocation(mock, "osaka", true)
+				expectLockStock(mock, "apple", 10, 2)
+				expectLockStockLevel(mock, "apple", "osaka", -1)
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: "insufficient stock: 8 available at default",
+		},
+		{
+			name:        "未登録のロケーションは404",
+			requestBody: `{"name":"apple","from":"default","to":"nagoya","amount":1}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectCheckLocation(mock, "nagoya", false)
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "Not found",
+		},
+		{
+			name:         "移動元と移動先が同じ場合は400",
+			requestBody:  `{"name":"apple","from":"osaka","to":"osaka","amount":1}`,
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "From and to must be different locations",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			router := gin.New()
+			require.NoError(t, setupRoutes(router, &SQLDB{DB: db}))
+
+			req, _ := http.NewRequest(http.MethodPost, "/v1/transfers", bytes.NewBufferString(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			responseBody := w.Body.String()
+			t.Logf("テストケース: %s", tc.name)
+			t.Logf("レスポンスボディ: %s", responseBody)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Contains(t, responseBody, tc.expectedBody)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
+
+func TestPutStockLevelHandlerDefaultLocation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	updatedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	stocks := newMemoryStockRepository(Stock{Name: "apple", Amount: 10})
+	stocks.now = func() time.Time { return updatedAt }
+
+	// 既定のロケーションは StockRepository で上書きするため、SQL のデータベースを使わない
+	router := gin.New()
+	router.PUT("/v1/locations/:code/stocks/:name", putStockLevelHandler(nil, stocks))
+
+	req, _ := http.NewRequest(http.MethodPut, "/v1/locations/default/stocks/apple", bytes.NewBufferString(`{"amount":4}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"name":"apple","location":"default","amount":4,"updated_at":"2026-01-02T03:04:05Z"}`, w.Body.String())
+	stock, err := stocks.Get(req.Context(), "apple", false)
+	require.NoError(t, err)
+	assert.Equal(t, 4, stock.Amount)
+}
+
+func TestLocationHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	stockColumns := []string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}
+	updatedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		method       string
+		path         string
+		requestBody  string
+		mockSetup    func(mock sqlmock.Sqlmock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:        "ロケーションを登録する",
+			method:      http.MethodPost,
+			path:        "/v1/locations",
+			requestBody: `{"code":"osaka","name":"大阪倉庫"}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO locations").
+					WithArgs("osaka", "大阪倉庫", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expectedCode: http.StatusCreated,
+			expectedBody: `{"code":"osaka","name":"大阪倉庫"`,
+		},
+		{
+			name:        "登録済みのロケーションは409",
+			method:      http.MethodPost,
+			path:        "/v1/locations",
+			requestBody: `{"code":"osaka"}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO locations").
+					WithArgs("osaka", "osaka", sqlmock.AnyArg()).
+					WillReturnError(&mysql.MySQLError{Number: 1062})
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: "location already exists",
+		},
+		{
+			name:         "不正なコードは400",
+			method:       http.MethodPost,
+			path:         "/v1/locations",
+			requestBody:  `{"code":"大阪"}`,
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "Code must be",
+		},
+		{
+			name:   "全てのロケーションの在庫数を集計する",
+			method: http.MethodGet,
+			path:   "/v1/stocks/apple/locations",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s").
+					WithArgs(sqlmock.AnyArg(), "apple").
+					WillReturnRows(sqlmock.NewRows(stockColumns).AddRow("apple", 6, 0, 2, nil, nil))
+				mock.ExpectQuery("SELECT location, amount, updated_at FROM stock_levels WHERE name = \\? ORDER BY location").
+					WithArgs("apple").
+					WillReturnRows(sqlmock.NewRows([]string{"location", "amount", "updated_at"}).
+						AddRow("osaka", 4, updatedAt).
+						AddRow("tokyo", 0, updatedAt))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"name":"apple","total":10,"locations":[{"name":"apple","location":"default","amount":6},{"name":"apple","location":"osaka","amount":4,"updated_at":"2026-01-02T03:04:05Z"},{"name":"apple","location":"tokyo","amount":0,"updated_at":"2026-01-02T03:04:05Z"}]}`,
+		},
+		{
+			name:   "ロケーションにない在庫は在庫数0",
+			method: http.MethodGet,
+			path:   "/v1/locations/tokyo/stocks/apple",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectCheckLocation(mock, "tokyo", true)
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s").
+					WithArgs(sqlmock.AnyArg(), "apple").
+					WillReturnRows(sqlmock.NewRows(stockColumns).AddRow("apple", 6, 0, 2, nil, nil))
+				mock.ExpectQuery("SELECT location, amount, updated_at FROM stock_levels").
+					WithArgs("apple").
+					WillReturnRows(sqlmock.NewRows([]string{"location", "amount", "updated_at"}).AddRow("osaka", 4, updatedAt))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"name":"apple","location":"tokyo","amount":0}`,
+		},
+		{
+			name:   "ロケーションの在庫を名前順に返す",
+			method: http.MethodGet,
+			path:   "/v1/locations/osaka/stocks?limit=1",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectCheckLocation(mock, "osaka", true)
+				mock.ExpectQuery("SELECT l.name, l.amount, l.updated_at FROM stock_levels l JOIN stocks s (.+) ORDER BY l.name LIMIT \\?").
+					WithArgs("osaka", "", 2).
+					WillReturnRows(sqlmock.NewRows([]string{"name", "amount", "updated_at"}).
+						AddRow("apple", 4, updatedAt).
+						AddRow("banana", 1, updatedAt))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"stocks":[{"name":"apple","location":"osaka","amount":4,"updated_at":"2026-01-02T03:04:05Z"}],"next_cursor":"` + encodeCursor("apple") + `"`,
+		},
+		{
+			name:   "既定のロケーションの在庫はstocksテーブルから返す",
+			method: http.MethodGet,
+			path:   "/v1/locations/default/stocks?cursor=" + encodeCursor("apple"),
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT s.name, s.amount, s.updated_at FROM stocks s WHERE s.deleted_at IS NULL AND s.name > \\?").
+					WithArgs("apple", defaultStockLevelLimit+1).
+					WillReturnRows(sqlmock.NewRows([]string{"name", "amount", "updated_at"}).AddRow("banana", 5, updatedAt))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"location":"default","stocks":[{"name":"banana","location":"default","amount":5,"updated_at":"2026-01-02T03:04:05Z"}]}`,
+		},
+		{
+			name:        "倉庫の在庫数を棚卸しの結果で上書きする",
+			method:      http.MethodPut,
+			path:        "/v1/locations/osaka/stocks/apple",
+			requestBody: `{"amount":7}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectCheckLocation(mock, "osaka", true)
+				expectLockStock(mock, "apple", 6, 0)
+				expectLockStockLevel(mock, "apple", "osaka", 4)
+				mock.ExpectExec("INSERT INTO stock_levels").
+					WithArgs("apple", "osaka", 7, sqlmock.AnyArg(), 7, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				expectRecordLocationMovement(mock, "apple", "osaka", 3, 7, "stocktake")
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"name":"apple","location":"osaka","amount":7}`,
+		},
+		{
+			name:        "未登録の在庫は倉庫の在庫数を設定できない",
+			method:      http.MethodPut,
+			path:        "/v1/locations/osaka/stocks/kiwi",
+			requestBody: `{"amount":7}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectCheckLocation(mock, "osaka", true)
+				mock.ExpectQuery("SELECT amount, version FROM stocks WHERE name = \\?").
+					WithArgs("kiwi").
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "Not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			router := gin.New()
+			require.NoError(t, setupRoutes(router, &SQLDB{DB: db}))
+
+			req, _ := http.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			responseBody := w.Body.String()
+			t.Logf("テストケース: %s", tc.name)
+			t.Logf("レスポンスボディ: %s", responseBody)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Contains(t, responseBody, tc.expectedBody)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
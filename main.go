@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	_ "lambda-api-gw-go/docs" // swaggoが生成したSwaggerドキュメントをインポート
 
@@ -18,6 +19,10 @@ import (
 
 var ginLambda *ginadapter.GinLambda
 
+// responseMargin は Lambda の実行期限より前にリクエストを打ち切る余裕です。
+// 期限の直前に DB の処理を中断し、Lambda が強制終了される前に 504 を返します。
+const responseMargin = 500 * time.Millisecond
+
 // アプリケーション設定を一元管理する構造体
 type AppConfig struct {
 	Host string
@@ -62,6 +67,13 @@ func init() {
 
 // Lambda用ハンドラー関数
 func Handler(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// Lambda の実行期限をリクエストの Context に引き継ぎ、DB の処理まで伝える
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline.Add(-responseMargin))
+		defer cancel()
+	}
+
 	// Ginルーターにリクエストを転送
 	return ginLambda.ProxyWithContext(ctx, req)
 }
@@ -70,7 +82,12 @@ func main() {
 	// ローカル開発環境とLambda環境を判別
 	if os.Getenv("AWS_LAMBDA_FUNCTION_NAME") != "" {
 		// Lambda環境ではLambdaハンドラを起動
-		lambda.Start(Handler)
+		// LAMBDA_HANDLER=worker の関数はスケジュールから定期実行ジョブを受け取る
+		if os.Getenv("LAMBDA_HANDLER") == "worker" {
+			lambda.Start(WorkerHandler)
+		} else {
+			lambda.Start(Handler)
+		}
 	} else {
 		// ローカル環境では通常のHTTPサーバーを起動
 		config := loadConfig()
@@ -94,7 +111,10 @@ func setupRouter(config AppConfig) *gin.Engine {
 	}
 
 	// ルート設定
-	setupRoutes(r, db)
+	// 設定の誤りは Lambda でも起動時に失敗させ、誤った配信先や保存先でリクエストを処理しない
+	if err := setupRoutes(r, db); err != nil {
+		log.Fatalf("Failed to configure routes: %v", err)
+	}
 
 	// ドキュメントを提供するエンドポイント
 	r.GET("/api-docs/swagger.yaml", func(c *gin.Context) {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
@@ -69,8 +70,8 @@ func TestE2ESwaggerWithYAML(t *testing.T) {
 
 	// 1. Swagger UIのテスト（インデックスページにアクセス）
 	t.Run("Swagger UI Test", func(t *testing.T) {
-		// 直接indexページにアクセス
-		req, _ := http.NewRequest("GET", "/swagger/index.html", nil)
+		// 直接indexページにアクセス（gin-swagger は RequestURI でファイルを判定するため httptest.NewRequest を使う）
+		req := httptest.NewRequest("GET", "/swagger/index.html", nil)
 		w := httptest.NewRecorder()
 		r.ServeHTTP(w, req)
 
@@ -107,13 +108,13 @@ func TestE2EAPIAgainstSwagger(t *testing.T) {
 
 	// テスト用のルーターを設定
 	r := gin.New()
-	setupRoutes(r, db) // 実際のルートを設定
+	require.NoError(t, setupRoutes(r, db)) // 実際のルートを設定
 
 	// Swagger定義からAPIエンドポイントをテスト
 
-	// 1. GET /stocks のテスト
-	t.Run("GET /stocks", func(t *testing.T) {
-		req, _ := http.NewRequest("GET", "/stocks", nil)
+	// 1. GET /v1/stocks のテスト
+	t.Run("GET /v1/stocks", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/v1/stocks", nil)
 		w := httptest.NewRecorder()
 		r.ServeHTTP(w, req)
 
@@ -123,10 +124,10 @@ func TestE2EAPIAgainstSwagger(t *testing.T) {
 		assert.Contains(t, contentType, "application/json")
 	})
 
-	// 2. GET /stocks/:name のテスト
-	t.Run("GET /stocks/:name", func(t *testing.T) {
+	// 2. GET /v1/stocks/:name のテスト
+	t.Run("GET /v1/stocks/:name", func(t *testing.T) {
 		// 存在する商品名で検証
-		req, _ := http.NewRequest("GET", "/stocks/apple", nil)
+		req, _ := http.NewRequest("GET", "/v1/stocks/apple", nil)
 		w := httptest.NewRecorder()
 		r.ServeHTTP(w, req)
 
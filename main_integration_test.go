@@ -11,6 +11,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -20,6 +22,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/mysql"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -33,6 +36,26 @@ func TestMain(m *testing.M) {
 
 // MySQLコンテナを起動し、接続用のSQLDBを返す関数
 func setupMySQLContainer(t *testing.T) (*SQLDB, func(), error) {
+	db, cleanup, err := startMySQLContainer(t)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// テーブル作成（作成済みのテーブルに対してマイグレーションが何もしないことも確認する）
+	if err := applySchema(db, "schema.sql"); err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("テーブル作成に失敗しました: %v", err)
+	}
+	if err := applyMigrations(db); err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("マイグレーションに失敗しました: %v", err)
+	}
+
+	return &SQLDB{DB: db}, cleanup, nil
+}
+
+// MySQLコンテナを起動し、テーブルを作成せずに接続を返す関数
+func startMySQLContainer(t *testing.T) (*sql.DB, func(), error) {
 	ctx := context.Background()
 
 	// MySQLコンテナの設定
@@ -95,39 +118,175 @@ func setupMySQLContainer(t *testing.T) (*SQLDB, func(), error) {
 		return nil, nil, fmt.Errorf("データベース接続テストに失敗しました: %v", pingErr)
 	}
 
-	// テーブル作成
-	_, err = db.Exec(`
-        CREATE TABLE IF NOT EXISTS stocks (
-            name VARCHAR(255) PRIMARY KEY,
-            amount INT NOT NULL
-        )
-    `)
+	return db, cleanup, nil
+}
+
+// PostgreSQLコンテナを起動し、接続用のSQLDBを返す関数
+func setupPostgresContainer(t *testing.T) (*SQLDB, func(), error) {
+	ctx := context.Background()
+
+	postgresContainer, err := postgres.Run(ctx,
+		"postgres:16-alpine",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(time.Second*180)),
+	)
 	if err != nil {
+		return nil, nil, fmt.Errorf("PostgreSQLコンテナの起動に失敗しました: %v", err)
+	}
+
+	cleanup := func() {
+		if err := postgresContainer.Terminate(ctx); err != nil {
+			t.Fatalf("PostgreSQLコンテナの終了に失敗しました: %v", err)
+		}
+	}
+
+	dsn, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("接続文字列の取得に失敗しました: %v", err)
+	}
+
+	db, err := sql.Open(driverPostgres, dsn)
+	if err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("データベース接続に失敗しました: %v", err)
+	}
+	if err := db.Ping(); err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("データベース接続テストに失敗しました: %v", err)
+	}
+
+	if err := applySchema(db, "schema_postgres.sql"); err != nil {
 		cleanup()
 		return nil, nil, fmt.Errorf("テーブル作成に失敗しました: %v", err)
 	}
 
-	return &SQLDB{DB: db}, cleanup, nil
+	return &SQLDB{DB: db, Driver: driverPostgres}, cleanup, nil
 }
 
 // 結合テスト: API全体のテスト
+// 同じシナリオを MySQL と PostgreSQL の両方で実行します。
 func TestAPIIntegration(t *testing.T) {
 	// テスト用ルーターの設定
 	gin.SetMode(gin.TestMode)
 
-	// MySQLコンテナのセットアップ
-	db, cleanup, err := setupMySQLContainer(t)
+	engines := []struct {
+		name  string
+		setup func(t *testing.T) (*SQLDB, func(), error)
+	}{
+		{name: "MySQL", setup: setupMySQLContainer},
+		{name: "PostgreSQL", setup: setupPostgresContainer},
+	}
+	for _, engine := range engines {
+		t.Run(engine.name, func(t *testing.T) {
+			db, cleanup, err := engine.setup(t)
+			require.NoError(t, err)
+			defer cleanup()
+			defer db.Close()
+
+			testStockAPI(t, db)
+		})
+	}
+}
+
+// 結合テスト: PostgreSQL の stocks.updated_at のトリガー
+func TestPostgresStocksUpdatedAt(t *testing.T) {
+	db, cleanup, err := setupPostgresContainer(t)
 	require.NoError(t, err)
 	defer cleanup()
 	defer db.Close()
 
+	past := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	_, err = db.Exec("INSERT INTO stocks (name, amount, updated_at) VALUES (?, ?, ?)", "apple", 5, past)
+	require.NoError(t, err)
+
+	// updated_at を指定せずに更新すると、トリガーが現在時刻を設定する
+	_, err = db.Exec("UPDATE stocks SET amount = amount - 1, version = version + 1 WHERE name = ?", "apple")
+	require.NoError(t, err)
+	var updatedAt time.Time
+	require.NoError(t, db.QueryRow("SELECT updated_at FROM stocks WHERE name = ?", "apple").Scan(&updatedAt))
+	assert.True(t, updatedAt.After(past), "updated_at が更新されていません: %v", updatedAt)
+
+	// updated_at を指定した場合はその値を使う
+	_, err = db.Exec("UPDATE stocks SET amount = 1, updated_at = ? WHERE name = ?", past, "apple")
+	require.NoError(t, err)
+	require.NoError(t, db.QueryRow("SELECT updated_at FROM stocks WHERE name = ?", "apple").Scan(&updatedAt))
+	assert.True(t, updatedAt.Equal(past), "updated_at が指定した値ではありません: %v", updatedAt)
+}
+
+// 結合テスト: 既存のデータベースのマイグレーション
+// 列を追加する前の stocks と stock_movements にマイグレーションを適用し、既存の在庫を操作できることを確認します。
+func TestMySQLMigrations(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, cleanup, err := startMySQLContainer(t)
+	require.NoError(t, err)
+	defer cleanup()
+	defer db.Close()
+
+	for _, stmt := range []string{
+		"CREATE TABLE stocks (name VARCHAR(255) PRIMARY KEY, amount INT NOT NULL)",
+		"CREATE TABLE stock_movements (id BIGINT AUTO_INCREMENT PRIMARY KEY, name VARCHAR(255) NOT NULL, delta INT NOT NULL, amount_after INT NOT NULL, " +
+			"reason VARCHAR(64) NOT NULL, actor VARCHAR(255) NOT NULL, request_id VARCHAR(255) NOT NULL DEFAULT '', created_at DATETIME(6) NOT NULL, " +
+			"INDEX idx_stock_movements_name (name, id))",
+		"INSERT INTO stocks (name, amount) VALUES ('apple', 5)",
+	} {
+		_, err := db.Exec(stmt)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, applySchema(db, "schema.sql"))
+	require.NoError(t, applyMigrations(db))
+	// 2 回目の適用では何も変更しない
+	require.NoError(t, applyMigrations(db))
+
+	router := gin.New()
+	require.NoError(t, setupRoutes(router, &SQLDB{DB: db}))
+
+	steps := []struct {
+		name         string
+		method       string
+		path         string
+		requestBody  string
+		expectedCode int
+		expectedBody string
+	}{
+		{name: "既存の在庫に加算する", method: http.MethodPost, path: "/v1/stocks", requestBody: `{"name":"apple","amount":3}`,
+			expectedCode: http.StatusOK, expectedBody: `"name":"apple","amount":8`},
+		{name: "更新日時の順に一覧を取得する", method: http.MethodGet, path: "/v1/stocks?sort=-updated_at",
+			expectedCode: http.StatusOK, expectedBody: `"name":"apple","amount":8`},
+		{name: "発注点を設定する", method: http.MethodPut, path: "/v1/stocks/apple/thresholds", requestBody: `{"reorder_point":3,"safety_stock":1}`,
+			expectedCode: http.StatusOK, expectedBody: `"reorder_point":3`},
+		{name: "在庫移動の履歴を取得する", method: http.MethodGet, path: "/v1/stocks/apple/history",
+			expectedCode: http.StatusOK, expectedBody: `"delta":3`},
+		{name: "在庫を論理削除する", method: http.MethodDelete, path: "/v1/stocks/apple",
+			expectedCode: http.StatusNoContent},
+	}
+	for _, step := range steps {
+		req, _ := http.NewRequest(step.method, step.path, newJSONReader(step.requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, step.expectedCode, w.Code, step.name+": "+w.Body.String())
+		assert.Contains(t, w.Body.String(), step.expectedBody, step.name)
+	}
+}
+
+// testStockAPI は在庫 API のシナリオを db に対して実行します。
+func testStockAPI(t *testing.T, db *SQLDB) {
 	// テストデータを準備
-	_, err = db.Exec("DELETE FROM stocks") // テーブルをクリア
+	_, err := db.Exec("DELETE FROM stocks") // テーブルをクリア
 	require.NoError(t, err)
 
 	// Ginルーターのセットアップ
 	router := gin.Default()
-	setupRoutes(router, db)
+	require.NoError(t, setupRoutes(router, db))
 
 	// テストケース
 	t.Run("在庫の作成と取得", func(t *testing.T) {
@@ -241,9 +400,10 @@ func TestAPIIntegration(t *testing.T) {
 		// レスポンスを検証
 		assert.Equal(t, http.StatusOK, w.Code)
 		// レスポンスをJSONとしてパース
-		var stocks []Stock
-		err = json.Unmarshal(w.Body.Bytes(), &stocks)
+		var page StockPage
+		err = json.Unmarshal(w.Body.Bytes(), &page)
 		require.NoError(t, err, "レスポンスのJSONパースに失敗しました")
+		stocks := page.Stocks
 
 		// スライスの長さを確認
 		assert.Len(t, stocks, 2, "在庫アイテムは2つあるべきです")
@@ -275,6 +435,79 @@ func TestAPIIntegration(t *testing.T) {
 	})
 }
 
+// sqlExecer は *sql.DB と *sql.Conn の共通インターフェースです。
+type sqlExecer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// applySchema はスキーマファイルの SQL 文を 1 文ずつ実行します。
+func applySchema(db sqlExecer, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	for _, stmt := range splitSQLStatements(string(data)) {
+		// コメント行を除外する
+		var lines []string
+		for _, line := range strings.Split(stmt, "\n") {
+			if !strings.HasPrefix(strings.TrimSpace(line), "--") {
+				lines = append(lines, line)
+			}
+		}
+		stmt = strings.TrimSpace(strings.Join(lines, "\n"))
+		if stmt == "" {
+			continue
+		}
+		if _, err := db.ExecContext(context.Background(), stmt); err != nil {
+			return fmt.Errorf("%s: %w", stmt, err)
+		}
+	}
+	return nil
+}
+
+// splitSQLStatements は SQL を ; で 1 文ずつに分割します。
+// PostgreSQL の関数本体のように $$ で囲まれた部分の ; では分割しません。
+func splitSQLStatements(script string) []string {
+	var (
+		stmts  []string
+		quoted bool
+		start  int
+	)
+	for i := 0; i < len(script); i++ {
+		switch {
+		case strings.HasPrefix(script[i:], "$$"):
+			quoted = !quoted
+			i++
+		case script[i] == ';' && !quoted:
+			stmts = append(stmts, script[start:i])
+			start = i + 1
+		}
+	}
+	return append(stmts, script[start:])
+}
+
+// applyMigrations は migrations ディレクトリの SQL をファイル名の順に実行します。
+// マイグレーションはセッション変数を使うため、1 つの接続で実行します。
+func applyMigrations(db *sql.DB) error {
+	paths, err := filepath.Glob("migrations/*.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	for _, path := range paths {
+		if err := applySchema(conn, path); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
 // JSONリクエストボディを作成するヘルパー関数
 func newJSONReader(jsonStr string) *strings.Reader {
 	return strings.NewReader(jsonStr)
@@ -9,6 +9,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSetupRoutes(t *testing.T) {
@@ -48,12 +49,12 @@ func TestSetupRoutes(t *testing.T) {
 	// connectDB 関数の代わりに直接モックを使用
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
-	setupRoutes(router, mockStorer)
+	require.NoError(t, setupRoutes(router, mockStorer))
 
 	// モックの準備：getAllStocks 用のクエリ設定
-	mock.ExpectQuery("SELECT \\* FROM stocks").WillReturnRows(
-		sqlmock.NewRows([]string{"name", "amount"}).
-			AddRow("test_stock", 10))
+	mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s").WillReturnRows(
+		sqlmock.NewRows([]string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}).
+			AddRow("test_stock", 10, 0, 1, nil, nil))
 
 	t.Run("GET /v1/stocks returns 200", func(t *testing.T) {
 		w := httptest.NewRecorder()
@@ -0,0 +1,10 @@
+-- 在庫行のバージョン（ETag / If-Match による楽観的排他制御）を追加します。
+
+SET @ddl = IF(
+    (SELECT COUNT(*) FROM information_schema.COLUMNS
+     WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'stocks' AND COLUMN_NAME = 'version') = 0,
+    'ALTER TABLE stocks ADD COLUMN version BIGINT NOT NULL DEFAULT 1',
+    'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
@@ -0,0 +1,10 @@
+-- 在庫の論理削除の日時を追加します。
+
+SET @ddl = IF(
+    (SELECT COUNT(*) FROM information_schema.COLUMNS
+     WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'stocks' AND COLUMN_NAME = 'deleted_at') = 0,
+    'ALTER TABLE stocks ADD COLUMN deleted_at DATETIME(6) NULL',
+    'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
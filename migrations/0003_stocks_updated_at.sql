@@ -0,0 +1,29 @@
+-- 一覧の絞り込みと並び替えに使う在庫の更新日時と、並び替え用のインデックスを追加します。
+-- 既存の行の更新日時はマイグレーションを実行した日時になります。
+
+SET @ddl = IF(
+    (SELECT COUNT(*) FROM information_schema.COLUMNS
+     WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'stocks' AND COLUMN_NAME = 'updated_at') = 0,
+    'ALTER TABLE stocks ADD COLUMN updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6)',
+    'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @ddl = IF(
+    (SELECT COUNT(*) FROM information_schema.STATISTICS
+     WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'stocks' AND INDEX_NAME = 'idx_stocks_amount') = 0,
+    'CREATE INDEX idx_stocks_amount ON stocks (amount, name)',
+    'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @ddl = IF(
+    (SELECT COUNT(*) FROM information_schema.STATISTICS
+     WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'stocks' AND INDEX_NAME = 'idx_stocks_updated_at') = 0,
+    'CREATE INDEX idx_stocks_updated_at ON stocks (updated_at, name)',
+    'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
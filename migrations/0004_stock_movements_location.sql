@@ -0,0 +1,10 @@
+-- 在庫移動にロケーションを追加します。既存の在庫移動は既定のロケーション default の移動として扱います。
+
+SET @ddl = IF(
+    (SELECT COUNT(*) FROM information_schema.COLUMNS
+     WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'stock_movements' AND COLUMN_NAME = 'location') = 0,
+    'ALTER TABLE stock_movements ADD COLUMN location VARCHAR(64) NOT NULL DEFAULT ''default''',
+    'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
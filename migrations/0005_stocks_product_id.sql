@@ -0,0 +1,28 @@
+-- 在庫と商品の紐付けを追加します。schema.sql で products テーブルを作成してから実行してください。
+
+SET @ddl = IF(
+    (SELECT COUNT(*) FROM information_schema.COLUMNS
+     WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'stocks' AND COLUMN_NAME = 'product_id') = 0,
+    'ALTER TABLE stocks ADD COLUMN product_id BIGINT NULL',
+    'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @ddl = IF(
+    (SELECT COUNT(*) FROM information_schema.STATISTICS
+     WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'stocks' AND INDEX_NAME = 'idx_stocks_product') = 0,
+    'CREATE INDEX idx_stocks_product ON stocks (product_id)',
+    'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @ddl = IF(
+    (SELECT COUNT(*) FROM information_schema.KEY_COLUMN_USAGE
+     WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'stocks' AND COLUMN_NAME = 'product_id' AND REFERENCED_TABLE_NAME = 'products') = 0,
+    'ALTER TABLE stocks ADD FOREIGN KEY (product_id) REFERENCES products (id)',
+    'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
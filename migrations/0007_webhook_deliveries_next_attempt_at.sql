@@ -0,0 +1,20 @@
+-- Webhook の配信ログを配信待ちのキューとして使うため、次に送信する日時を追加します。
+-- 追加前の配信ログは配信済み（succeeded または failed）のため、next_attempt_at は NULL のままにします。
+
+SET @ddl = IF(
+    (SELECT COUNT(*) FROM information_schema.COLUMNS
+     WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'webhook_deliveries' AND COLUMN_NAME = 'next_attempt_at') = 0,
+    'ALTER TABLE webhook_deliveries ADD COLUMN next_attempt_at DATETIME(6) NULL',
+    'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @ddl = IF(
+    (SELECT COUNT(*) FROM information_schema.STATISTICS
+     WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'webhook_deliveries' AND INDEX_NAME = 'idx_webhook_deliveries_pending') = 0,
+    'CREATE INDEX idx_webhook_deliveries_pending ON webhook_deliveries (status, next_attempt_at)',
+    'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/awslabs/aws-lambda-go-api-proxy/core"
+	"github.com/gin-gonic/gin"
+)
+
+// 在庫移動の理由
+const (
+	movementReceipt           = "receipt"
+	movementStocktake         = "stocktake"
+	movementAllocation        = "allocation"
+	movementReservationCommit = "reservation_commit"
+	movementOrder             = "order"
+	movementDelete            = "delete"
+	movementRestore           = "restore"
+	movementTransfer          = "transfer"
+)
+
+const (
+	defaultHistoryLimit = 50
+	maxHistoryLimit     = 200
+)
+
+// Movement は在庫数の変更履歴（在庫移動）の 1 行です。
+// stock_movements テーブルは追記のみで、更新・削除は行いません。
+type Movement struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Location    string    `json:"location"`
+	Delta       int       `json:"delta"`
+	AmountAfter int       `json:"amount_after"`
+	Reason      string    `json:"reason"`
+	Actor       string    `json:"actor"`
+	RequestID   string    `json:"request_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// MovementMeta は在庫移動を記録する際の、誰が・なぜ・どのリクエストで変更したかの情報です。
+type MovementMeta struct {
+	Reason    string
+	Actor     string
+	RequestID string
+
+	// idempotencyKey は idempotencyMiddleware が処理中として登録したキーで、空でなければ在庫を変更したトランザクションで保持します。
+	idempotencyKey string
+}
+
+// MovementHistory は GET /stocks/:name/history のレスポンスです。
+type MovementHistory struct {
+	Name       string     `json:"name"`
+	Movements  []Movement `json:"movements"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
+
+// movementMetaFromContext はリクエストから在庫移動の記録に使う情報を取り出します。
+// 操作者は X-Actor ヘッダー、リクエスト ID は API Gateway のリクエストコンテキスト
+// （ローカル実行時は X-Request-Id ヘッダー）から取得します。
+func movementMetaFromContext(c *gin.Context, reason string) MovementMeta {
+	meta := MovementMeta{
+		Reason:    reason,
+		Actor:     c.GetHeader("X-Actor"),
+		RequestID: c.GetHeader("X-Request-Id"),
+	}
+
+	if gwCtx, ok := core.GetAPIGatewayContextFromContext(c.Request.Context()); ok {
+		if gwCtx.RequestID != "" {
+			meta.RequestID = gwCtx.RequestID
+		}
+		if meta.Actor == "" {
+			meta.Actor = gwCtx.Identity.User
+		}
+	}
+	if meta.Actor == "" {
+		meta.Actor = "anonymous"
+	}
+	meta.idempotencyKey = c.GetString(idempotencyKeyContextKey)
+	return meta
+}
+
+// recordMovement は既定のロケーションの在庫移動を 1 行追記します。
+// 在庫数を変更したのと同じトランザクション内で呼び出してください。
+func recordMovement(ctx context.Context, tx Querier, name string, delta, amountAfter int, meta MovementMeta, now time.Time) error {
+	return recordLocationMovement(ctx, tx, name, defaultLocation, delta, amountAfter, meta, now)
+}
+
+// recordLocationMovement は指定したロケーションの在庫移動を 1 行追記します。
+// amountAfter はそのロケーションの変更後の在庫数です。
+// 同じトランザクションで在庫イベントを outbox に書き込み、購読している Webhook の配信待ちとして記録します。
+func recordLocationMovement(ctx context.Context, tx Querier, name, location string, delta, amountAfter int, meta MovementMeta, now time.Time) error {
+	_, err := tx.ExecContext(ctx, "INSERT INTO stock_movements (name, location, delta, amount_after, reason, actor, request_id, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		name, location, delta, amountAfter, meta.Reason, meta.Actor, meta.RequestID, now)
+	if err != nil {
+		return err
+	}
+	event := newStockEvent(name, location, delta, amountAfter, meta, now)
+	if err := writeOutbox(ctx, tx, event); err != nil {
+		return err
+	}
+	if err := enqueueWebhookDeliveries(ctx, tx, event); err != nil {
+		return err
+	}
+	if meta.idempotencyKey != "" {
+		if err := holdIdempotencyKey(ctx, tx, meta.idempotencyKey, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// currentAmount はトランザクション内で在庫数を読み直します。
+// 同じトランザクションで更新した行はロック済みのため、更新後の値が返ります。
+// 在庫が論理削除されている場合は errStockDeleted を返します。
+func currentAmount(ctx context.Context, tx Querier, name string) (int, error) {
+	var (
+		amount  int
+		deleted bool
+	)
+	err := tx.QueryRowContext(ctx, "SELECT amount, deleted_at IS NOT NULL FROM stocks WHERE name = ?", name).Scan(&amount, &deleted)
+	if err != nil {
+		return 0, err
+	}
+	if deleted {
+		return 0, errStockDeleted
+	}
+	return amount, nil
+}
+
+// getStockHistoryHandler は GET /stocks/:name/history のリクエストを処理します。
+func getStockHistoryHandler(ledger StockLedger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		name := c.Param("name")
+
+		limit, err := parseLimit(c, defaultHistoryLimit, maxHistoryLimit)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		var beforeID int64
+		if v := c.Query("cursor"); v != "" {
+			key, err := decodeCursor(v)
+			if err == nil {
+				beforeID, err = strconv.ParseInt(key, 10, 64)
+			}
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": errInvalidCursor.Error()})
+				return
+			}
+		}
+
+		movements, err := ledger.Movements(ctx, name, beforeID, limit+1)
+		if err != nil {
+			writeServerError(c, err)
+			return
+		}
+
+		history := MovementHistory{Name: name, Movements: movements}
+		if len(movements) > limit {
+			// 1 件多く取得できた場合は次のページがある
+			history.Movements = movements[:limit]
+			history.NextCursor = encodeCursor(strconv.FormatInt(movements[limit-1].ID, 10))
+		}
+		if history.Movements == nil {
+			history.Movements = []Movement{}
+		}
+
+		c.JSON(http.StatusOK, history)
+	}
+}
+
+// getMovements は全てのロケーションの在庫移動を新しい順に取得します。
+// beforeID が 0 より大きい場合は、その ID より前の移動のみを返します。
+func getMovements(ctx context.Context, db Querier, name string, beforeID int64, limit int) ([]Movement, error) {
+	query := "SELECT id, name, location, delta, amount_after, reason, actor, request_id, created_at FROM stock_movements WHERE name = ?"
+	args := []interface{}{name}
+	if beforeID > 0 {
+		query += " AND id < ?"
+		args = append(args, beforeID)
+	}
+	query += " ORDER BY id DESC LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var movements []Movement
+	for rows.Next() {
+		var m Movement
+		if err := rows.Scan(&m.ID, &m.Name, &m.Location, &m.Delta, &m.AmountAfter, &m.Reason, &m.Actor, &m.RequestID, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		movements = append(movements, m)
+	}
+	return movements, rows.Err()
+}
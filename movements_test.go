@@ -0,0 +1,109 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStockHistoryHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	movementColumns := []string{"id", "name", "location", "delta", "amount_after", "reason", "actor", "request_id", "created_at"}
+
+	testCases := []struct {
+		name         string
+		query        string
+		mockSetup    func(mock sqlmock.Sqlmock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:  "次のページがある場合はnext_cursorを返す",
+			query: "?limit=2",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM stock_movements WHERE name = \\? ORDER BY id DESC LIMIT \\?").
+					WithArgs("apple", 3).
+					WillReturnRows(sqlmock.NewRows(movementColumns).
+						AddRow(12, "apple", "default", -60, 40, "allocation", "alice", "req-2", time.Now()).
+						AddRow(11, "apple", "default", 90, 100, "receipt", "bob", "req-1", time.Now()).
+						AddRow(10, "apple", "default", 10, 10, "receipt", "bob", "req-0", time.Now()))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"next_cursor":"` + encodeCursor("11") + `"`,
+		},
+		{
+			name:  "カーソル以前の移動を返す",
+			query: "?cursor=" + encodeCursor("11"),
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM stock_movements WHERE name = \\? AND id < \\? ORDER BY id DESC LIMIT \\?").
+					WithArgs("apple", int64(11), defaultHistoryLimit+1).
+					WillReturnRows(sqlmock.NewRows(movementColumns).
+						AddRow(10, "apple", "default", 10, 10, "receipt", "bob", "req-0", time.Now()))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"location":"default","delta":10,"amount_after":10,"reason":"receipt","actor":"bob","request_id":"req-0"`,
+		},
+		{
+			name:         "不正なカーソルの場合は400",
+			query:        "?cursor=" + encodeCursor("abc"),
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid cursor",
+		},
+		{
+			name:         "limitが上限を超える場合は400",
+			query:        "?limit=1000",
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "limit must be between 1 and 200",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			router := gin.Default()
+			router.GET("/stocks/:name/history", getStockHistoryHandler(sqlStockLedger{db: &SQLDB{DB: db}}))
+
+			req, _ := http.NewRequest(http.MethodGet, "/stocks/apple/history"+tc.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			responseBody := w.Body.String()
+			t.Logf("テストケース: %s", tc.name)
+			t.Logf("レスポンスボディ: %s", responseBody)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Contains(t, responseBody, tc.expectedBody)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
+
+func TestMovementMetaFromContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/stocks", nil)
+
+	meta := movementMetaFromContext(c, movementReceipt)
+	assert.Equal(t, MovementMeta{Reason: "receipt", Actor: "anonymous"}, meta)
+
+	c.Request.Header.Set("X-Actor", "alice")
+	c.Request.Header.Set("X-Request-Id", "req-1")
+	meta = movementMetaFromContext(c, movementReceipt)
+	assert.Equal(t, MovementMeta{Reason: "receipt", Actor: "alice", RequestID: "req-1"}, meta)
+}
@@ -118,7 +118,7 @@ func TestRoutesMappingToOpenAPI(t *testing.T) {
 
 	// 一時的なサーバーを起動してAPIハンドラーを登録
 	store := NewMockStore()
-	setupRoutes(router, store)
+	require.NoError(t, setupRoutes(router, store))
 
 	// 登録されたルートを取得
 	routes := router.Routes()
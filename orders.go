@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var errOrderNotFound = errors.New("order not found")
+
+// OrderLine は注文の明細行です。
+type OrderLine struct {
+	Name   string `json:"name"`
+	Amount int    `json:"amount"`
+}
+
+// OrderRequest は注文の作成リクエストのボディです。
+type OrderRequest struct {
+	Lines []OrderLine `json:"lines"`
+}
+
+// Order は全ての明細行の在庫を引き当て済みの注文です。
+type Order struct {
+	ID        string      `json:"id"`
+	Lines     []OrderLine `json:"lines"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// Shortage は在庫が不足している明細行の情報です。
+type Shortage struct {
+	Name      string `json:"name"`
+	Requested int    `json:"requested"`
+	Available int    `json:"available"`
+}
+
+// ShortageError は 1 つ以上の明細行で在庫が不足していることを表します。
+type ShortageError struct {
+	Shortages []Shortage
+}
+
+func (e *ShortageError) Error() string {
+	return errInsufficientStock.Error()
+}
+
+func (e *ShortageError) Unwrap() error {
+	return errInsufficientStock
+}
+
+// createOrderHandler は POST /orders のリクエストを処理します。
+func createOrderHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var req OrderRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+
+		if len(req.Lines) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "At least one line is required"})
+			return
+		}
+		for _, line := range req.Lines {
+			if line.Name == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Name is required"})
+				return
+			}
+			if line.Amount <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must be greater than 0"})
+				return
+			}
+		}
+
+		order, err := createOrder(ctx, db, req.Lines, movementMetaFromContext(c, movementOrder))
+		var shortageErr *ShortageError
+		switch {
+		case errors.Is(err, errStockNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		case errors.As(err, &shortageErr):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "shortages": shortageErr.Shortages})
+			return
+		case err != nil:
+			writeServerError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, order)
+	}
+}
+
+// getOrderHandler は GET /orders/:id のリクエストを処理します。
+func getOrderHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		order, err := getOrder(ctx, db, c.Param("id"))
+		if errors.Is(err, errOrderNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			writeServerError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, order)
+	}
+}
+
+// mergeOrderLines は同じ在庫の明細行を合算し、在庫名の昇順に並べます。
+// 在庫行を常に同じ順序でロックすることで、同時に処理される注文同士のデッドロックを防ぎます。
+func mergeOrderLines(lines []OrderLine) []OrderLine {
+	amounts := make(map[string]int, len(lines))
+	for _, line := range lines {
+		amounts[line.Name] += line.Amount
+	}
+
+	merged := make([]OrderLine, 0, len(amounts))
+	for name, amount := range amounts {
+		merged = append(merged, OrderLine{Name: name, Amount: amount})
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Name < merged[j].Name
+	})
+	return merged
+}
+
+// createOrder は全ての明細行の在庫を 1 つのトランザクションで引き当てます。
+// 1 行でも在庫が不足していれば何も引き当てずにロールバックします。
+func createOrder(ctx context.Context, db Storer, lines []OrderLine, meta MovementMeta) (Order, error) {
+	now := time.Now()
+	order := Order{
+		ID:        uuid.NewString(),
+		Lines:     mergeOrderLines(lines),
+		CreatedAt: now,
+	}
+
+	err := db.WithTx(ctx, func(tx Querier) error {
+		// 全ての在庫行を在庫名の昇順でロックしてから在庫数を確認する
+		var shortages []Shortage
+		amounts := make([]int, len(order.Lines))
+		for i, line := range order.Lines {
+			stock, err := lockStock(ctx, tx, line.Name, now)
+			if err != nil {
+				return err
+			}
+			amounts[i] = stock.Amount
+			if stock.Available < line.Amount {
+				shortages = append(shortages, Shortage{
+					Name:      line.Name,
+					Requested: line.Amount,
+					Available: stock.Available,
+				})
+			}
+		}
+		if len(shortages) > 0 {
+			return &ShortageError{Shortages: shortages}
+		}
+
+		for i, line := range order.Lines {
+			if _, err := tx.ExecContext(ctx, "UPDATE stocks SET amount = amount - ?, version = version + 1 WHERE name = ?", line.Amount, line.Name); err != nil {
+				return err
+			}
+			if err := recordMovement(ctx, tx, line.Name, -line.Amount, amounts[i]-line.Amount, meta, now); err != nil {
+				return err
+			}
+			if err := checkLowStock(ctx, tx, line.Name, amounts[i], amounts[i]-line.Amount, now); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, "INSERT INTO orders (id, created_at) VALUES (?, ?)", order.ID, order.CreatedAt); err != nil {
+			return err
+		}
+		for i, line := range order.Lines {
+			_, err := tx.ExecContext(ctx, "INSERT INTO order_lines (order_id, line_no, name, amount) VALUES (?, ?, ?, ?)",
+				order.ID, i+1, line.Name, line.Amount)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Order{}, err
+	}
+	return order, nil
+}
+
+// getOrder は ID を指定して注文と明細行を取得します。
+func getOrder(ctx context.Context, db Querier, id string) (Order, error) {
+	var order Order
+	err := db.QueryRowContext(ctx, "SELECT id, created_at FROM orders WHERE id = ?", id).Scan(&order.ID, &order.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Order{}, errOrderNotFound
+	}
+	if err != nil {
+		return Order{}, err
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT name, amount FROM order_lines WHERE order_id = ? ORDER BY line_no", id)
+	if err != nil {
+		return Order{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line OrderLine
+		if err := rows.Scan(&line.Name, &line.Amount); err != nil {
+			return Order{}, err
+		}
+		order.Lines = append(order.Lines, line)
+	}
+	return order, rows.Err()
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateOrderHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name         string
+		requestBody  string
+		mockSetup    func(mock sqlmock.Sqlmock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:        "全ての明細行を在庫名の順にロックして引き当てる",
+			requestBody: `{"lines":[{"name":"orange","amount":1},{"name":"apple","amount":2},{"name":"orange","amount":1}]}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockStock(mock, "apple", 10, 0)
+				expectLockStock(mock, "orange", 5, 1)
+				mock.ExpectExec("UPDATE stocks SET amount = amount - \\?, version = version \\+ 1 WHERE name = \\?").
+					WithArgs(2, "apple").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRecordMovement(mock, "apple", -2, 8, "order")
+				expectThresholds(mock, "apple", nil, nil)
+				mock.ExpectExec("UPDATE stocks SET amount = amount - \\?, version = version \\+ 1 WHERE name = \\?").
+					WithArgs(2, "orange").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRecordMovement(mock, "orange", -2, 3, "order")
+				expectThresholds(mock, "orange", nil, nil)
+				mock.ExpectExec("INSERT INTO orders").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO order_lines").
+					WithArgs(sqlmock.AnyArg(), 1, "apple", 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO order_lines").
+					WithArgs(sqlmock.AnyArg(), 2, "orange", 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusCreated,
+			expectedBody: `"lines":[{"name":"apple","amount":2},{"name":"orange","amount":2}]`,
+		},
+		{
+			name:        "1行でも在庫が不足していれば全てロールバックする",
+			requestBody: `{"lines":[{"name":"apple","amount":2},{"name":"orange","amount":5}]}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockStock(mock, "apple", 10, 0)
+				expectLockStock(mock, "orange", 5, 1)
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: `"shortages":[{"name":"orange","requested":5,"available":4}]`,
+		},
+		{
+			name:        "存在しない在庫が含まれる場合は404",
+			requestBody: `{"lines":[{"name":"grape","amount":1}]}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT amount, version FROM stocks WHERE name = \\? AND deleted_at IS NULL FOR UPDATE").
+					WithArgs("grape").
+					WillReturnRows(sqlmock.NewRows([]string{"amount", "version"}))
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "stock not found: grape",
+		},
+		{
+			name:         "明細行がない場合は400",
+			requestBody:  `{"lines":[]}`,
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "At least one line is required",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			router := gin.Default()
+			router.POST("/orders", createOrderHandler(&SQLDB{DB: db}))
+
+			req, _ := http.NewRequest(http.MethodPost, "/orders", bytes.NewBufferString(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			responseBody := w.Body.String()
+			t.Logf("テストケース: %s", tc.name)
+			t.Logf("レスポンスボディ: %s", responseBody)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Contains(t, responseBody, tc.expectedBody)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// outbox に書き込むドメインイベントの種類
+const (
+	eventTypeStockChanged   = "StockChanged"
+	eventTypeStockAllocated = "StockAllocated"
+)
+
+const (
+	cloudEventsSpecVersion = "1.0"
+	// cloudEventSource は CloudEvents の source 属性で、イベントを発行したサービスを表します。
+	cloudEventSource = "lambda-api-gw-go"
+
+	// outboxBatchSize は relayOutbox が 1 つのトランザクションで配信するイベントの件数です。
+	outboxBatchSize = 100
+)
+
+// CloudEvent は CloudEvents 1.0 の JSON 形式のイベントです。
+// id は在庫イベントの ID で、source と組み合わせて一意になります。受信側は id で重複を除いてください。
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// RelayResult は POST /outbox/relay のレスポンスです。
+type RelayResult struct {
+	Published int    `json:"published"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Publisher は outbox のイベントを配信する先です。
+// 配信先ごとにこのインターフェースを実装し、outboxPublisherFromEnv で選べるようにします。
+// ctx はリクエストの Context で、期限を過ぎたら配信を中断してください。
+type Publisher interface {
+	Publish(ctx context.Context, event CloudEvent) error
+}
+
+// Outbox は在庫の変更と同じトランザクションで書き込まれた、未配信のイベントを保持する outbox です。
+// 在庫の保存先ごとにこのインターフェースを実装します。
+type Outbox interface {
+	// RelayBatch は未配信のイベントを書き込んだ順に最大 outboxBatchSize 件 publisher に配信します。
+	// more は続きのイベントが残っている可能性がある場合に true です。
+	RelayBatch(ctx context.Context, publisher Publisher) (published int, more bool, err error)
+}
+
+// outboxFor は stocks と同じ保存先の Outbox を返します。
+// stocks が Outbox を実装していなければ、db の SQL データベースの outbox_events テーブルを使います。
+func outboxFor(db Storer, stocks StockRepository) Outbox {
+	if outbox, ok := stocks.(Outbox); ok {
+		return outbox
+	}
+	return sqlOutbox{db: db}
+}
+
+// sqlOutbox は SQL の outbox_events テーブルを使う Outbox です。
+type sqlOutbox struct {
+	db Storer
+}
+
+func (o sqlOutbox) RelayBatch(ctx context.Context, publisher Publisher) (int, bool, error) {
+	return relayOutboxBatch(ctx, o.db, publisher)
+}
+
+// memoryPublisher はイベントをメモリに保持する Publisher です。テストとローカル実行で使います。
+type memoryPublisher struct {
+	mu     sync.Mutex
+	events []CloudEvent
+}
+
+func newMemoryPublisher() *memoryPublisher {
+	return &memoryPublisher{}
+}
+
+func (p *memoryPublisher) Publish(_ context.Context, event CloudEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+// Events は配信されたイベントを配信順に返します。
+func (p *memoryPublisher) Events() []CloudEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]CloudEvent(nil), p.events...)
+}
+
+// filePublisher はイベントを 1 行 1 イベントの JSON Lines 形式でファイルに追記する Publisher です。
+type filePublisher struct {
+	mu   sync.Mutex
+	path string
+}
+
+func newFilePublisher(path string) *filePublisher {
+	return &filePublisher{path: path}
+}
+
+func (p *filePublisher) Publish(ctx context.Context, event CloudEvent) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	f, err := os.OpenFile(p.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// outboxPublisherFromEnv は OUTBOX_PUBLISHER 環境変数から Publisher を作成します。
+// eventbridge の場合は OUTBOX_EVENT_BUS のイベントバスに、file の場合は OUTBOX_FILE（既定値 outbox.jsonl）に配信します。
+// 未設定の場合は nil を返します。memory はテスト（TEST_MODE=true）でのみ使え、未知の値とともにエラーを返します。
+func outboxPublisherFromEnv(ctx context.Context) (Publisher, error) {
+	switch kind := os.Getenv("OUTBOX_PUBLISHER"); kind {
+	case "":
+		return nil, nil
+	case "eventbridge":
+		publisher, err := newEventBridgePublisherFromEnv(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure EventBridge: %w", err)
+		}
+		return publisher, nil
+	case "memory":
+		// メモリの Publisher は Lambda の実行環境が破棄されるとイベントが消え、配信済みとして記録したイベントを失う
+		if os.Getenv("TEST_MODE") != "true" {
+			return nil, errors.New("OUTBOX_PUBLISHER=memory is only available in tests")
+		}
+		return newMemoryPublisher(), nil
+	case "file":
+		return newFilePublisher(getEnv("OUTBOX_FILE", "outbox.jsonl")), nil
+	default:
+		return nil, fmt.Errorf("unknown OUTBOX_PUBLISHER %q", kind)
+	}
+}
+
+// outboxEventType は在庫移動の理由からドメインイベントの種類を決めます。
+// 引き当て（注文と予約の確定を含む）は StockAllocated、それ以外の在庫の変更は StockChanged です。
+func outboxEventType(reason string) string {
+	switch reason {
+	case movementAllocation, movementOrder, movementReservationCommit:
+		return eventTypeStockAllocated
+	}
+	return eventTypeStockChanged
+}
+
+// newCloudEvent は在庫イベントを CloudEvents 形式に変換します。
+func newCloudEvent(event StockEvent) (CloudEvent, error) {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return CloudEvent{}, err
+	}
+	return CloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              event.ID,
+		Source:          cloudEventSource,
+		Type:            outboxEventType(event.Data.Reason),
+		Subject:         event.Data.Name,
+		Time:            event.OccurredAt,
+		DataContentType: "application/json",
+		Data:            data,
+	}, nil
+}
+
+// writeOutbox は在庫イベントを CloudEvents 形式で outbox に書き込みます。
+// 在庫数を変更したのと同じトランザクション内で呼び出してください。
+func writeOutbox(ctx context.Context, tx Querier, event StockEvent) error {
+	cloudEvent, err := newCloudEvent(event)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(cloudEvent)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "INSERT INTO outbox_events (event_id, type, payload, created_at) VALUES (?, ?, ?, ?)",
+		cloudEvent.ID, cloudEvent.Type, string(payload), event.OccurredAt)
+	return err
+}
+
+// relayOutboxHandler は POST /outbox/relay のリクエストを処理します。
+// 未配信のイベントを書き込んだ順に配信し、配信した件数を返します。
+func relayOutboxHandler(outbox Outbox, publisher Publisher) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		if publisher == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "No outbox publisher is configured"})
+			return
+		}
+		published, err := relayOutbox(ctx, outbox, publisher)
+		if err != nil {
+			status := http.StatusBadGateway
+			if errors.Is(err, context.DeadlineExceeded) {
+				status = http.StatusGatewayTimeout
+			}
+			c.JSON(status, RelayResult{Published: published, Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, RelayResult{Published: published})
+	}
+}
+
+// relayOutbox は outbox が空になるまで、未配信のイベントを書き込んだ順に publisher に配信します。
+// 配信に失敗したイベントには試行回数とエラーを記録し、順序を保つためそれ以降のイベントは次の呼び出しで配信します。
+// 配信後、配信済みの記録をコミットする前に失敗すると同じイベントを再び配信します（at-least-once）。
+func relayOutbox(ctx context.Context, outbox Outbox, publisher Publisher) (int, error) {
+	published := 0
+	for {
+		n, more, err := outbox.RelayBatch(ctx, publisher)
+		published += n
+		if err != nil || !more {
+			return published, err
+		}
+	}
+}
+
+// relayOutboxBatch は未配信のイベントを最大 outboxBatchSize 件配信します。
+// more は続きのイベントが残っている可能性がある場合に true です。
+func relayOutboxBatch(ctx context.Context, db Storer, publisher Publisher) (published int, more bool, err error) {
+	var publishErr error
+	err = db.WithTx(ctx, func(tx Querier) error {
+		// 他の relay がロックしているイベントは飛ばし、同じイベントを同時に配信しない
+		rows, err := tx.QueryContext(ctx, "SELECT id, payload FROM outbox_events WHERE published_at IS NULL ORDER BY id LIMIT ? FOR UPDATE SKIP LOCKED", outboxBatchSize)
+		if err != nil {
+			return err
+		}
+		type pending struct {
+			id    int64
+			event CloudEvent
+		}
+		var batch []pending
+		for rows.Next() {
+			var (
+				p       pending
+				payload string
+			)
+			if err := rows.Scan(&p.id, &payload); err != nil {
+				rows.Close()
+				return err
+			}
+			if err := json.Unmarshal([]byte(payload), &p.event); err != nil {
+				rows.Close()
+				return fmt.Errorf("outbox event %d: %w", p.id, err)
+			}
+			batch = append(batch, p)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		more = len(batch) == outboxBatchSize
+
+		now := time.Now()
+		for _, p := range batch {
+			if publishErr = publisher.Publish(ctx, p.event); publishErr != nil {
+				more = false
+				_, err := tx.ExecContext(ctx, "UPDATE outbox_events SET attempts = attempts + 1, last_error = ? WHERE id = ?", publishErr.Error(), p.id)
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, "UPDATE outbox_events SET published_at = ?, attempts = attempts + 1 WHERE id = ?", now, p.id); err != nil {
+				return err
+			}
+			published++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	return published, more, publishErr
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+)
+
+// defaultOutboxEventBus は OUTBOX_EVENT_BUS を指定しない場合に使う EventBridge のイベントバスです。
+const defaultOutboxEventBus = "default"
+
+// eventBridgeAPI は eventBridgePublisher が使う EventBridge の操作です。テストではフェイクに置き換えます。
+type eventBridgeAPI interface {
+	PutEvents(ctx context.Context, params *eventbridge.PutEventsInput, optFns ...func(*eventbridge.Options)) (*eventbridge.PutEventsOutput, error)
+}
+
+// eventBridgePublisher は outbox のイベントを EventBridge のイベントバスに送信する Publisher です。
+// source と detail-type には CloudEvents の source と type を、detail には CloudEvent の JSON 全体を設定します。
+// ルールでは detail-type（StockChanged / StockAllocated）で購読するイベントを選べます。
+type eventBridgePublisher struct {
+	client eventBridgeAPI
+	bus    string
+}
+
+func newEventBridgePublisher(client eventBridgeAPI, bus string) *eventBridgePublisher {
+	return &eventBridgePublisher{client: client, bus: bus}
+}
+
+// newEventBridgePublisherFromEnv は AWS の既定の設定（環境変数や Lambda の実行ロール）で EventBridge に送信する Publisher を作成します。
+// イベントバスは OUTBOX_EVENT_BUS（既定値 default）で変更できます。
+func newEventBridgePublisherFromEnv(ctx context.Context) (*eventBridgePublisher, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newEventBridgePublisher(eventbridge.NewFromConfig(cfg), getEnv("OUTBOX_EVENT_BUS", defaultOutboxEventBus)), nil
+}
+
+func (p *eventBridgePublisher) Publish(ctx context.Context, event CloudEvent) error {
+	detail, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	out, err := p.client.PutEvents(ctx, &eventbridge.PutEventsInput{
+		Entries: []types.PutEventsRequestEntry{{
+			EventBusName: aws.String(p.bus),
+			Source:       aws.String(event.Source),
+			DetailType:   aws.String(event.Type),
+			Detail:       aws.String(string(detail)),
+			Time:         aws.Time(event.Time),
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	// PutEvents はエントリーごとの失敗をエラーではなく FailedEntryCount と各エントリーの ErrorCode で返す
+	if out.FailedEntryCount > 0 {
+		code, message := "unknown", ""
+		if len(out.Entries) > 0 {
+			code, message = aws.ToString(out.Entries[0].ErrorCode), aws.ToString(out.Entries[0].ErrorMessage)
+		}
+		return fmt.Errorf("eventbridge rejected event %s: %s %s", event.ID, code, message)
+	}
+	return nil
+}
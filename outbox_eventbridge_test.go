@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEventBridge は PutEvents の入力を記録し、out と err を返す eventBridgeAPI です。
+type fakeEventBridge struct {
+	inputs []*eventbridge.PutEventsInput
+	out    *eventbridge.PutEventsOutput
+	err    error
+}
+
+func (f *fakeEventBridge) PutEvents(_ context.Context, params *eventbridge.PutEventsInput, _ ...func(*eventbridge.Options)) (*eventbridge.PutEventsOutput, error) {
+	f.inputs = append(f.inputs, params)
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.out != nil {
+		return f.out, nil
+	}
+	return &eventbridge.PutEventsOutput{Entries: []types.PutEventsResultEntry{{EventId: aws.String("eb-1")}}}, nil
+}
+
+func TestEventBridgePublisher(t *testing.T) {
+	event := CloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              "e-1",
+		Source:          cloudEventSource,
+		Type:            eventTypeStockAllocated,
+		Subject:         "apple",
+		Time:            time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		DataContentType: "application/json",
+		Data:            json.RawMessage(`{"name":"apple"}`),
+	}
+
+	testCases := []struct {
+		name        string
+		client      *fakeEventBridge
+		expectedErr string
+	}{
+		{
+			name:   "イベントバスに送信する",
+			client: &fakeEventBridge{},
+		},
+		{
+			name: "エントリーが拒否された場合はエラー",
+			client: &fakeEventBridge{out: &eventbridge.PutEventsOutput{
+				FailedEntryCount: 1,
+				Entries:          []types.PutEventsResultEntry{{ErrorCode: aws.String("ThrottlingException"), ErrorMessage: aws.String("Rate exceeded")}},
+			}},
+			expectedErr: "eventbridge rejected event e-1: ThrottlingException Rate exceeded",
+		},
+		{
+			name:        "APIの呼び出しに失敗した場合はエラー",
+			client:      &fakeEventBridge{err: errors.New("connection refused")},
+			expectedErr: "connection refused",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := newEventBridgePublisher(tc.client, "stock-events").Publish(context.Background(), event)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			require.Len(t, tc.client.inputs, 1)
+			require.Len(t, tc.client.inputs[0].Entries, 1)
+			entry := tc.client.inputs[0].Entries[0]
+			assert.Equal(t, "stock-events", aws.ToString(entry.EventBusName))
+			assert.Equal(t, cloudEventSource, aws.ToString(entry.Source))
+			assert.Equal(t, eventTypeStockAllocated, aws.ToString(entry.DetailType))
+			assert.Equal(t, event.Time, aws.ToTime(entry.Time))
+			assert.JSONEq(t, `{"specversion":"1.0","id":"e-1","source":"lambda-api-gw-go","type":"StockAllocated","subject":"apple","time":"2026-01-02T03:04:05Z","datacontenttype":"application/json","data":{"name":"apple"}}`, aws.ToString(entry.Detail))
+		})
+	}
+}
+
+func TestOutboxPublisherFromEnv(t *testing.T) {
+	testCases := []struct {
+		name        string
+		env         map[string]string
+		expected    Publisher
+		expectedErr string
+	}{
+		{
+			name:     "未設定の場合はnil",
+			env:      map[string]string{"OUTBOX_PUBLISHER": ""},
+			expected: nil,
+		},
+		{
+			name:     "ファイル",
+			env:      map[string]string{"OUTBOX_PUBLISHER": "file", "OUTBOX_FILE": "events.jsonl"},
+			expected: newFilePublisher("events.jsonl"),
+		},
+		{
+			name:     "テストではメモリを使える",
+			env:      map[string]string{"OUTBOX_PUBLISHER": "memory", "TEST_MODE": "true"},
+			expected: newMemoryPublisher(),
+		},
+		{
+			name:        "テスト以外ではメモリを使えない",
+			env:         map[string]string{"OUTBOX_PUBLISHER": "memory", "TEST_MODE": ""},
+			expectedErr: "OUTBOX_PUBLISHER=memory is only available in tests",
+		},
+		{
+			name:        "未知の配信先はエラー",
+			env:         map[string]string{"OUTBOX_PUBLISHER": "sns"},
+			expectedErr: `unknown OUTBOX_PUBLISHER "sns"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+			publisher, err := outboxPublisherFromEnv(context.Background())
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				assert.Nil(t, publisher)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, publisher)
+		})
+	}
+
+	t.Run("EventBridge", func(t *testing.T) {
+		t.Setenv("OUTBOX_PUBLISHER", "eventbridge")
+		t.Setenv("OUTBOX_EVENT_BUS", "stock-events")
+		t.Setenv("AWS_REGION", "ap-northeast-1")
+		publisher, err := outboxPublisherFromEnv(context.Background())
+		require.NoError(t, err)
+		if assert.IsType(t, &eventBridgePublisher{}, publisher) {
+			assert.Equal(t, "stock-events", publisher.(*eventBridgePublisher).bus)
+		}
+	})
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cloudEventPayload は outbox に書き込む payload が CloudEvents 1.0 の JSON であることを検証する sqlmock.Argument です。
+type cloudEventPayload struct {
+	eventType string
+	data      StockEventData
+}
+
+func (p cloudEventPayload) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	if !ok {
+		return false
+	}
+	var event struct {
+		CloudEvent
+		Data StockEventData `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(s), &event); err != nil {
+		return false
+	}
+	return event.SpecVersion == "1.0" && event.Source == cloudEventSource && event.Type == p.eventType &&
+		event.Subject == p.data.Name && event.DataContentType == "application/json" && event.Data == p.data
+}
+
+func TestWriteOutbox(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		reason       string
+		expectedType string
+	}{
+		{name: "引き当てはStockAllocated", reason: movementAllocation, expectedType: eventTypeStockAllocated},
+		{name: "注文はStockAllocated", reason: movementOrder, expectedType: eventTypeStockAllocated},
+		{name: "予約の確定はStockAllocated", reason: movementReservationCommit, expectedType: eventTypeStockAllocated},
+		{name: "入庫はStockChanged", reason: movementReceipt, expectedType: eventTypeStockChanged},
+		{name: "調整はStockChanged", reason: "damage", expectedType: eventTypeStockChanged},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+
+			meta := MovementMeta{Reason: tc.reason, Actor: "pos-01", RequestID: "req-1"}
+			event := newStockEvent("apple", defaultLocation, -3, 7, meta, now)
+			mock.ExpectExec("INSERT INTO outbox_events \\(event_id, type, payload, created_at\\)").
+				WithArgs(event.ID, tc.expectedType, cloudEventPayload{eventType: tc.expectedType, data: event.Data}, now).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			assert.NoError(t, writeOutbox(context.Background(), db, event))
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
+
+// failingPublisher は failAt 番目（1 始まり）のイベントの配信に失敗する Publisher です。
+type failingPublisher struct {
+	memoryPublisher
+	failAt int
+	calls  int
+}
+
+func (p *failingPublisher) Publish(ctx context.Context, event CloudEvent) error {
+	p.calls++
+	if p.calls == p.failAt {
+		return errors.New("event bus is unavailable")
+	}
+	return p.memoryPublisher.Publish(ctx, event)
+}
+
+func outboxRow(id int64, eventID, eventType string) (int64, string) {
+	payload := `{"specversion":"1.0","id":"` + eventID + `","source":"lambda-api-gw-go","type":"` + eventType +
+		`","subject":"apple","time":"2026-01-02T03:04:05Z","datacontenttype":"application/json","data":{"name":"apple"}}`
+	return id, payload
+}
+
+func TestRelayOutbox(t *testing.T) {
+	t.Run("未配信のイベントを書き込んだ順に配信する", func(t *testing.T) {
+		db, mock := NewMockDB(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		rows := sqlmock.NewRows([]string{"id", "payload"})
+		rows.AddRow(outboxRow(1, "e-1", eventTypeStockChanged))
+		rows.AddRow(outboxRow(2, "e-2", eventTypeStockAllocated))
+		mock.ExpectQuery("SELECT id, payload FROM outbox_events WHERE published_at IS NULL ORDER BY id LIMIT \\? FOR UPDATE SKIP LOCKED").
+			WithArgs(outboxBatchSize).
+			WillReturnRows(rows)
+		for _, id := range []int64{1, 2} {
+			mock.ExpectExec("UPDATE outbox_events SET published_at = \\?, attempts = attempts \\+ 1 WHERE id = \\?").
+				WithArgs(sqlmock.AnyArg(), id).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		mock.ExpectCommit()
+
+		publisher := newMemoryPublisher()
+		published, err := relayOutbox(context.Background(), sqlOutbox{db: &SQLDB{DB: db}}, publisher)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, published)
+
+		events := publisher.Events()
+		if assert.Len(t, events, 2) {
+			assert.Equal(t, "e-1", events[0].ID)
+			assert.Equal(t, eventTypeStockChanged, events[0].Type)
+			assert.Equal(t, "e-2", events[1].ID)
+			assert.Equal(t, eventTypeStockAllocated, events[1].Type)
+			assert.JSONEq(t, `{"name":"apple"}`, string(events[1].Data))
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("未処理の期待値があります: %s", err)
+		}
+	})
+
+	t.Run("配信に失敗したイベント以降は次の呼び出しで配信する", func(t *testing.T) {
+		db, mock := NewMockDB(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		rows := sqlmock.NewRows([]string{"id", "payload"})
+		rows.AddRow(outboxRow(1, "e-1", eventTypeStockChanged))
+		rows.AddRow(outboxRow(2, "e-2", eventTypeStockChanged))
+		rows.AddRow(outboxRow(3, "e-3", eventTypeStockChanged))
+		mock.ExpectQuery("SELECT id, payload FROM outbox_events").
+			WillReturnRows(rows)
+		mock.ExpectExec("UPDATE outbox_events SET published_at = \\?").
+			WithArgs(sqlmock.AnyArg(), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE outbox_events SET attempts = attempts \\+ 1, last_error = \\? WHERE id = \\?").
+			WithArgs("event bus is unavailable", int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		publisher := &failingPublisher{failAt: 2}
+		published, err := relayOutbox(context.Background(), sqlOutbox{db: &SQLDB{DB: db}}, publisher)
+		assert.EqualError(t, err, "event bus is unavailable")
+		assert.Equal(t, 1, published)
+		assert.Len(t, publisher.Events(), 1)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("未処理の期待値があります: %s", err)
+		}
+	})
+}
+
+func TestRelayOutboxHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("ファイルにJSON Linesで配信する", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "outbox.jsonl")
+		t.Setenv("OUTBOX_PUBLISHER", "file")
+		t.Setenv("OUTBOX_FILE", path)
+
+		db, mock := NewMockDB(t)
+		defer db.Close()
+		mock.ExpectBegin()
+		rows := sqlmock.NewRows([]string{"id", "payload"})
+		rows.AddRow(outboxRow(1, "e-1", eventTypeStockAllocated))
+		mock.ExpectQuery("SELECT id, payload FROM outbox_events").
+			WillReturnRows(rows)
+		mock.ExpectExec("UPDATE outbox_events SET published_at = \\?").
+			WithArgs(sqlmock.AnyArg(), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		router := gin.New()
+		require.NoError(t, setupRoutes(router, &SQLDB{DB: db}))
+		req, _ := http.NewRequest(http.MethodPost, "/v1/outbox/relay", bytes.NewBufferString(""))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"published":1}`, w.Body.String())
+
+		content, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+		if assert.Len(t, lines, 1) {
+			assert.JSONEq(t, `{"specversion":"1.0","id":"e-1","source":"lambda-api-gw-go","type":"StockAllocated","subject":"apple","time":"2026-01-02T03:04:05Z","datacontenttype":"application/json","data":{"name":"apple"}}`, lines[0])
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("未処理の期待値があります: %s", err)
+		}
+	})
+
+	t.Run("配信先が設定されていない場合は503", func(t *testing.T) {
+		t.Setenv("OUTBOX_PUBLISHER", "")
+
+		db, mock := NewMockDB(t)
+		defer db.Close()
+
+		router := gin.New()
+		require.NoError(t, setupRoutes(router, &SQLDB{DB: db}))
+		req, _ := http.NewRequest(http.MethodPost, "/v1/outbox/relay", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Contains(t, w.Body.String(), "No outbox publisher is configured")
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("未処理の期待値があります: %s", err)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// parseLimit はクエリパラメータ limit を読み込みます。
+// 指定がない場合は defaultLimit を返し、1 から maxLimit の範囲外であればエラーを返します。
+func parseLimit(c *gin.Context, defaultLimit, maxLimit int) (int, error) {
+	v := c.Query("limit")
+	if v == "" {
+		return defaultLimit, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 || n > maxLimit {
+		return 0, fmt.Errorf("limit must be between 1 and %d", maxLimit)
+	}
+	return n, nil
+}
+
+// encodeCursor はページングのキーをクライアントに渡す不透明なカーソルに変換します。
+func encodeCursor(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+// decodeCursor は encodeCursor で作成したカーソルからページングのキーを取り出します。
+func decodeCursor(cursor string) (string, error) {
+	key, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(key) == 0 {
+		return "", errInvalidCursor
+	}
+	return string(key), nil
+}
@@ -0,0 +1,451 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultProductUnit = "pcs"
+
+	defaultProductPageLimit = 100
+	maxProductPageLimit     = 500
+)
+
+var (
+	errProductNotFound = errors.New("product not found")
+	errProductExists   = errors.New("product with the same SKU or barcode already exists")
+	errInvalidProduct  = errors.New("invalid product")
+)
+
+// productColumns は products テーブルから読み込む列で、scanProduct の引数の順序に対応します。
+const productColumns = "p.id, p.sku, p.display_name, p.barcode, p.unit, p.category, p.attributes, p.created_at, p.updated_at"
+
+// Product は商品マスタの 1 件です。
+// 在庫（stocks の行）は商品を ID で参照するため、商品名や SKU を変更しても在庫数や在庫移動の履歴は変わりません。
+type Product struct {
+	ID          int64                  `json:"id"`
+	SKU         string                 `json:"sku"`
+	DisplayName string                 `json:"display_name"`
+	Barcode     string                 `json:"barcode,omitempty"`
+	Unit        string                 `json:"unit"`
+	Category    string                 `json:"category,omitempty"`
+	Attributes  map[string]interface{} `json:"attributes,omitempty"`
+	CreatedAt   *time.Time             `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time             `json:"updated_at,omitempty"`
+}
+
+// ProductUpdate は PATCH /products/:id のリクエストボディです。指定したフィールドのみを変更します。
+type ProductUpdate struct {
+	SKU         *string                 `json:"sku"`
+	DisplayName *string                 `json:"display_name"`
+	Barcode     *string                 `json:"barcode"`
+	Unit        *string                 `json:"unit"`
+	Category    *string                 `json:"category"`
+	Attributes  *map[string]interface{} `json:"attributes"`
+}
+
+// ProductPage は GET /products のレスポンスです。
+type ProductPage struct {
+	Products   []Product `json:"products"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+}
+
+// StockProductRequest は PUT /stocks/:name/product のリクエストボディです。
+type StockProductRequest struct {
+	ProductID int64 `json:"product_id"`
+}
+
+// createProductHandler は POST /products のリクエストを処理します。
+func createProductHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var product Product
+		if err := c.ShouldBindJSON(&product); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+		if err := validateProduct(&product); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		product, err := createProduct(ctx, db, product)
+		switch {
+		case errors.Is(err, errProductExists):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			writeServerError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, product)
+	}
+}
+
+// getProductsHandler は GET /products のリクエストを処理します。
+// category を指定した場合はそのカテゴリの商品のみを、ID 順に返します。
+func getProductsHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		limit, err := parseLimit(c, defaultProductPageLimit, maxProductPageLimit)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var afterID int64
+		if v := c.Query("cursor"); v != "" {
+			key, err := decodeCursor(v)
+			if err == nil {
+				afterID, err = strconv.ParseInt(key, 10, 64)
+			}
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": errInvalidCursor.Error()})
+				return
+			}
+		}
+
+		products, err := getProducts(ctx, db, c.Query("category"), afterID, limit+1)
+		if err != nil {
+			writeServerError(c, err)
+			return
+		}
+
+		page := ProductPage{Products: products}
+		if len(products) > limit {
+			page.Products = products[:limit]
+			page.NextCursor = encodeCursor(strconv.FormatInt(products[limit-1].ID, 10))
+		}
+		if page.Products == nil {
+			page.Products = []Product{}
+		}
+		c.JSON(http.StatusOK, page)
+	}
+}
+
+// getProductHandler は GET /products/:id のリクエストを処理します。
+func getProductHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		id, ok := productIDParam(c)
+		if !ok {
+			return
+		}
+		product, err := getProduct(ctx, db, id)
+		switch {
+		case errors.Is(err, errProductNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			writeServerError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, product)
+	}
+}
+
+// updateProductHandler は PATCH /products/:id のリクエストを処理します。
+// 商品名や SKU を変更しても、商品に紐付く在庫の在庫数や履歴は変わりません。
+func updateProductHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		id, ok := productIDParam(c)
+		if !ok {
+			return
+		}
+		var update ProductUpdate
+		if err := c.ShouldBindJSON(&update); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+
+		product, err := updateProduct(ctx, db, id, update)
+		switch {
+		case errors.Is(err, errProductNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, errProductExists):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, errInvalidProduct):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			writeServerError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, product)
+	}
+}
+
+// putStockProductHandler は PUT /stocks/:name/product のリクエストを処理します。
+// 在庫を商品に紐付け、商品を埋め込んだ在庫を返します。
+func putStockProductHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		name := c.Param("name")
+
+		var req StockProductRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+		if req.ProductID <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Product ID is required"})
+			return
+		}
+
+		err := linkStockProduct(ctx, db, name, sql.NullInt64{Int64: req.ProductID, Valid: true})
+		switch {
+		case errors.Is(err, errStockNotFound), errors.Is(err, errProductNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			writeServerError(c, err)
+			return
+		}
+
+		stock, err := getStock(ctx, db, name)
+		if err == nil {
+			stock.Product, err = getStockProduct(ctx, db, name)
+		}
+		if err != nil {
+			writeServerError(c, err)
+			return
+		}
+		c.Header("ETag", stockETag(stock.Version))
+		c.JSON(http.StatusOK, stock)
+	}
+}
+
+// deleteStockProductHandler は DELETE /stocks/:name/product のリクエストを処理し、在庫と商品の紐付けを解除します。
+func deleteStockProductHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		err := linkStockProduct(ctx, db, c.Param("name"), sql.NullInt64{})
+		switch {
+		case errors.Is(err, errStockNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			writeServerError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// productIDParam はパスパラメータ id を読み込みます。不正な場合は 400 を返して false を返します。
+func productIDParam(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+// validateProduct は商品の必須項目とバーコードを検証し、単位が指定されていない場合は既定値を設定します。
+func validateProduct(product *Product) error {
+	product.SKU = strings.TrimSpace(product.SKU)
+	product.DisplayName = strings.TrimSpace(product.DisplayName)
+	if product.SKU == "" {
+		return errors.New("SKU is required")
+	}
+	if product.DisplayName == "" {
+		return errors.New("Display name is required")
+	}
+	if product.Unit == "" {
+		product.Unit = defaultProductUnit
+	}
+	if product.Barcode != "" {
+		barcode, err := normalizeBarcode(product.Barcode)
+		if err != nil {
+			return err
+		}
+		product.Barcode = barcode
+	}
+	return nil
+}
+
+// createProduct は商品を登録し、採番された ID を設定して返します。
+func createProduct(ctx context.Context, db Storer, product Product) (Product, error) {
+	attributes, err := encodeAttributes(product.Attributes)
+	if err != nil {
+		return Product{}, err
+	}
+	now := time.Now()
+	product.ID, err = insertReturningID(ctx, db, "INSERT INTO products (sku, display_name, barcode, unit, category, attributes, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		product.SKU, product.DisplayName, nullString(product.Barcode), product.Unit, product.Category, attributes, now, now)
+	if isDuplicateKey(err) {
+		return Product{}, errProductExists
+	}
+	if err != nil {
+		return Product{}, err
+	}
+	product.CreatedAt, product.UpdatedAt = &now, &now
+	return product, nil
+}
+
+// getProduct は ID で商品を取得します。
+func getProduct(ctx context.Context, db Querier, id int64) (Product, error) {
+	var product Product
+	err := scanProduct(db.QueryRowContext(ctx, "SELECT "+productColumns+" FROM products p WHERE p.id = ?", id), &product)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Product{}, errProductNotFound
+	}
+	return product, err
+}
+
+// getProducts は商品を ID 順に取得します。afterID より大きい ID の商品のみを返します。
+func getProducts(ctx context.Context, db Querier, category string, afterID int64, limit int) ([]Product, error) {
+	query := "SELECT " + productColumns + " FROM products p WHERE p.id > ?"
+	args := []interface{}{afterID}
+	if category != "" {
+		query += " AND p.category = ?"
+		args = append(args, category)
+	}
+	query += " ORDER BY p.id LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var products []Product
+	for rows.Next() {
+		var product Product
+		if err := scanProduct(rows, &product); err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+	return products, rows.Err()
+}
+
+// getStockProduct は在庫に紐付く商品を取得します。紐付いていない場合は nil を返します。
+func getStockProduct(ctx context.Context, db Querier, name string) (*Product, error) {
+	var product Product
+	err := scanProduct(db.QueryRowContext(ctx, "SELECT "+productColumns+" FROM stocks s JOIN products p ON p.id = s.product_id WHERE s.name = ?", name), &product)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// updateProduct は指定されたフィールドのみを変更し、変更後の商品を返します。
+func updateProduct(ctx context.Context, db Storer, id int64, update ProductUpdate) (Product, error) {
+	var product Product
+	err := db.WithTx(ctx, func(tx Querier) error {
+		err := scanProduct(tx.QueryRowContext(ctx, "SELECT "+productColumns+" FROM products p WHERE p.id = ? FOR UPDATE", id), &product)
+		if errors.Is(err, sql.ErrNoRows) {
+			return errProductNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if update.SKU != nil {
+			product.SKU = *update.SKU
+		}
+		if update.DisplayName != nil {
+			product.DisplayName = *update.DisplayName
+		}
+		if update.Barcode != nil {
+			product.Barcode = *update.Barcode
+		}
+		if update.Unit != nil {
+			product.Unit = *update.Unit
+		}
+		if update.Category != nil {
+			product.Category = *update.Category
+		}
+		if update.Attributes != nil {
+			product.Attributes = *update.Attributes
+		}
+		if err := validateProduct(&product); err != nil {
+			return fmt.Errorf("%w: %w", errInvalidProduct, err)
+		}
+
+		attributes, err := encodeAttributes(product.Attributes)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		_, err = tx.ExecContext(ctx, "UPDATE products SET sku = ?, display_name = ?, barcode = ?, unit = ?, category = ?, attributes = ?, updated_at = ? WHERE id = ?",
+			product.SKU, product.DisplayName, nullString(product.Barcode), product.Unit, product.Category, attributes, now, id)
+		if isDuplicateKey(err) {
+			return errProductExists
+		}
+		product.UpdatedAt = &now
+		return err
+	})
+	return product, err
+}
+
+// linkStockProduct は在庫を商品に紐付けます。productID が NULL の場合は紐付けを解除します。
+func linkStockProduct(ctx context.Context, db Storer, name string, productID sql.NullInt64) error {
+	return db.WithTx(ctx, func(tx Querier) error {
+		if _, err := lockStock(ctx, tx, name, time.Now()); err != nil {
+			return err
+		}
+		if productID.Valid {
+			if _, err := getProduct(ctx, tx, productID.Int64); err != nil {
+				return err
+			}
+		}
+		_, err := tx.ExecContext(ctx, "UPDATE stocks SET product_id = ? WHERE name = ?", productID, name)
+		return err
+	})
+}
+
+// scanProduct は productColumns の順に読み込んだ行を Product に変換します。
+func scanProduct(row rowScanner, product *Product) error {
+	var (
+		barcode, attributes  sql.NullString
+		createdAt, updatedAt time.Time
+	)
+	if err := row.Scan(&product.ID, &product.SKU, &product.DisplayName, &barcode, &product.Unit, &product.Category, &attributes, &createdAt, &updatedAt); err != nil {
+		return err
+	}
+	product.Barcode = barcode.String
+	if attributes.Valid && attributes.String != "" {
+		if err := json.Unmarshal([]byte(attributes.String), &product.Attributes); err != nil {
+			return err
+		}
+	}
+	product.CreatedAt, product.UpdatedAt = &createdAt, &updatedAt
+	return nil
+}
+
+// encodeAttributes は商品の属性を JSON 列に保存する値に変換します。属性がない場合は NULL です。
+func encodeAttributes(attributes map[string]interface{}) (sql.NullString, error) {
+	if len(attributes) == 0 {
+		return sql.NullString{}, nil
+	}
+	encoded, err := json.Marshal(attributes)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(encoded), Valid: true}, nil
+}
+
+// nullString は空文字列を NULL として保存するための値に変換します。
+// バーコードは一意キーのため、未設定の商品が複数あっても重複しないよう NULL で保存します。
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
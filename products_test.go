@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var productColumnNames = []string{"id", "sku", "display_name", "barcode", "unit", "category", "attributes", "created_at", "updated_at"}
+
+func TestProductHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	stockColumns := []string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}
+
+	testCases := []struct {
+		name         string
+		method       string
+		path         string
+		requestBody  string
+		mockSetup    func(mock sqlmock.Sqlmock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:        "商品を登録する",
+			method:      http.MethodPost,
+			path:        "/v1/products",
+			requestBody: `{"sku":"APL-001","display_name":"ふじりんご","barcode":"4901234567894","category":"fruit","attributes":{"origin":"青森"}}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO products").
+					WithArgs("APL-001", "ふじりんご", "4901234567894", "pcs", "fruit", `{"origin":"青森"}`, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+			},
+			expectedCode: http.StatusCreated,
+			expectedBody: `{"id":3,"sku":"APL-001","display_name":"ふじりんご","barcode":"4901234567894","unit":"pcs","category":"fruit","attributes":{"origin":"青森"}`,
+		},
+		{
+			name:        "SKUが重複する場合は409",
+			method:      http.MethodPost,
+			path:        "/v1/products",
+			requestBody: `{"sku":"APL-001","display_name":"りんご"}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO products").
+					WillReturnError(&mysql.MySQLError{Number: 1062})
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: "product with the same SKU or barcode already exists",
+		},
+		{
+			name:         "不正なバーコードは400",
+			method:       http.MethodPost,
+			path:         "/v1/products",
+			requestBody:  `{"sku":"APL-001","display_name":"りんご","barcode":"49-0123"}`,
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "barcode must be",
+		},
+		{
+			name:   "存在しない商品は404",
+			method: http.MethodGet,
+			path:   "/v1/products/9",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM products p WHERE p.id = \\?").
+					WithArgs(int64(9)).
+					WillReturnRows(sqlmock.NewRows(productColumnNames))
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "product not found",
+		},
+		{
+			name:   "カテゴリで絞り込んでID順に返す",
+			method: http.MethodGet,
+			path:   "/v1/products?category=fruit&limit=1",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM products p WHERE p.id > \\? AND p.category = \\? ORDER BY p.id LIMIT \\?").
+					WithArgs(int64(0), "fruit", 2).
+					WillReturnRows(sqlmock.NewRows(productColumnNames).
+						AddRow(3, "APL-001", "りんご", nil, "pcs", "fruit", nil, now, now).
+						AddRow(4, "BAN-001", "バナナ", nil, "pcs", "fruit", nil, now, now))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"next_cursor":"` + encodeCursor("3") + `"`,
+		},
+		{
+			name:        "商品名を変更しても在庫は変更しない",
+			method:      http.MethodPatch,
+			path:        "/v1/products/3",
+			requestBody: `{"display_name":"サンふじ"}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM products p WHERE p.id = \\? FOR UPDATE").
+					WithArgs(int64(3)).
+					WillReturnRows(sqlmock.NewRows(productColumnNames).
+						AddRow(3, "APL-001", "ふじりんご", "4901234567894", "pcs", "fruit", `{"origin":"青森"}`, now, now))
+				mock.ExpectExec("UPDATE products SET (.+) WHERE id = \\?").
+					WithArgs("APL-001", "サンふじ", "4901234567894", "pcs", "fruit", `{"origin":"青森"}`, sqlmock.AnyArg(), int64(3)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"sku":"APL-001","display_name":"サンふじ","barcode":"4901234567894"`,
+		},
+		{
+			name:        "SKUを空にする変更は400",
+			method:      http.MethodPatch,
+			path:        "/v1/products/3",
+			requestBody: `{"sku":""}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM products p WHERE p.id = \\? FOR UPDATE").
+					WithArgs(int64(3)).
+					WillReturnRows(sqlmock.NewRows(productColumnNames).
+						AddRow(3, "APL-001", "りんご", nil, "pcs", "", nil, now, now))
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid product: SKU is required",
+		},
+		{
+			name:        "在庫を商品に紐付ける",
+			method:      http.MethodPut,
+			path:        "/v1/stocks/apple/product",
+			requestBody: `{"product_id":3}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockStock(mock, "apple", 10, 0)
+				mock.ExpectQuery("SELECT (.+) FROM products p WHERE p.id = \\?").
+					WithArgs(int64(3)).
+					WillReturnRows(sqlmock.NewRows(productColumnNames).
+						AddRow(3, "APL-001", "りんご", nil, "pcs", "", nil, now, now))
+				mock.ExpectExec("UPDATE stocks SET product_id = \\? WHERE name = \\?").
+					WithArgs(int64(3), "apple").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s").
+					WithArgs(sqlmock.AnyArg(), "apple").
+					WillReturnRows(sqlmock.NewRows(stockColumns).AddRow("apple", 10, 0, 1, nil, nil))
+				mock.ExpectQuery("SELECT (.+) FROM stocks s JOIN products p ON p.id = s.product_id WHERE s.name = \\?").
+					WithArgs("apple").
+					WillReturnRows(sqlmock.NewRows(productColumnNames).
+						AddRow(3, "APL-001", "りんご", nil, "pcs", "", nil, now, now))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"name":"apple","amount":10,"reserved":0,"available":10,"product":{"id":3,"sku":"APL-001","display_name":"りんご","unit":"pcs"`,
+		},
+		{
+			name:        "存在しない商品には紐付けられない",
+			method:      http.MethodPut,
+			path:        "/v1/stocks/apple/product",
+			requestBody: `{"product_id":9}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockStock(mock, "apple", 10, 0)
+				mock.ExpectQuery("SELECT (.+) FROM products p WHERE p.id = \\?").
+					WithArgs(int64(9)).
+					WillReturnRows(sqlmock.NewRows(productColumnNames))
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "product not found",
+		},
+		{
+			name:   "紐付けを解除する",
+			method: http.MethodDelete,
+			path:   "/v1/stocks/apple/product",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockStock(mock, "apple", 10, 0)
+				mock.ExpectExec("UPDATE stocks SET product_id = \\? WHERE name = \\?").
+					WithArgs(nil, "apple").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:   "GET /stocks/:nameは紐付いた商品を埋め込む",
+			method: http.MethodGet,
+			path:   "/v1/stocks/apple",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s").
+					WithArgs(sqlmock.AnyArg(), "apple").
+					WillReturnRows(sqlmock.NewRows(stockColumns).AddRow("apple", 10, 0, 1, nil, nil))
+				mock.ExpectQuery("SELECT (.+) FROM stocks s JOIN products p ON p.id = s.product_id WHERE s.name = \\?").
+					WithArgs("apple").
+					WillReturnRows(sqlmock.NewRows(productColumnNames).
+						AddRow(3, "APL-001", "サンふじ", "4901234567894", "pcs", "fruit", nil, now, now))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"product":{"id":3,"sku":"APL-001","display_name":"サンふじ","barcode":"4901234567894","unit":"pcs","category":"fruit"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			router := gin.New()
+			require.NoError(t, setupRoutes(router, &SQLDB{DB: db}))
+
+			req, _ := http.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			responseBody := w.Body.String()
+			t.Logf("テストケース: %s", tc.name)
+			t.Logf("レスポンスボディ: %s", responseBody)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Contains(t, responseBody, tc.expectedBody)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// 引当予約のステータス
+const (
+	reservationActive    = "active"
+	reservationCommitted = "committed"
+	reservationReleased  = "released"
+	reservationExpired   = "expired"
+)
+
+const (
+	defaultReservationTTL = 15 * time.Minute
+	maxReservationTTL     = 24 * time.Hour
+)
+
+// reservedSubquery は stocks.name に対する有効期限内の引当予約数を求めるサブクエリです。
+// プレースホルダには現在時刻を渡します。
+const reservedSubquery = "SELECT COALESCE(SUM(r.amount), 0) FROM stock_reservations r " +
+	"WHERE r.name = stocks.name AND r.status = 'active' AND r.expires_at > ?"
+
+const reservationSelectQuery = "SELECT id, name, amount, status, expires_at, created_at FROM stock_reservations"
+
+var (
+	errReservationNotFound  = errors.New("reservation not found")
+	errReservationNotActive = errors.New("reservation is not active")
+)
+
+// ReservationRequest は引当予約の作成リクエストのボディです。
+type ReservationRequest struct {
+	Amount     int `json:"amount"`
+	TTLSeconds int `json:"ttl_seconds"`
+}
+
+// Reservation は在庫の引当予約です。
+// 有効な予約は在庫数（amount）を変えずに引当可能数（available）だけを減らします。
+type Reservation struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Amount    int       `json:"amount"`
+	Status    string    `json:"status"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// normalizeStatus は有効期限切れで未掃除の予約のステータスを expired に置き換えます。
+func (r *Reservation) normalizeStatus(now time.Time) {
+	if r.Status == reservationActive && !r.ExpiresAt.After(now) {
+		r.Status = reservationExpired
+	}
+}
+
+// createReservationHandler は POST /stocks/:name/reservations のリクエストを処理します。
+func createReservationHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		name := c.Param("name")
+
+		var req ReservationRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+
+		if req.Amount <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must be greater than 0"})
+			return
+		}
+
+		ttl := defaultReservationTTL
+		if req.TTLSeconds != 0 {
+			ttl = time.Duration(req.TTLSeconds) * time.Second
+		}
+		if ttl <= 0 || ttl > maxReservationTTL {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ttl_seconds must be between 1 and 86400"})
+			return
+		}
+
+		reservation, stock, err := createReservation(ctx, db, name, req.Amount, ttl)
+		switch {
+		case errors.Is(err, errStockNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, errInsufficientStock):
+			c.JSON(http.StatusConflict, gin.H{
+				"error":     err.Error(),
+				"name":      name,
+				"requested": req.Amount,
+				"available": stock.Available,
+			})
+			return
+		case err != nil:
+			writeServerError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, reservation)
+	}
+}
+
+// getReservationHandler は GET /reservations/:id のリクエストを処理します。
+func getReservationHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		reservation, err := getReservation(ctx, db, c.Param("id"))
+		if errors.Is(err, errReservationNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			writeServerError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, reservation)
+	}
+}
+
+// commitReservationHandler は POST /reservations/:id/commit のリクエストを処理します。
+func commitReservationHandler(db Storer) gin.HandlerFunc {
+	return reservationTransitionHandler(func(c *gin.Context, id string) (Reservation, error) {
+		return commitReservation(c.Request.Context(), db, id, movementMetaFromContext(c, movementReservationCommit))
+	})
+}
+
+// releaseReservationHandler は POST /reservations/:id/release のリクエストを処理します。
+func releaseReservationHandler(db Storer) gin.HandlerFunc {
+	return reservationTransitionHandler(func(c *gin.Context, id string) (Reservation, error) {
+		return releaseReservation(c.Request.Context(), db, id)
+	})
+}
+
+// reservationTransitionHandler は予約のステータスを変更するハンドラーの共通処理です。
+func reservationTransitionHandler(transition func(c *gin.Context, id string) (Reservation, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reservation, err := transition(c, c.Param("id"))
+		switch {
+		case errors.Is(err, errReservationNotFound), errors.Is(err, errStockNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, errReservationNotActive):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "status": reservation.Status})
+			return
+		case errors.Is(err, errInsufficientStock):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			writeServerError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, reservation)
+	}
+}
+
+// createReservation は在庫の引当予約を作成します。
+// 在庫行をロックしてから引当可能数を確認するため、同じ在庫への予約・引き当ては直列化されます。
+func createReservation(ctx context.Context, db Storer, name string, amount int, ttl time.Duration) (Reservation, Stock, error) {
+	now := time.Now()
+
+	// 期限切れの予約を掃除する（引当可能数の計算では期限切れの予約は常に除外される）
+	if _, err := sweepExpiredReservations(ctx, db, now); err != nil {
+		return Reservation{}, Stock{}, err
+	}
+
+	reservation := Reservation{
+		ID:        uuid.NewString(),
+		Name:      name,
+		Amount:    amount,
+		Status:    reservationActive,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+
+	var stock Stock
+	err := db.WithTx(ctx, func(tx Querier) error {
+		var err error
+		stock, err = lockStock(ctx, tx, name, now)
+		if err != nil {
+			return err
+		}
+		if stock.Available < amount {
+			return errInsufficientStock
+		}
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO stock_reservations (id, name, amount, status, expires_at, created_at) VALUES (?, ?, ?, ?, ?, ?)",
+			reservation.ID, reservation.Name, reservation.Amount, reservation.Status, reservation.ExpiresAt, reservation.CreatedAt)
+		return err
+	})
+	if err != nil {
+		return Reservation{}, stock, err
+	}
+	return reservation, stock, nil
+}
+
+// getReservation は ID を指定して予約を取得します。
+func getReservation(ctx context.Context, db Querier, id string) (Reservation, error) {
+	var r Reservation
+	err := db.QueryRowContext(ctx, reservationSelectQuery+" WHERE id = ?", id).
+		Scan(&r.ID, &r.Name, &r.Amount, &r.Status, &r.ExpiresAt, &r.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Reservation{}, errReservationNotFound
+	}
+	if err != nil {
+		return Reservation{}, err
+	}
+	r.normalizeStatus(time.Now())
+	return r, nil
+}
+
+// commitReservation は予約を確定し、予約数量を在庫数から差し引きます。
+func commitReservation(ctx context.Context, db Storer, id string, meta MovementMeta) (Reservation, error) {
+	reservation, err := getReservation(ctx, db, id)
+	if err != nil {
+		return Reservation{}, err
+	}
+	if reservation.Status != reservationActive {
+		return reservation, errReservationNotActive
+	}
+
+	now := time.Now()
+	err = db.WithTx(ctx, func(tx Querier) error {
+		// 引き当てと同じ順序（在庫行 → 予約）でロックしてデッドロックを防ぐ
+		var amount int
+		err := tx.QueryRowContext(ctx, "SELECT amount FROM stocks WHERE name = ? AND deleted_at IS NULL FOR UPDATE", reservation.Name).Scan(&amount)
+		if errors.Is(err, sql.ErrNoRows) {
+			// 予約した後に在庫が削除された
+			return fmt.Errorf("%w: %s", errStockNotFound, reservation.Name)
+		}
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, "UPDATE stock_reservations SET status = ? WHERE id = ? AND status = 'active' AND expires_at > ?",
+			reservationCommitted, id, now)
+		if err != nil {
+			return err
+		}
+		if affected, err := result.RowsAffected(); err != nil {
+			return err
+		} else if affected == 0 {
+			return errReservationNotActive
+		}
+
+		result, err = tx.ExecContext(ctx, "UPDATE stocks SET amount = amount - ?, version = version + 1 WHERE name = ? AND amount >= ?",
+			reservation.Amount, reservation.Name, reservation.Amount)
+		if err != nil {
+			return err
+		}
+		if affected, err := result.RowsAffected(); err != nil {
+			return err
+		} else if affected == 0 {
+			return errInsufficientStock
+		}
+		if err := recordMovement(ctx, tx, reservation.Name, -reservation.Amount, amount-reservation.Amount, meta, now); err != nil {
+			return err
+		}
+		return checkLowStock(ctx, tx, reservation.Name, amount, amount-reservation.Amount, now)
+	})
+	if errors.Is(err, errReservationNotActive) {
+		// 他のリクエストが先に状態を変更したため、最新のステータスを返す
+		if latest, getErr := getReservation(ctx, db, id); getErr == nil {
+			reservation = latest
+		}
+		return reservation, err
+	}
+	if err != nil {
+		return Reservation{}, err
+	}
+
+	reservation.Status = reservationCommitted
+	return reservation, nil
+}
+
+// releaseReservation は予約を解放し、引当可能数を元に戻します。
+func releaseReservation(ctx context.Context, db Storer, id string) (Reservation, error) {
+	reservation, err := getReservation(ctx, db, id)
+	if err != nil {
+		return Reservation{}, err
+	}
+	if reservation.Status != reservationActive {
+		return reservation, errReservationNotActive
+	}
+
+	result, err := db.ExecContext(ctx, "UPDATE stock_reservations SET status = ? WHERE id = ? AND status = 'active' AND expires_at > ?",
+		reservationReleased, id, time.Now())
+	if err != nil {
+		return Reservation{}, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return Reservation{}, err
+	}
+	if affected == 0 {
+		// 他のリクエストが先に状態を変更したため、最新のステータスを返す
+		if latest, getErr := getReservation(ctx, db, id); getErr == nil {
+			reservation = latest
+		}
+		return reservation, errReservationNotActive
+	}
+
+	reservation.Status = reservationReleased
+	return reservation, nil
+}
+
+// sweepExpiredReservations は有効期限を過ぎた予約のステータスを expired に更新します。
+func sweepExpiredReservations(ctx context.Context, db Querier, now time.Time) (int64, error) {
+	result, err := db.ExecContext(ctx, "UPDATE stock_reservations SET status = ? WHERE status = 'active' AND expires_at <= ?",
+		reservationExpired, now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
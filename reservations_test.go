@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateReservationHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name         string
+		requestBody  string
+		mockSetup    func(mock sqlmock.Sqlmock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:        "正常な予約",
+			requestBody: `{"amount":3,"ttl_seconds":60}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE stock_reservations SET status = \\? WHERE status = 'active' AND expires_at <= \\?").
+					WithArgs("expired", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectBegin()
+				expectLockStock(mock, "apple", 10, 4)
+				mock.ExpectExec("INSERT INTO stock_reservations").
+					WithArgs(sqlmock.AnyArg(), "apple", 3, "active", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusCreated,
+			expectedBody: `"name":"apple","amount":3,"status":"active"`,
+		},
+		{
+			name:        "引当可能数が不足している場合は409",
+			requestBody: `{"amount":7}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE stock_reservations SET status = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectBegin()
+				expectLockStock(mock, "apple", 10, 4)
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: `"available":6`,
+		},
+		{
+			name:         "有効期限が上限を超える場合は400",
+			requestBody:  `{"amount":1,"ttl_seconds":100000}`,
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "ttl_seconds",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			router := gin.Default()
+			router.POST("/stocks/:name/reservations", createReservationHandler(&SQLDB{DB: db}))
+
+			req, _ := http.NewRequest(http.MethodPost, "/stocks/apple/reservations", bytes.NewBufferString(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			responseBody := w.Body.String()
+			t.Logf("テストケース: %s", tc.name)
+			t.Logf("レスポンスボディ: %s", responseBody)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Contains(t, responseBody, tc.expectedBody)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
+
+func TestReservationTransitionHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	reservationColumns := []string{"id", "name", "amount", "status", "expires_at", "created_at"}
+	expiresAt := time.Now().Add(10 * time.Minute)
+
+	testCases := []struct {
+		name         string
+		path         string
+		mockSetup    func(mock sqlmock.Sqlmock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "予約を確定すると在庫数から差し引かれる",
+			path: "/reservations/r-1/commit",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, name, amount, status, expires_at, created_at FROM stock_reservations WHERE id = \\?").
+					WithArgs("r-1").
+					WillReturnRows(sqlmock.NewRows(reservationColumns).
+						AddRow("r-1", "apple", 3, "active", expiresAt, time.Now()))
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT amount FROM stocks WHERE name = \\? AND deleted_at IS NULL FOR UPDATE").
+					WithArgs("apple").
+					WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(10))
+				mock.ExpectExec("UPDATE stock_reservations SET status = \\? WHERE id = \\?").
+					WithArgs("committed", "r-1", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE stocks SET amount = amount - \\?, version = version \\+ 1 WHERE name = \\? AND amount >= \\?").
+					WithArgs(3, "apple", 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRecordMovement(mock, "apple", -3, 7, "reservation_commit")
+				expectThresholds(mock, "apple", nil, nil)
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"status":"committed"`,
+		},
+		{
+			name: "予約した在庫が削除されている場合は404",
+			path: "/reservations/r-1/commit",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, name, amount, status, expires_at, created_at FROM stock_reservations WHERE id = \\?").
+					WithArgs("r-1").
+					WillReturnRows(sqlmock.NewRows(reservationColumns).
+						AddRow("r-1", "apple", 3, "active", expiresAt, time.Now()))
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT amount FROM stocks WHERE name = \\? AND deleted_at IS NULL FOR UPDATE").
+					WithArgs("apple").
+					WillReturnRows(sqlmock.NewRows([]string{"amount"}))
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "stock not found: apple",
+		},
+		{
+			name: "確定済みの予約は解放できない",
+			path: "/reservations/r-1/release",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, name, amount, status, expires_at, created_at FROM stock_reservations WHERE id = \\?").
+					WithArgs("r-1").
+					WillReturnRows(sqlmock.NewRows(reservationColumns).
+						AddRow("r-1", "apple", 3, "committed", expiresAt, time.Now()))
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: `"status":"committed"`,
+		},
+		{
+			name: "有効期限切れの予約は確定できない",
+			path: "/reservations/r-2/commit",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, name, amount, status, expires_at, created_at FROM stock_reservations WHERE id = \\?").
+					WithArgs("r-2").
+					WillReturnRows(sqlmock.NewRows(reservationColumns).
+						AddRow("r-2", "apple", 3, "active", time.Now().Add(-time.Minute), time.Now()))
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: `"status":"expired"`,
+		},
+		{
+			name: "存在しない予約は404",
+			path: "/reservations/unknown/release",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, name, amount, status, expires_at, created_at FROM stock_reservations WHERE id = \\?").
+					WithArgs("unknown").
+					WillReturnRows(sqlmock.NewRows(reservationColumns))
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "reservation not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			mockStorer := &SQLDB{DB: db}
+			router := gin.Default()
+			router.POST("/reservations/:id/commit", commitReservationHandler(mockStorer))
+			router.POST("/reservations/:id/release", releaseReservationHandler(mockStorer))
+
+			req, _ := http.NewRequest(http.MethodPost, tc.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			responseBody := w.Body.String()
+			t.Logf("テストケース: %s", tc.name)
+			t.Logf("レスポンスボディ: %s", responseBody)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Contains(t, responseBody, tc.expectedBody)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
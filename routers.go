@@ -1,15 +1,102 @@
 package main
 
 import (
+	"context"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 )
 
 // setupRoutes は Gin のルーティングを設定します。
-func setupRoutes(r *gin.Engine, db Storer) {
+// 環境変数で選んだ配信先などの設定に誤りがある場合はエラーを返します。
+func setupRoutes(r *gin.Engine, db Storer) error {
+	stocks, err := stockRepositoryFromEnv(db)
+	if err != nil {
+		return err
+	}
+	publisher, err := outboxPublisherFromEnv(context.Background())
+	if err != nil {
+		return err
+	}
+
+	// 在庫移動・在庫アラート・outbox・Idempotency-Key は在庫と同じ保存先に記録する
+	ledger := stockLedgerFor(db, stocks)
+	outbox := outboxFor(db, stocks)
+	idempotency := idempotencyMiddleware(idempotencyStoreFor(db, stocks))
+	// SQL の stocks テーブルを直接読み書きするエンドポイント
+	sqlOnly := sqlStocksOnly(stocks)
+
 	v1 := r.Group("/v1")
 	{
-		v1.GET("/stocks/:name", getStocksHandler(db))
-		v1.GET("/stocks", getAllStocksHandler(db))
-		v1.POST("/stocks", postStocksHandler(db))
+		v1.GET("/stocks/export.csv", exportStocksHandler(stocks))
+		v1.POST("/stocks/import", sqlOnly, importStocksHandler(db))
+		v1.GET("/stocks/:name", getStocksHandler(stocks))
+		v1.GET("/stocks/by-barcode/:code", sqlOnly, getStockByBarcodeHandler(db))
+		v1.GET("/stocks", getAllStocksHandler(stocks))
+		v1.POST("/stocks", idempotency, postStocksHandler(stocks))
+		v1.POST("/stocks:method", customMethod("batch"), idempotency, batchStocksHandler(stocks))
+		v1.PUT("/stocks/:name", putStockHandler(stocks))
+		v1.PATCH("/stocks/:name", adjustStockHandler(stocks))
+		v1.DELETE("/stocks/:name", deleteStockHandler(stocks))
+		v1.POST("/stocks/:name/restore", restoreStockHandler(stocks))
+		v1.GET("/stocks/:name/history", getStockHistoryHandler(ledger))
+		v1.GET("/stocks/:name/locations", sqlOnly, getStockLocationsHandler(db))
+		v1.PUT("/stocks/:name/product", sqlOnly, putStockProductHandler(db))
+		v1.DELETE("/stocks/:name/product", sqlOnly, deleteStockProductHandler(db))
+		v1.GET("/stocks/:name/thresholds", getStockThresholdsHandler(stocks))
+		v1.PUT("/stocks/:name/thresholds", putStockThresholdsHandler(stocks))
+		v1.POST("/stocks/:name/allocate", allocateStockHandler(stocks))
+		v1.POST("/stocks/:name/reservations", sqlOnly, createReservationHandler(db))
+		v1.GET("/reservations/:id", getReservationHandler(db))
+		v1.POST("/reservations/:id/commit", sqlOnly, commitReservationHandler(db))
+		v1.POST("/reservations/:id/release", sqlOnly, releaseReservationHandler(db))
+		v1.GET("/products", getProductsHandler(db))
+		v1.POST("/products", createProductHandler(db))
+		v1.GET("/products/:id", getProductHandler(db))
+		v1.PATCH("/products/:id", updateProductHandler(db))
+		v1.GET("/locations", getLocationsHandler(db))
+		v1.POST("/locations", createLocationHandler(db))
+		v1.GET("/locations/:code/stocks", sqlOnly, getLocationStocksHandler(db))
+		v1.GET("/locations/:code/stocks/:name", sqlOnly, getStockLevelHandler(db))
+		v1.PUT("/locations/:code/stocks/:name", sqlOnly, putStockLevelHandler(db, stocks))
+		v1.POST("/transfers", sqlOnly, transferStockHandler(db))
+		v1.POST("/orders", sqlOnly, createOrderHandler(db))
+		v1.GET("/orders/:id", getOrderHandler(db))
+		v1.GET("/alerts", getAlertsHandler(ledger))
+		v1.POST("/alerts/:id/acknowledge", acknowledgeAlertHandler(ledger))
+		v1.GET("/webhooks", sqlOnly, getWebhooksHandler(db))
+		v1.POST("/webhooks", sqlOnly, createWebhookHandler(db))
+		v1.GET("/webhooks/:id", sqlOnly, getWebhookHandler(db))
+		v1.DELETE("/webhooks/:id", sqlOnly, deleteWebhookHandler(db))
+		v1.GET("/webhooks/:id/deliveries", sqlOnly, getWebhookDeliveriesHandler(db))
+		v1.POST("/outbox/relay", relayOutboxHandler(outbox, publisher))
+	}
+	return nil
+}
+
+// sqlStocksOnly は在庫を DynamoDB に保存している場合に 501 を返すミドルウェアです。
+// 引当予約・注文・ロケーション・CSV の取り込みなどは SQL の stocks テーブルを直接読み書きするため、
+// DynamoDB の在庫と食い違わないように受け付けません。
+// Webhook の配信待ちは SQL の在庫移動と同じトランザクションで記録するため、Webhook も受け付けません。
+func sqlStocksOnly(stocks StockRepository) gin.HandlerFunc {
+	_, dynamo := stocks.(*dynamoStockRepository)
+	return func(c *gin.Context) {
+		if dynamo {
+			c.AbortWithStatusJSON(http.StatusNotImplemented, gin.H{"error": "This endpoint is not available when stocks are stored in DynamoDB"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// customMethod は POST /stocks:batch のようなカスタムメソッドのルートで、メソッド名が一致しない場合に 404 を返すミドルウェアです。
+// Gin はパスセグメントの途中のコロンをパラメータとして扱い、:method にはコロンを含めた値（":batch"）が入ります。
+func customMethod(name string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Param("method") != ":"+name {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Not found"})
+			return
+		}
+		c.Next()
 	}
 }
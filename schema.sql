@@ -0,0 +1,175 @@
+-- 在庫管理APIのスキーマ定義
+-- make db-setup や結合テストから読み込まれます。
+
+-- 商品マスタ
+-- 在庫は product_id で商品を参照するため、商品名や SKU を変更しても在庫数や在庫移動の履歴は変わりません。
+-- barcode は JAN/EAN コードで、未設定の場合は NULL です。
+CREATE TABLE IF NOT EXISTS products (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    sku VARCHAR(64) NOT NULL,
+    display_name VARCHAR(255) NOT NULL,
+    barcode VARCHAR(13) NULL,
+    unit VARCHAR(32) NOT NULL DEFAULT 'pcs',
+    category VARCHAR(255) NOT NULL DEFAULT '',
+    attributes JSON NULL,
+    created_at DATETIME(6) NOT NULL,
+    updated_at DATETIME(6) NOT NULL,
+    UNIQUE KEY uq_products_sku (sku),
+    UNIQUE KEY uq_products_barcode (barcode),
+    INDEX idx_products_category (category, id)
+);
+
+-- version は在庫数を変更するたびに加算され、ETag / If-Match による楽観的排他制御に使います。
+-- deleted_at が設定された在庫は論理削除済みで、一覧や名前での取得から除外されます。
+CREATE TABLE IF NOT EXISTS stocks (
+    name VARCHAR(255) PRIMARY KEY,
+    amount INT NOT NULL,
+    version BIGINT NOT NULL DEFAULT 1,
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6),
+    deleted_at DATETIME(6) NULL,
+    product_id BIGINT NULL,
+    reorder_point INT NULL,
+    safety_stock INT NULL,
+    INDEX idx_stocks_amount (amount, name),
+    INDEX idx_stocks_updated_at (updated_at, name),
+    INDEX idx_stocks_product (product_id),
+    FOREIGN KEY (product_id) REFERENCES products (id)
+);
+
+-- 在庫移動（在庫数の変更履歴）
+-- 在庫数を変更するたびに同じトランザクションで 1 行追記します。更新・削除は行いません。
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    delta INT NOT NULL,
+    amount_after INT NOT NULL,
+    reason VARCHAR(64) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    request_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME(6) NOT NULL,
+    location VARCHAR(64) NOT NULL DEFAULT 'default',
+    INDEX idx_stock_movements_name (name, id)
+);
+
+-- 在庫を保管するロケーション（倉庫）
+-- 既定のロケーション default の在庫数は stocks.amount で、/v1/stocks の各エンドポイントはこの在庫数を操作します。
+CREATE TABLE IF NOT EXISTS locations (
+    code VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at DATETIME(6) NOT NULL
+);
+
+INSERT IGNORE INTO locations (code, name, created_at) VALUES ('default', 'Default', CURRENT_TIMESTAMP(6));
+
+-- 既定以外のロケーションの在庫数
+-- 在庫（stocks の行）ごと・ロケーションごとに 1 行で、在庫を操作する際は stocks の行を先にロックします。
+CREATE TABLE IF NOT EXISTS stock_levels (
+    name VARCHAR(255) NOT NULL,
+    location VARCHAR(64) NOT NULL,
+    amount INT NOT NULL,
+    updated_at DATETIME(6) NOT NULL,
+    PRIMARY KEY (name, location),
+    INDEX idx_stock_levels_location (location, name),
+    FOREIGN KEY (location) REFERENCES locations (code)
+);
+
+-- Idempotency-Key ごとのリクエストとレスポンス
+-- status_code が NULL の行は、最初のリクエストがまだ処理中であることを表します。
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    idempotency_key VARCHAR(255) PRIMARY KEY,
+    fingerprint CHAR(64) NOT NULL,
+    status_code INT NULL,
+    response_headers TEXT NULL,
+    response_body MEDIUMBLOB NULL,
+    created_at DATETIME(6) NOT NULL,
+    expires_at DATETIME(6) NOT NULL,
+    INDEX idx_idempotency_keys_expires (expires_at)
+);
+
+-- 在庫の引当予約
+-- 有効期限内の active な予約の数量が、在庫の引当可能数から差し引かれます。
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    id CHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    amount INT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'active',
+    expires_at DATETIME(6) NOT NULL,
+    created_at DATETIME(6) NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_stock_reservations_name (name, status, expires_at),
+    INDEX idx_stock_reservations_expires (status, expires_at)
+);
+
+-- 注文と明細行
+-- 注文の全ての明細行は 1 つのトランザクションで引き当てられます。
+CREATE TABLE IF NOT EXISTS orders (
+    id CHAR(36) PRIMARY KEY,
+    created_at DATETIME(6) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS order_lines (
+    order_id CHAR(36) NOT NULL,
+    line_no INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    amount INT NOT NULL,
+    PRIMARY KEY (order_id, line_no),
+    FOREIGN KEY (order_id) REFERENCES orders (id)
+);
+
+-- 在庫アラート
+-- 引き当てや調整で在庫数が発注点（reorder_point）または安全在庫（safety_stock）以下に減ったときに、同じトランザクションで 1 行追記します。
+CREATE TABLE IF NOT EXISTS stock_alerts (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    threshold INT NOT NULL,
+    amount INT NOT NULL,
+    created_at DATETIME(6) NOT NULL,
+    acknowledged_at DATETIME(6) NULL,
+    acknowledged_by VARCHAR(255) NULL,
+    INDEX idx_stock_alerts_open (acknowledged_at, id),
+    INDEX idx_stock_alerts_name (name, id)
+);
+
+-- Webhook の配信先と配信ログ
+-- event_types は購読する在庫イベントの種類をカンマ区切りで保存します。
+-- 配信ログは status が pending の間、定期実行ジョブが next_attempt_at を過ぎたものから配信します。
+CREATE TABLE IF NOT EXISTS webhooks (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL,
+    event_types VARCHAR(255) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    created_at DATETIME(6) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    webhook_id BIGINT NOT NULL,
+    event_id CHAR(36) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSON NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    attempts INT NOT NULL,
+    response_status INT NULL,
+    error TEXT NOT NULL,
+    created_at DATETIME(6) NOT NULL,
+    next_attempt_at DATETIME(6) NULL,
+    INDEX idx_webhook_deliveries_webhook (webhook_id, id),
+    INDEX idx_webhook_deliveries_pending (status, next_attempt_at),
+    FOREIGN KEY (webhook_id) REFERENCES webhooks (id)
+);
+
+-- トランザクショナル outbox
+-- 在庫数を変更したのと同じトランザクションで、ドメインイベントを CloudEvents 1.0 の JSON 形式で書き込みます。
+-- POST /v1/outbox/relay が published_at が NULL のイベントを id 順に配信します。
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    event_id CHAR(36) NOT NULL UNIQUE,
+    type VARCHAR(64) NOT NULL,
+    payload JSON NOT NULL,
+    created_at DATETIME(6) NOT NULL,
+    published_at DATETIME(6) NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    INDEX idx_outbox_events_unpublished (published_at, id)
+);
@@ -0,0 +1,185 @@
+-- 在庫管理APIの PostgreSQL 用スキーマ定義
+-- DB_DRIVER=postgres または postgres:// の DATABASE_URL で接続する場合に使います。結合テストからも読み込まれます。
+-- テーブルと列は schema.sql と同じです。PostgreSQL には ON UPDATE がないため、updated_at は BEFORE UPDATE のトリガーで更新します。
+
+-- set_updated_at は MySQL の ON UPDATE CURRENT_TIMESTAMP と同じように、行を変更した UPDATE で updated_at を更新します。
+-- UPDATE 文で updated_at を明示的に変更した場合は、その値をそのまま使います。
+CREATE OR REPLACE FUNCTION set_updated_at() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW IS DISTINCT FROM OLD AND NEW.updated_at IS NOT DISTINCT FROM OLD.updated_at THEN
+        NEW.updated_at := statement_timestamp();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- 商品マスタ
+CREATE TABLE IF NOT EXISTS products (
+    id BIGSERIAL PRIMARY KEY,
+    sku VARCHAR(64) NOT NULL UNIQUE,
+    display_name VARCHAR(255) NOT NULL,
+    barcode VARCHAR(13) NULL UNIQUE,
+    unit VARCHAR(32) NOT NULL DEFAULT 'pcs',
+    category VARCHAR(255) NOT NULL DEFAULT '',
+    attributes JSONB NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_products_category ON products (category, id);
+
+-- version は在庫数を変更するたびに加算され、ETag / If-Match による楽観的排他制御に使います。
+-- deleted_at が設定された在庫は論理削除済みで、一覧や名前での取得から除外されます。
+CREATE TABLE IF NOT EXISTS stocks (
+    name VARCHAR(255) PRIMARY KEY,
+    amount INTEGER NOT NULL,
+    version BIGINT NOT NULL DEFAULT 1,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ NULL,
+    product_id BIGINT NULL REFERENCES products (id),
+    reorder_point INTEGER NULL,
+    safety_stock INTEGER NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_stocks_amount ON stocks (amount, name);
+CREATE INDEX IF NOT EXISTS idx_stocks_updated_at ON stocks (updated_at, name);
+CREATE INDEX IF NOT EXISTS idx_stocks_product ON stocks (product_id);
+
+DROP TRIGGER IF EXISTS trg_stocks_updated_at ON stocks;
+CREATE TRIGGER trg_stocks_updated_at BEFORE UPDATE ON stocks
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- 在庫移動（在庫数の変更履歴）
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    delta INTEGER NOT NULL,
+    amount_after INTEGER NOT NULL,
+    reason VARCHAR(64) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    request_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    location VARCHAR(64) NOT NULL DEFAULT 'default'
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_name ON stock_movements (name, id);
+
+-- 在庫を保管するロケーション（倉庫）
+CREATE TABLE IF NOT EXISTS locations (
+    code VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+INSERT INTO locations (code, name, created_at) VALUES ('default', 'Default', CURRENT_TIMESTAMP) ON CONFLICT (code) DO NOTHING;
+
+-- 既定以外のロケーションの在庫数
+CREATE TABLE IF NOT EXISTS stock_levels (
+    name VARCHAR(255) NOT NULL,
+    location VARCHAR(64) NOT NULL REFERENCES locations (code),
+    amount INTEGER NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (name, location)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_levels_location ON stock_levels (location, name);
+
+-- Idempotency-Key ごとのリクエストとレスポンス
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    idempotency_key VARCHAR(255) PRIMARY KEY,
+    fingerprint CHAR(64) NOT NULL,
+    status_code INTEGER NULL,
+    response_headers TEXT NULL,
+    response_body BYTEA NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires ON idempotency_keys (expires_at);
+
+-- 在庫の引当予約
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    id CHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    amount INTEGER NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'active',
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_name ON stock_reservations (name, status, expires_at);
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_expires ON stock_reservations (status, expires_at);
+
+DROP TRIGGER IF EXISTS trg_stock_reservations_updated_at ON stock_reservations;
+CREATE TRIGGER trg_stock_reservations_updated_at BEFORE UPDATE ON stock_reservations
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- 注文と明細行
+CREATE TABLE IF NOT EXISTS orders (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS order_lines (
+    order_id CHAR(36) NOT NULL REFERENCES orders (id),
+    line_no INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    amount INTEGER NOT NULL,
+    PRIMARY KEY (order_id, line_no)
+);
+
+-- 在庫アラート
+CREATE TABLE IF NOT EXISTS stock_alerts (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    threshold INTEGER NOT NULL,
+    amount INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    acknowledged_at TIMESTAMPTZ NULL,
+    acknowledged_by VARCHAR(255) NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_alerts_open ON stock_alerts (acknowledged_at, id);
+CREATE INDEX IF NOT EXISTS idx_stock_alerts_name ON stock_alerts (name, id);
+
+-- Webhook の配信先と配信ログ
+CREATE TABLE IF NOT EXISTS webhooks (
+    id BIGSERIAL PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL,
+    event_types VARCHAR(255) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id BIGINT NOT NULL REFERENCES webhooks (id),
+    event_id CHAR(36) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    attempts INTEGER NOT NULL,
+    response_status INTEGER NULL,
+    error TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    next_attempt_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON webhook_deliveries (webhook_id, id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending ON webhook_deliveries (status, next_attempt_at);
+
+-- トランザクショナル outbox
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_id CHAR(36) NOT NULL UNIQUE,
+    type VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    published_at TIMESTAMPTZ NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_unpublished ON outbox_events (published_at, id);
@@ -0,0 +1,174 @@
+-- 在庫管理APIの SQLite 用スキーマ定義
+-- DB_DRIVER=sqlite で起動すると、接続時にこのスキーマで存在しないテーブルを作成します。
+-- テーブルと列は schema.sql と同じです。SQLite には ON UPDATE がないため、stocks.updated_at はトリガーで更新します。
+-- 日時の列は DATETIME として宣言し、ドライバーが time.Time に変換できるようにします。
+
+-- 商品マスタ
+CREATE TABLE IF NOT EXISTS products (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    sku TEXT NOT NULL UNIQUE,
+    display_name TEXT NOT NULL,
+    barcode TEXT NULL UNIQUE,
+    unit TEXT NOT NULL DEFAULT 'pcs',
+    category TEXT NOT NULL DEFAULT '',
+    attributes TEXT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_products_category ON products (category, id);
+
+-- version は在庫数を変更するたびに加算され、ETag / If-Match による楽観的排他制御に使います。
+-- deleted_at が設定された在庫は論理削除済みで、一覧や名前での取得から除外されます。
+CREATE TABLE IF NOT EXISTS stocks (
+    name TEXT PRIMARY KEY,
+    amount INTEGER NOT NULL,
+    version INTEGER NOT NULL DEFAULT 1,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME NULL,
+    product_id INTEGER NULL REFERENCES products (id),
+    reorder_point INTEGER NULL,
+    safety_stock INTEGER NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_stocks_amount ON stocks (amount, name);
+CREATE INDEX IF NOT EXISTS idx_stocks_updated_at ON stocks (updated_at, name);
+CREATE INDEX IF NOT EXISTS idx_stocks_product ON stocks (product_id);
+
+-- MySQL の ON UPDATE CURRENT_TIMESTAMP の代わりに、updated_at を指定せずに更新した行の updated_at を現在時刻にする
+CREATE TRIGGER IF NOT EXISTS trg_stocks_updated_at AFTER UPDATE ON stocks
+WHEN NEW.updated_at = OLD.updated_at
+BEGIN
+    UPDATE stocks SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE name = NEW.name;
+END;
+
+-- 在庫移動（在庫数の変更履歴）
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    delta INTEGER NOT NULL,
+    amount_after INTEGER NOT NULL,
+    reason TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    request_id TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+    location TEXT NOT NULL DEFAULT 'default'
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_name ON stock_movements (name, id);
+
+-- 在庫を保管するロケーション（倉庫）
+CREATE TABLE IF NOT EXISTS locations (
+    code TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at DATETIME NOT NULL
+);
+
+INSERT OR IGNORE INTO locations (code, name, created_at) VALUES ('default', 'Default', CURRENT_TIMESTAMP);
+
+-- 既定以外のロケーションの在庫数
+CREATE TABLE IF NOT EXISTS stock_levels (
+    name TEXT NOT NULL,
+    location TEXT NOT NULL REFERENCES locations (code),
+    amount INTEGER NOT NULL,
+    updated_at DATETIME NOT NULL,
+    PRIMARY KEY (name, location)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_levels_location ON stock_levels (location, name);
+
+-- Idempotency-Key ごとのリクエストとレスポンス
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    idempotency_key TEXT PRIMARY KEY,
+    fingerprint TEXT NOT NULL,
+    status_code INTEGER NULL,
+    response_headers TEXT NULL,
+    response_body BLOB NULL,
+    created_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires ON idempotency_keys (expires_at);
+
+-- 在庫の引当予約
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    amount INTEGER NOT NULL,
+    status TEXT NOT NULL DEFAULT 'active',
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_name ON stock_reservations (name, status, expires_at);
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_expires ON stock_reservations (status, expires_at);
+
+-- 注文と明細行
+CREATE TABLE IF NOT EXISTS orders (
+    id TEXT PRIMARY KEY,
+    created_at DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS order_lines (
+    order_id TEXT NOT NULL REFERENCES orders (id),
+    line_no INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    amount INTEGER NOT NULL,
+    PRIMARY KEY (order_id, line_no)
+);
+
+-- 在庫アラート
+CREATE TABLE IF NOT EXISTS stock_alerts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    threshold INTEGER NOT NULL,
+    amount INTEGER NOT NULL,
+    created_at DATETIME NOT NULL,
+    acknowledged_at DATETIME NULL,
+    acknowledged_by TEXT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_alerts_open ON stock_alerts (acknowledged_at, id);
+CREATE INDEX IF NOT EXISTS idx_stock_alerts_name ON stock_alerts (name, id);
+
+-- Webhook の配信先と配信ログ
+CREATE TABLE IF NOT EXISTS webhooks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    url TEXT NOT NULL,
+    event_types TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    created_at DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    webhook_id INTEGER NOT NULL REFERENCES webhooks (id),
+    event_id TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    status TEXT NOT NULL,
+    attempts INTEGER NOT NULL,
+    response_status INTEGER NULL,
+    error TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    next_attempt_at DATETIME NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON webhook_deliveries (webhook_id, id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending ON webhook_deliveries (status, next_attempt_at);
+
+-- トランザクショナル outbox
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_id TEXT NOT NULL UNIQUE,
+    type TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    published_at DATETIME NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_unpublished ON outbox_events (published_at, id);
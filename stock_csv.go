@@ -0,0 +1,339 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	importModeAdd = "add"
+	importModeSet = "set"
+
+	maxImportRows = 5000
+)
+
+// utf8BOM は Excel が UTF-8 の CSV を正しく開くために先頭に付けるバイト順マークです。
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// stockCSVHeader はエクスポートする CSV の列です。
+// インポートは name と amount の列のみを読み込むため、エクスポートしたファイルをそのまま取り込めます。
+var stockCSVHeader = []string{"name", "amount", "reserved", "available", "updated_at"}
+
+// errImportInvalid はインポートする CSV に誤りのある行が含まれることを表します。
+var errImportInvalid = errors.New("CSV has invalid rows")
+
+// ImportRow はインポートする CSV の 1 行と、その行による在庫数の変更です。
+type ImportRow struct {
+	Line           int    `json:"line"`
+	Name           string `json:"name"`
+	Action         string `json:"action"`
+	PreviousAmount int    `json:"previous_amount"`
+	Amount         int    `json:"amount"`
+}
+
+// ImportError は CSV の行ごとのエラーです。
+type ImportError struct {
+	Line  int    `json:"line"`
+	Name  string `json:"name,omitempty"`
+	Error string `json:"error"`
+}
+
+// ImportResult は POST /stocks/import のレスポンスです。
+type ImportResult struct {
+	Mode      string        `json:"mode"`
+	DryRun    bool          `json:"dry_run"`
+	Created   int           `json:"created"`
+	Updated   int           `json:"updated"`
+	Unchanged int           `json:"unchanged"`
+	Rows      []ImportRow   `json:"rows"`
+	Errors    []ImportError `json:"errors,omitempty"`
+}
+
+// exportStocksHandler は GET /stocks/export.csv のリクエストを処理します。
+// 在庫を 1 行ずつ読み込みながら CSV を書き出します。
+func exportStocksHandler(stocks StockRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		opts := StockListOptions{IncludeDeleted: c.Query("include_deleted") == "true", Sort: defaultStockSort}
+
+		w := csv.NewWriter(c.Writer)
+		started := false
+		start := func() error {
+			started = true
+			c.Header("Content-Type", "text/csv; charset=utf-8")
+			c.Header("Content-Disposition", `attachment; filename="stocks.csv"`)
+			c.Status(http.StatusOK)
+			if _, err := c.Writer.Write(utf8BOM); err != nil {
+				return err
+			}
+			return w.Write(stockCSVHeader)
+		}
+
+		err := eachStockIn(ctx, stocks, opts, func(stock Stock) error {
+			if !started {
+				if err := start(); err != nil {
+					return err
+				}
+			}
+			updatedAt := ""
+			if stock.UpdatedAt != nil {
+				updatedAt = stock.UpdatedAt.UTC().Format(time.RFC3339)
+			}
+			return w.Write([]string{
+				stock.Name,
+				strconv.Itoa(stock.Amount),
+				strconv.Itoa(stock.Reserved),
+				strconv.Itoa(stock.Available),
+				updatedAt,
+			})
+		})
+		if err == nil && !started {
+			// 在庫がない場合もヘッダー行だけの CSV を返す
+			err = start()
+		}
+		if err != nil && !started {
+			writeServerError(c, err)
+			return
+		}
+		w.Flush()
+		if err == nil {
+			err = w.Error()
+		}
+		if err != nil {
+			// 書き出しを始めた後はステータスコードを変更できないため、ログに残して打ち切る
+			log.Printf("Failed to export stocks: %v", err)
+			c.Abort()
+		}
+	}
+}
+
+// importStocksHandler は POST /stocks/import のリクエストを処理します。
+// mode=add（既定）は CSV の数量を現在の在庫数に加算し、mode=set は CSV の数量で在庫数を上書きします。
+// 全ての行を 1 つのトランザクションで反映し、1 行でも誤りがあれば何も反映せずに行番号付きのエラーを返します。
+// dry_run=true の場合は反映せずに変更内容のみを返します。
+func importStocksHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		mode := c.DefaultQuery("mode", importModeAdd)
+		if mode != importModeAdd && mode != importModeSet {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "mode must be add or set"})
+			return
+		}
+		result := ImportResult{Mode: mode, DryRun: c.Query("dry_run") == "true", Rows: []ImportRow{}}
+
+		rows, rowErrors, err := parseStockCSV(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if len(rowErrors) > 0 {
+			result.Errors = rowErrors
+			c.JSON(http.StatusBadRequest, result)
+			return
+		}
+
+		reason := movementReceipt
+		if mode == importModeSet {
+			reason = movementStocktake
+		}
+		err = importStocks(ctx, db, rows, &result, movementMetaFromContext(c, reason))
+		switch {
+		case errors.Is(err, errImportInvalid):
+			c.JSON(http.StatusBadRequest, result)
+			return
+		case err != nil:
+			writeServerError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// parseStockCSV は CSV を読み込み、行ごとに検証します。
+// 1 行目はヘッダー行で、name と amount の列が必要です（列の順序は問いません）。
+// ヘッダー行が不正な場合などファイル全体を読み込めない場合は error を、行ごとの誤りは []ImportError を返します。
+func parseStockCSV(body io.Reader) ([]ImportRow, []ImportError, error) {
+	br := bufio.NewReader(body)
+	if prefix, err := br.Peek(len(utf8BOM)); err == nil && bytes.Equal(prefix, utf8BOM) {
+		br.Discard(len(utf8BOM))
+	}
+
+	r := csv.NewReader(br)
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, errors.New("CSV is empty")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+	nameCol, amountCol := -1, -1
+	for i, column := range header {
+		switch strings.ToLower(strings.TrimSpace(column)) {
+		case "name":
+			nameCol = i
+		case "amount":
+			amountCol = i
+		}
+	}
+	if nameCol < 0 || amountCol < 0 {
+		return nil, nil, errors.New("CSV header must contain name and amount columns")
+	}
+
+	var (
+		rows      []ImportRow
+		rowErrors []ImportError
+		seen      = make(map[string]int)
+	)
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			// 引用符の誤りなどは以降の行を正しく読み込めないため、その行で打ち切る
+			rowErrors = append(rowErrors, ImportError{Line: parseErr.Line, Error: parseErr.Err.Error()})
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		line, _ := r.FieldPos(0)
+		if len(rows)+len(rowErrors) >= maxImportRows {
+			return nil, nil, fmt.Errorf("CSV must not have more than %d rows", maxImportRows)
+		}
+		if len(record) <= nameCol || len(record) <= amountCol {
+			rowErrors = append(rowErrors, ImportError{Line: line, Error: "Row has too few columns"})
+			continue
+		}
+
+		row := ImportRow{Line: line, Name: strings.TrimSpace(record[nameCol])}
+		if row.Name == "" {
+			rowErrors = append(rowErrors, ImportError{Line: line, Error: "Name is required"})
+			continue
+		}
+		amount, err := strconv.Atoi(strings.TrimSpace(record[amountCol]))
+		if err != nil {
+			rowErrors = append(rowErrors, ImportError{Line: line, Name: row.Name, Error: "Amount must be an integer"})
+			continue
+		}
+		if amount < 0 {
+			rowErrors = append(rowErrors, ImportError{Line: line, Name: row.Name, Error: "Amount must not be negative"})
+			continue
+		}
+		if first, ok := seen[row.Name]; ok {
+			rowErrors = append(rowErrors, ImportError{Line: line, Name: row.Name, Error: fmt.Sprintf("Duplicate name (first on line %d)", first)})
+			continue
+		}
+		seen[row.Name] = line
+		row.Amount = amount
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 && len(rowErrors) == 0 {
+		return nil, nil, errors.New("CSV has no rows")
+	}
+	return rows, rowErrors, nil
+}
+
+// importStocks は検証済みの行を 1 つのトランザクションで反映し、result に変更内容を設定します。
+// 在庫行は名前の昇順でロックし、同時に処理される一括登録や注文とのデッドロックを防ぎます。
+// 論理削除された在庫の行があれば result.Errors に追加してロールバックし、errImportInvalid を返します。
+// result.DryRun が true の場合は在庫数を読み込むだけで書き込みません。
+func importStocks(ctx context.Context, db Storer, rows []ImportRow, result *ImportResult, meta MovementMeta) error {
+	order := make([]int, len(rows))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return rows[order[a]].Name < rows[order[b]].Name
+	})
+
+	now := time.Now()
+	return db.WithTx(ctx, func(tx Querier) error {
+		for _, i := range order {
+			row := &rows[i]
+			var deleted bool
+			err := tx.QueryRowContext(ctx, "SELECT amount, deleted_at IS NOT NULL FROM stocks WHERE name = ? FOR UPDATE", row.Name).Scan(&row.PreviousAmount, &deleted)
+			exists := true
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				exists = false
+			case err != nil:
+				return err
+			case deleted:
+				result.Errors = append(result.Errors, ImportError{Line: row.Line, Name: row.Name, Error: errStockDeleted.Error()})
+				continue
+			}
+
+			delta := row.Amount
+			if result.Mode == importModeAdd {
+				row.Amount = row.PreviousAmount + delta
+			} else {
+				delta = row.Amount - row.PreviousAmount
+			}
+			switch {
+			case !exists:
+				row.Action = "create"
+			case delta == 0:
+				row.Action = "unchanged"
+			default:
+				row.Action = "update"
+			}
+			if result.DryRun || row.Action == "unchanged" || len(result.Errors) > 0 {
+				continue
+			}
+
+			if exists {
+				_, err = tx.ExecContext(ctx, "UPDATE stocks SET amount = ?, version = version + 1 WHERE name = ?", row.Amount, row.Name)
+			} else {
+				_, err = tx.ExecContext(ctx, "INSERT INTO stocks (name, amount) VALUES (?, ?)", row.Name, row.Amount)
+			}
+			if err != nil {
+				return err
+			}
+			if err := recordMovement(ctx, tx, row.Name, delta, row.Amount, meta, now); err != nil {
+				return err
+			}
+			if err := checkLowStock(ctx, tx, row.Name, row.PreviousAmount, row.Amount, now); err != nil {
+				return err
+			}
+		}
+
+		if len(result.Errors) > 0 {
+			sort.Slice(result.Errors, func(a, b int) bool { return result.Errors[a].Line < result.Errors[b].Line })
+			return errImportInvalid
+		}
+		result.Rows = rows
+		for _, row := range rows {
+			switch row.Action {
+			case "create":
+				result.Created++
+			case "update":
+				result.Updated++
+			default:
+				result.Unchanged++
+			}
+		}
+		return nil
+	})
+}
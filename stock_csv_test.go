@@ -0,0 +1,228 @@
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expectImportLock は importStocks が発行する在庫行のロックの期待値を設定します。
+// amount が負の場合は在庫が存在しないものとします。
+func expectImportLock(mock sqlmock.Sqlmock, name string, amount int, deleted bool) {
+	q := mock.ExpectQuery("SELECT amount, deleted_at IS NOT NULL FROM stocks WHERE name = \\? FOR UPDATE").WithArgs(name)
+	if amount < 0 {
+		q.WillReturnError(sql.ErrNoRows)
+		return
+	}
+	q.WillReturnRows(sqlmock.NewRows([]string{"amount", "deleted"}).AddRow(amount, deleted))
+}
+
+func TestExportStocksHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	stockColumns := []string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}
+
+	testCases := []struct {
+		name         string
+		mockSetup    func(mock sqlmock.Sqlmock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "BOM付きのCSVを返す",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s (.+) WHERE s.deleted_at IS NULL (.+) ORDER BY s.name$").
+					WithArgs(sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows(stockColumns).
+						AddRow("りんご", 10, 2, 1, nil, nil).
+						AddRow("banana, large", 5, 0, 1, nil, nil))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "\xEF\xBB\xBFname,amount,reserved,available,updated_at\nりんご,10,2,8,\n\"banana, large\",5,0,5,\n",
+		},
+		{
+			name: "在庫がない場合はヘッダー行のみ",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s").
+					WithArgs(sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows(stockColumns))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "\xEF\xBB\xBFname,amount,reserved,available,updated_at\n",
+		},
+		{
+			name: "クエリが失敗した場合は500",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s").
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"error":"sql: connection is already closed"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			router := gin.New()
+			require.NoError(t, setupRoutes(router, &SQLDB{DB: db}))
+
+			req, _ := http.NewRequest(http.MethodGet, "/v1/stocks/export.csv", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			responseBody := w.Body.String()
+			t.Logf("テストケース: %s", tc.name)
+			t.Logf("レスポンスボディ: %s", responseBody)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Equal(t, tc.expectedBody, responseBody)
+			if tc.expectedCode == http.StatusOK {
+				assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
+
+func TestImportStocksHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name         string
+		query        string
+		requestBody  string
+		mockSetup    func(mock sqlmock.Sqlmock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:        "addモードは在庫数に加算する",
+			requestBody: "\xEF\xBB\xBFname,amount\nbanana,10\napple,3\n",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectImportLock(mock, "apple", -1, false)
+				mock.ExpectExec("INSERT INTO stocks \\(name, amount\\) VALUES \\(\\?, \\?\\)$").
+					WithArgs("apple", 3).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectRecordMovement(mock, "apple", 3, 3, "receipt")
+				expectImportLock(mock, "banana", 5, false)
+				mock.ExpectExec("UPDATE stocks SET amount = \\?, version = version \\+ 1 WHERE name = \\?").
+					WithArgs(15, "banana").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRecordMovement(mock, "banana", 10, 15, "receipt")
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"mode":"add","dry_run":false,"created":1,"updated":1,"unchanged":0,"rows":[{"line":2,"name":"banana","action":"update","previous_amount":5,"amount":15},{"line":3,"name":"apple","action":"create","previous_amount":0,"amount":3}]}`,
+		},
+		{
+			name:        "setモードは在庫数を上書きし、エクスポートしたCSVを取り込める",
+			query:       "?mode=set",
+			requestBody: "name,amount,reserved,available,updated_at\nbanana,4,0,4,2026-01-01T00:00:00Z\napple,3,1,2,\n",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectImportLock(mock, "apple", 3, false)
+				expectImportLock(mock, "banana", 10, false)
+				mock.ExpectExec("UPDATE stocks SET amount = \\?, version = version \\+ 1 WHERE name = \\?").
+					WithArgs(4, "banana").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRecordMovement(mock, "banana", -6, 4, "stocktake")
+				expectThresholds(mock, "banana", nil, nil)
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"created":0,"updated":1,"unchanged":1`,
+		},
+		{
+			name:        "dry_runは書き込まずに変更内容を返す",
+			query:       "?mode=set&dry_run=true",
+			requestBody: "name,amount\nbanana,4\n",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectImportLock(mock, "banana", 10, false)
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"mode":"set","dry_run":true,"created":0,"updated":1,"unchanged":0,"rows":[{"line":2,"name":"banana","action":"update","previous_amount":10,"amount":4}]}`,
+		},
+		{
+			name:         "誤りのある行は行番号付きで返す",
+			requestBody:  "amount,name\n1,banana\nx,apple\n2,\n-1,grape\n3,banana\n",
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `"errors":[{"line":3,"name":"apple","error":"Amount must be an integer"},{"line":4,"error":"Name is required"},{"line":5,"name":"grape","error":"Amount must not be negative"},{"line":6,"name":"banana","error":"Duplicate name (first on line 2)"}]`,
+		},
+		{
+			name:        "論理削除された在庫の行があれば何も反映しない",
+			requestBody: "name,amount\napple,1\nbanana,2\n",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectImportLock(mock, "apple", 5, false)
+				mock.ExpectExec("UPDATE stocks SET amount = \\?, version = version \\+ 1 WHERE name = \\?").
+					WithArgs(6, "apple").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRecordMovement(mock, "apple", 1, 6, "receipt")
+				expectImportLock(mock, "banana", 0, true)
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `"errors":[{"line":3,"name":"banana","error":"stock is deleted"}]`,
+		},
+		{
+			name:         "ヘッダーにamountがない場合は400",
+			requestBody:  "name,qty\napple,1\n",
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "CSV header must contain name and amount columns",
+		},
+		{
+			name:         "未知のmodeは400",
+			query:        "?mode=replace",
+			requestBody:  "name,amount\napple,1\n",
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "mode must be add or set",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			router := gin.New()
+			require.NoError(t, setupRoutes(router, &SQLDB{DB: db}))
+
+			req, _ := http.NewRequest(http.MethodPost, "/v1/stocks/import"+tc.query, strings.NewReader(tc.requestBody))
+			req.Header.Set("Content-Type", "text/csv")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			responseBody := w.Body.String()
+			t.Logf("テストケース: %s", tc.name)
+			t.Logf("レスポンスボディ: %s", responseBody)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Contains(t, responseBody, tc.expectedBody)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
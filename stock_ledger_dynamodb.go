@@ -0,0 +1,522 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// 台帳のテーブルのパーティションキー（pk）です。
+// 在庫移動は在庫ごと、outbox のイベントは在庫と同じ gsi_pk の分け方でパーティションを分けます。
+// 在庫アラートはしきい値を下回ったときだけ書き込まれるため、1 つのパーティションにまとめて新しい順に読み込みます。
+const (
+	dynamoMovementPartition    = "movement#"
+	dynamoOutboxPartition      = "outbox#"
+	dynamoIdempotencyPartition = "idempotency#"
+	dynamoAlertPartition       = "alert"
+	dynamoSequencePartition    = "sequence"
+)
+
+// dynamoIDLayout は ID をソートキー（sk）に使う際の形式です。桁数を固定し、文字列の順序と数値の順序を一致させます。
+const dynamoIDLayout = "%020d"
+
+// movementItems は在庫移動と、その在庫イベントを outbox に書き込む項目を返します。
+// 在庫移動の ID は変更後の在庫のバージョンで、在庫ごとに変更の順に増えます。
+// outbox のイベントのソートキーは書き込んだ時刻・在庫の名前・在庫移動の ID で、RelayBatch はこの順に配信します。
+// 同じトランザクションで同じ在庫を複数回変更した場合も、変更の順に配信されます。
+func (r *dynamoStockRepository) movementItems(name string, id int64, delta, amountAfter int, meta MovementMeta, now time.Time) ([]types.TransactWriteItem, error) {
+	event := newStockEvent(name, defaultLocation, delta, amountAfter, meta, now)
+	cloudEvent, err := newCloudEvent(event)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(cloudEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	movement := map[string]types.AttributeValue{
+		"pk":           dynamoString(dynamoMovementPartition + name),
+		"sk":           dynamoString(fmt.Sprintf(dynamoIDLayout, id)),
+		"id":           dynamoNumber(id),
+		"name":         dynamoString(name),
+		"location":     dynamoString(defaultLocation),
+		"delta":        dynamoNumber(int64(delta)),
+		"amount_after": dynamoNumber(int64(amountAfter)),
+		"reason":       dynamoString(meta.Reason),
+		"actor":        dynamoString(meta.Actor),
+		"request_id":   dynamoString(meta.RequestID),
+		"created_at":   dynamoTime(now),
+	}
+	outbox := map[string]types.AttributeValue{
+		"pk":       dynamoString(dynamoOutboxPartition + strconv.Itoa(dynamoStockShard(name))),
+		"sk":       dynamoString(now.UTC().Format(dynamoTimeLayout) + "#" + name + "#" + fmt.Sprintf(dynamoIDLayout, id)),
+		"payload":  dynamoString(string(payload)),
+		"attempts": dynamoNumber(0),
+	}
+	return []types.TransactWriteItem{r.putLedgerItem(movement), r.putLedgerItem(outbox)}, nil
+}
+
+// appendAlertItems は在庫数が在庫の項目 item のしきい値を下回った場合に、在庫アラートを書き込む項目を writes に追加します。
+// アラートの ID は台帳の連番から払い出すため、書き込みがやり直しになった場合は使われない ID が残ります。
+func (r *dynamoStockRepository) appendAlertItems(ctx context.Context, writes []types.TransactWriteItem, item map[string]types.AttributeValue, name string, amountBefore, amountAfter int, now time.Time) ([]types.TransactWriteItem, error) {
+	thresholds, err := thresholdsFromItem(name, item)
+	if err != nil {
+		return nil, err
+	}
+	alerts := lowStockAlerts(thresholds, amountBefore, amountAfter, now)
+	if len(alerts) == 0 {
+		return writes, nil
+	}
+	id, err := r.nextIDs(ctx, dynamoAlertPartition, len(alerts))
+	if err != nil {
+		return nil, err
+	}
+	for i, alert := range alerts {
+		writes = append(writes, r.putLedgerItem(map[string]types.AttributeValue{
+			"pk":         dynamoString(dynamoAlertPartition),
+			"sk":         dynamoString(fmt.Sprintf(dynamoIDLayout, id+int64(i))),
+			"id":         dynamoNumber(id + int64(i)),
+			"name":       dynamoString(alert.Name),
+			"kind":       dynamoString(alert.Kind),
+			"threshold":  dynamoNumber(int64(alert.Threshold)),
+			"amount":     dynamoNumber(int64(alert.Amount)),
+			"created_at": dynamoTime(alert.CreatedAt),
+		}))
+	}
+	return writes, nil
+}
+
+// putLedgerItem は同じキーの項目がないことを条件に、台帳のテーブルに項目を書き込む項目を返します。
+func (r *dynamoStockRepository) putLedgerItem(item map[string]types.AttributeValue) types.TransactWriteItem {
+	condition := "attribute_not_exists(#pk)"
+	return types.TransactWriteItem{Put: &types.Put{
+		TableName:                aws.String(r.ledgerTable),
+		Item:                     item,
+		ConditionExpression:      aws.String(condition),
+		ExpressionAttributeNames: dynamoExpressionNames(condition),
+	}}
+}
+
+// nextIDs は台帳の連番の項目 kind から n 個の連続した ID を払い出し、最初の ID を返します。
+func (r *dynamoStockRepository) nextIDs(ctx context.Context, kind string, n int) (int64, error) {
+	update := "SET #value = if_not_exists(#value, :zero) + :n"
+	out, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                aws.String(r.ledgerTable),
+		Key:                      dynamoLedgerKey(dynamoSequencePartition, kind),
+		UpdateExpression:         aws.String(update),
+		ExpressionAttributeNames: dynamoExpressionNames(update),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":zero": dynamoNumber(0),
+			":n":    dynamoNumber(int64(n)),
+		},
+		ReturnValues: types.ReturnValueUpdatedNew,
+	})
+	if err != nil {
+		return 0, err
+	}
+	last, err := dynamoItemNumber(out.Attributes, "value")
+	if err != nil {
+		return 0, err
+	}
+	return last - int64(n) + 1, nil
+}
+
+// Movements は在庫のパーティションをソートキー（ID）の降順に Query します。
+func (r *dynamoStockRepository) Movements(ctx context.Context, name string, beforeID int64, limit int) ([]Movement, error) {
+	keyCondition := "#pk = :pk"
+	values := map[string]types.AttributeValue{":pk": dynamoString(dynamoMovementPartition + name)}
+	if beforeID > 0 {
+		keyCondition += " AND #sk < :before"
+		values[":before"] = dynamoString(fmt.Sprintf(dynamoIDLayout, beforeID))
+	}
+	items, err := r.queryLedger(ctx, keyCondition, "", values, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	movements := make([]Movement, len(items))
+	for i, item := range items {
+		m := &movements[i]
+		m.Name, m.Location, m.Reason = dynamoItemString(item, "name"), dynamoItemString(item, "location"), dynamoItemString(item, "reason")
+		m.Actor, m.RequestID = dynamoItemString(item, "actor"), dynamoItemString(item, "request_id")
+		if m.ID, err = dynamoItemNumber(item, "id"); err != nil {
+			return nil, err
+		}
+		delta, err := dynamoItemNumber(item, "delta")
+		if err != nil {
+			return nil, err
+		}
+		amountAfter, err := dynamoItemNumber(item, "amount_after")
+		if err != nil {
+			return nil, err
+		}
+		createdAt, err := dynamoItemTime(item, "created_at")
+		if err != nil {
+			return nil, err
+		}
+		m.Delta, m.AmountAfter = int(delta), int(amountAfter)
+		if createdAt != nil {
+			m.CreatedAt = *createdAt
+		}
+	}
+	return movements, nil
+}
+
+// Alerts は在庫アラートのパーティションを ID の降順に Query し、確認の状態と名前はフィルター式で絞り込みます。
+func (r *dynamoStockRepository) Alerts(ctx context.Context, status, name string, beforeID int64, limit int) ([]Alert, error) {
+	keyCondition := "#pk = :pk"
+	values := map[string]types.AttributeValue{":pk": dynamoString(dynamoAlertPartition)}
+	if beforeID > 0 {
+		keyCondition += " AND #sk < :before"
+		values[":before"] = dynamoString(fmt.Sprintf(dynamoIDLayout, beforeID))
+	}
+	var filters []string
+	switch status {
+	case "open":
+		filters = append(filters, "attribute_not_exists(#acknowledged_at)")
+	case "acknowledged":
+		filters = append(filters, "attribute_exists(#acknowledged_at)")
+	}
+	if name != "" {
+		filters = append(filters, "#name = :name")
+		values[":name"] = dynamoString(name)
+	}
+	items, err := r.queryLedger(ctx, keyCondition, strings.Join(filters, " AND "), values, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]Alert, len(items))
+	for i, item := range items {
+		if alerts[i], err = alertFromItem(item); err != nil {
+			return nil, err
+		}
+	}
+	return alerts, nil
+}
+
+// AcknowledgeAlert は確認済みでないことを条件に、在庫アラートに確認した日時と操作者を書き込みます。
+func (r *dynamoStockRepository) AcknowledgeAlert(ctx context.Context, id int64, actor string, now time.Time) (Alert, error) {
+	update := "SET #acknowledged_at = :now, #acknowledged_by = :actor"
+	condition := "attribute_exists(#pk) AND attribute_not_exists(#acknowledged_at)"
+	out, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                aws.String(r.ledgerTable),
+		Key:                      dynamoLedgerKey(dynamoAlertPartition, fmt.Sprintf(dynamoIDLayout, id)),
+		UpdateExpression:         aws.String(update),
+		ConditionExpression:      aws.String(condition),
+		ExpressionAttributeNames: dynamoExpressionNames(update, condition),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":now":   dynamoTime(now),
+			":actor": dynamoString(actor),
+		},
+		ReturnValues: types.ReturnValueAllNew,
+	})
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		// 確認済みのアラートはそのまま返し、存在しないアラートは errAlertNotFound を返す
+		got, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+			TableName:      aws.String(r.ledgerTable),
+			Key:            dynamoLedgerKey(dynamoAlertPartition, fmt.Sprintf(dynamoIDLayout, id)),
+			ConsistentRead: aws.Bool(true),
+		})
+		if err != nil {
+			return Alert{}, err
+		}
+		if got.Item == nil {
+			return Alert{}, errAlertNotFound
+		}
+		return alertFromItem(got.Item)
+	}
+	if err != nil {
+		return Alert{}, err
+	}
+	return alertFromItem(out.Attributes)
+}
+
+// queryLedger は台帳のテーブルをソートキーの降順に Query し、フィルター式を満たす項目を最大 limit 件返します。
+// フィルター式は Limit 件を読み込んだ後に適用されるため、limit 件に達するか最後の項目まで Query を繰り返します。
+func (r *dynamoStockRepository) queryLedger(ctx context.Context, keyCondition, filter string, values map[string]types.AttributeValue, limit int) ([]map[string]types.AttributeValue, error) {
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(r.ledgerTable),
+		KeyConditionExpression:    aws.String(keyCondition),
+		ExpressionAttributeNames:  dynamoExpressionNames(keyCondition, filter),
+		ExpressionAttributeValues: values,
+		ScanIndexForward:          aws.Bool(false),
+		ConsistentRead:            aws.Bool(true),
+	}
+	if filter != "" {
+		input.FilterExpression = aws.String(filter)
+	}
+
+	var items []map[string]types.AttributeValue
+	for {
+		input.Limit = aws.Int32(int32(limit - len(items)))
+		out, err := r.client.Query(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, out.Items...)
+		if len(out.LastEvaluatedKey) == 0 || len(items) >= limit {
+			return items, nil
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+}
+
+// RelayBatch は outbox のパーティションごとに古いイベントから Query し、書き込んだ時刻の順に並べて最大 outboxBatchSize 件配信します。
+// 配信したイベントは outbox から削除します。配信に失敗したイベントには試行回数とエラーを記録し、
+// 順序を保つためそれ以降のイベントは次の呼び出しで配信します。
+// 複数の relay を同時に実行すると、同じイベントを配信することがあります（at-least-once）。
+func (r *dynamoStockRepository) RelayBatch(ctx context.Context, publisher Publisher) (int, bool, error) {
+	var (
+		items []map[string]types.AttributeValue
+		more  bool
+	)
+	keyCondition := "#pk = :pk"
+	for shard := range dynamoStockShards {
+		out, err := r.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:                 aws.String(r.ledgerTable),
+			KeyConditionExpression:    aws.String(keyCondition),
+			ExpressionAttributeNames:  dynamoExpressionNames(keyCondition),
+			ExpressionAttributeValues: map[string]types.AttributeValue{":pk": dynamoString(dynamoOutboxPartition + strconv.Itoa(shard))},
+			Limit:                     aws.Int32(outboxBatchSize),
+			ConsistentRead:            aws.Bool(true),
+		})
+		if err != nil {
+			return 0, false, err
+		}
+		items = append(items, out.Items...)
+		more = more || len(out.LastEvaluatedKey) > 0
+	}
+	slices.SortFunc(items, func(a, b map[string]types.AttributeValue) int {
+		return strings.Compare(dynamoItemString(a, "sk"), dynamoItemString(b, "sk"))
+	})
+	if len(items) > outboxBatchSize {
+		items = items[:outboxBatchSize]
+		more = true
+	}
+
+	published := 0
+	for _, item := range items {
+		key := dynamoLedgerKey(dynamoItemString(item, "pk"), dynamoItemString(item, "sk"))
+		var event CloudEvent
+		if err := json.Unmarshal([]byte(dynamoItemString(item, "payload")), &event); err != nil {
+			return published, false, fmt.Errorf("outbox event %s: %w", dynamoItemString(item, "sk"), err)
+		}
+		if publishErr := publisher.Publish(ctx, event); publishErr != nil {
+			update := "SET #attempts = #attempts + :one, #last_error = :error"
+			condition := "attribute_exists(#pk)"
+			_, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+				TableName:                aws.String(r.ledgerTable),
+				Key:                      key,
+				UpdateExpression:         aws.String(update),
+				ConditionExpression:      aws.String(condition),
+				ExpressionAttributeNames: dynamoExpressionNames(update, condition),
+				ExpressionAttributeValues: map[string]types.AttributeValue{
+					":one":   dynamoNumber(1),
+					":error": dynamoString(publishErr.Error()),
+				},
+			})
+			if err != nil && !isDynamoConditionFailed(err) {
+				return published, false, err
+			}
+			return published, false, publishErr
+		}
+		if _, err := r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{TableName: aws.String(r.ledgerTable), Key: key}); err != nil {
+			return published, false, err
+		}
+		published++
+	}
+	return published, more, nil
+}
+
+// ClaimIdempotencyKey は有効期限が切れたキーの項目を削除してから、項目がないことを条件に処理中のキーを書き込みます。
+func (r *dynamoStockRepository) ClaimIdempotencyKey(ctx context.Context, key, fingerprint string, now time.Time) (idempotencyRecord, bool, error) {
+	itemKey := dynamoIdempotencyKey(key)
+	if err := r.deleteIdempotencyKey(ctx, itemKey, now); err != nil {
+		return idempotencyRecord{}, false, err
+	}
+
+	expiresAt := now.Add(idempotencyLeaseTTL)
+	condition := "attribute_not_exists(#pk)"
+	_, err := r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.ledgerTable),
+		Item: map[string]types.AttributeValue{
+			"pk":          itemKey["pk"],
+			"sk":          itemKey["sk"],
+			"fingerprint": dynamoString(fingerprint),
+			"expires_at":  dynamoTime(expiresAt),
+			"ttl":         dynamoNumber(expiresAt.Unix()),
+		},
+		ConditionExpression:      aws.String(condition),
+		ExpressionAttributeNames: dynamoExpressionNames(condition),
+	})
+	if err == nil {
+		return idempotencyRecord{Fingerprint: fingerprint}, true, nil
+	}
+	if !isDynamoConditionFailed(err) {
+		return idempotencyRecord{}, false, err
+	}
+
+	out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{TableName: aws.String(r.ledgerTable), Key: itemKey, ConsistentRead: aws.Bool(true)})
+	if err != nil {
+		return idempotencyRecord{}, false, err
+	}
+	if out.Item == nil {
+		return idempotencyRecord{}, false, fmt.Errorf("idempotency key %q was released while claiming it", key)
+	}
+	record := idempotencyRecord{Fingerprint: dynamoItemString(out.Item, "fingerprint"), Body: []byte{}}
+	status, err := dynamoItemNumber(out.Item, "status_code")
+	if err != nil {
+		return idempotencyRecord{}, false, err
+	}
+	record.StatusCode = int(status)
+	if headers := dynamoItemString(out.Item, "headers"); headers != "" {
+		if err := json.Unmarshal([]byte(headers), &record.Headers); err != nil {
+			return idempotencyRecord{}, false, err
+		}
+	}
+	if body, ok := out.Item["body"].(*types.AttributeValueMemberB); ok {
+		record.Body = body.Value
+	}
+	return record, false, nil
+}
+
+// ReleaseIdempotencyKey は有効期限が処理中の期限（idempotencyLeaseTTL）以内のキーの項目だけを削除します。
+func (r *dynamoStockRepository) ReleaseIdempotencyKey(ctx context.Context, key string) {
+	if err := r.deleteIdempotencyKey(ctx, dynamoIdempotencyKey(key), time.Now().Add(idempotencyLeaseTTL)); err != nil {
+		log.Printf("Failed to release idempotency key %q: %v", key, err)
+	}
+}
+
+// deleteIdempotencyKey は有効期限が before 以前の場合のみ、キーの項目を削除します。
+func (r *dynamoStockRepository) deleteIdempotencyKey(ctx context.Context, key map[string]types.AttributeValue, before time.Time) error {
+	condition := "#expires_at <= :before"
+	_, err := r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName:                 aws.String(r.ledgerTable),
+		Key:                       key,
+		ConditionExpression:       aws.String(condition),
+		ExpressionAttributeNames:  dynamoExpressionNames(condition),
+		ExpressionAttributeValues: map[string]types.AttributeValue{":before": dynamoTime(before)},
+	})
+	if isDynamoConditionFailed(err) {
+		return nil
+	}
+	return err
+}
+
+func (r *dynamoStockRepository) SaveIdempotentResponse(ctx context.Context, key string, status int, headers map[string]string, body []byte, now time.Time) error {
+	encoded, err := json.Marshal(headers)
+	if err != nil {
+		return err
+	}
+	expiresAt := now.Add(idempotencyKeyTTL)
+	update := "SET #status_code = :status_code, #headers = :headers, #body = :body, #expires_at = :expires_at, #ttl = :ttl"
+	condition := "attribute_exists(#pk)"
+	_, err = r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                aws.String(r.ledgerTable),
+		Key:                      dynamoIdempotencyKey(key),
+		UpdateExpression:         aws.String(update),
+		ConditionExpression:      aws.String(condition),
+		ExpressionAttributeNames: dynamoExpressionNames(update, condition),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":status_code": dynamoNumber(int64(status)),
+			":headers":     dynamoString(string(encoded)),
+			":body":        &types.AttributeValueMemberB{Value: body},
+			":expires_at":  dynamoTime(expiresAt),
+			":ttl":         dynamoNumber(expiresAt.Unix()),
+		},
+	})
+	return err
+}
+
+// holdIdempotencyKey は処理中のキーの期限を idempotencyKeyTTL まで延ばす項目を返します。
+// 在庫を変更するトランザクションに含め、変更を書き込んだキーをリトライで再実行しないようにします。
+func (r *dynamoStockRepository) holdIdempotencyKey(key string, now time.Time) types.TransactWriteItem {
+	expiresAt := now.Add(idempotencyKeyTTL)
+	update := "SET #expires_at = :expires_at, #ttl = :ttl"
+	condition := "attribute_exists(#pk)"
+	return types.TransactWriteItem{Update: &types.Update{
+		TableName:                aws.String(r.ledgerTable),
+		Key:                      dynamoIdempotencyKey(key),
+		UpdateExpression:         aws.String(update),
+		ConditionExpression:      aws.String(condition),
+		ExpressionAttributeNames: dynamoExpressionNames(update, condition),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":expires_at": dynamoTime(expiresAt),
+			":ttl":        dynamoNumber(expiresAt.Unix()),
+		},
+	}}
+}
+
+// dynamoLedgerKey は台帳のテーブルの項目のプライマリキーを返します。
+func dynamoLedgerKey(pk, sk string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{"pk": dynamoString(pk), "sk": dynamoString(sk)}
+}
+
+// dynamoIdempotencyKey は Idempotency-Key の項目のプライマリキーを返します。
+func dynamoIdempotencyKey(key string) map[string]types.AttributeValue {
+	return dynamoLedgerKey(dynamoIdempotencyPartition+key, "-")
+}
+
+// alertFromItem は台帳のテーブルの項目を Alert に変換します。
+func alertFromItem(item map[string]types.AttributeValue) (Alert, error) {
+	alert := Alert{
+		Name:           dynamoItemString(item, "name"),
+		Kind:           dynamoItemString(item, "kind"),
+		AcknowledgedBy: dynamoItemString(item, "acknowledged_by"),
+	}
+	var err error
+	if alert.ID, err = dynamoItemNumber(item, "id"); err != nil {
+		return Alert{}, err
+	}
+	threshold, err := dynamoItemNumber(item, "threshold")
+	if err != nil {
+		return Alert{}, err
+	}
+	amount, err := dynamoItemNumber(item, "amount")
+	if err != nil {
+		return Alert{}, err
+	}
+	alert.Threshold, alert.Amount = int(threshold), int(amount)
+	createdAt, err := dynamoItemTime(item, "created_at")
+	if err != nil {
+		return Alert{}, err
+	}
+	if createdAt != nil {
+		alert.CreatedAt = *createdAt
+	}
+	if alert.AcknowledgedAt, err = dynamoItemTime(item, "acknowledged_at"); err != nil {
+		return Alert{}, err
+	}
+	return alert, nil
+}
+
+// isDynamoConditionFailed は UpdateItem などの条件式を満たさなかったことによるエラーかを判定します。
+func isDynamoConditionFailed(err error) bool {
+	var conditionFailed *types.ConditionalCheckFailedException
+	return errors.As(err, &conditionFailed)
+}
+
+func dynamoString(s string) types.AttributeValue {
+	return &types.AttributeValueMemberS{Value: s}
+}
+
+// dynamoItemString は文字列の属性を読み込みます。属性がない場合は空文字列を返します。
+func dynamoItemString(item map[string]types.AttributeValue, key string) string {
+	if s, ok := item[key].(*types.AttributeValueMemberS); ok {
+		return s.Value
+	}
+	return ""
+}
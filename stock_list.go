@@ -0,0 +1,326 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultStockPageLimit = 100
+	maxStockPageLimit     = 500
+	defaultStockSort      = "name"
+)
+
+// stockSortColumns は sort パラメータで指定できるキーと、並べ替えに使う列の対応です。
+// 列名は必ずこの表から選び、リクエストの値を SQL に埋め込むことはしません。
+var stockSortColumns = map[string]string{
+	"name":       "s.name",
+	"amount":     "s.amount",
+	"updated_at": "s.updated_at",
+}
+
+// StockListOptions は在庫一覧の取得条件です。
+type StockListOptions struct {
+	IncludeDeleted bool
+	// Prefix は名前の前方一致、Contains は名前の部分一致の条件です。
+	Prefix       string
+	Contains     string
+	MinAmount    *int
+	MaxAmount    *int
+	UpdatedSince *time.Time
+	// Sort は stockSortColumns のキーで、先頭に "-" を付けると降順になります。
+	Sort string
+	// After が指定された場合は、そのカーソルより後の在庫のみを返します。
+	After *stockCursor
+	// Limit が 0 の場合は件数を制限しません。
+	Limit int
+}
+
+// StockPage は GET /stocks のレスポンスです。
+type StockPage struct {
+	Stocks     []Stock `json:"stocks"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+// stockCursor は一覧の最後の在庫の並べ替えキーです。
+// 名前順以外では同じ値の在庫が複数あるため、名前と組み合わせて位置を決めます。
+type stockCursor struct {
+	Sort  string `json:"sort"`
+	Value string `json:"value"`
+	// Null は並べ替えキーの値がない（updated_at が nil の）在庫のカーソルであることを示します。
+	// nil の更新日時は compareTimes と同じく、他のどの時刻よりも前として並べます。
+	Null bool   `json:"null,omitempty"`
+	Name string `json:"name"`
+	// Shards は在庫を複数のパーティションに分けて保存する保存先（DynamoDB）で、パーティションごとに最後に返した在庫の位置です。
+	// まだ在庫を返していないパーティションは nil です。
+	Shards []*stockShardPosition `json:"shards,omitempty"`
+}
+
+// stockShardPosition は 1 つのパーティションで最後に返した在庫の並べ替えキーです。
+type stockShardPosition struct {
+	Value string `json:"value,omitempty"`
+	Null  bool   `json:"null,omitempty"`
+	Name  string `json:"name"`
+}
+
+// stockCursorer は一覧のページから次のページのカーソルを作成するリポジトリです。
+// 在庫を複数のパーティションに分けて保存するリポジトリは、パーティションごとの位置をカーソルに含めます。
+type stockCursorer interface {
+	NextCursor(opts StockListOptions, page []Stock) string
+}
+
+// parseStockListOptions は GET /stocks のクエリパラメータを検証して取得条件に変換します。
+func parseStockListOptions(c *gin.Context) (StockListOptions, error) {
+	opts := StockListOptions{
+		IncludeDeleted: c.Query("include_deleted") == "true",
+		Prefix:         c.Query("prefix"),
+		Contains:       c.Query("contains"),
+		Sort:           c.DefaultQuery("sort", defaultStockSort),
+	}
+
+	if _, ok := stockSortColumns[strings.TrimPrefix(opts.Sort, "-")]; !ok {
+		return opts, fmt.Errorf("sort must be one of name, amount, updated_at (prefix with - for descending)")
+	}
+
+	var err error
+	if opts.MinAmount, err = parseOptionalInt(c, "min_amount"); err != nil {
+		return opts, err
+	}
+	if opts.MaxAmount, err = parseOptionalInt(c, "max_amount"); err != nil {
+		return opts, err
+	}
+	if opts.MinAmount != nil && opts.MaxAmount != nil && *opts.MinAmount > *opts.MaxAmount {
+		return opts, errors.New("min_amount must not be greater than max_amount")
+	}
+
+	if v := c.Query("updated_since"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return opts, errors.New("updated_since must be an RFC 3339 timestamp")
+		}
+		opts.UpdatedSince = &t
+	}
+
+	limit, err := parseLimit(c, defaultStockPageLimit, maxStockPageLimit)
+	if err != nil {
+		return opts, err
+	}
+	opts.Limit = limit
+
+	if v := c.Query("cursor"); v != "" {
+		cursor, err := decodeStockCursor(v, opts.Sort)
+		if err != nil {
+			return opts, err
+		}
+		opts.After = &cursor
+	}
+	return opts, nil
+}
+
+// parseOptionalInt は整数のクエリパラメータを読み込みます。指定がない場合は nil を返します。
+func parseOptionalInt(c *gin.Context, key string) (*int, error) {
+	v := c.Query(key)
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an integer", key)
+	}
+	return &n, nil
+}
+
+// where は取得条件を WHERE 句の条件とプレースホルダの値に変換します。
+func (opts StockListOptions) where() ([]string, []interface{}) {
+	var (
+		conds []string
+		args  []interface{}
+	)
+	if !opts.IncludeDeleted {
+		conds = append(conds, stockNotDeleted)
+	}
+	if opts.Prefix != "" {
+		conds = append(conds, "s.name LIKE ? ESCAPE '!'")
+		args = append(args, escapeLike(opts.Prefix)+"%")
+	}
+	if opts.Contains != "" {
+		conds = append(conds, "s.name LIKE ? ESCAPE '!'")
+		args = append(args, "%"+escapeLike(opts.Contains)+"%")
+	}
+	if opts.MinAmount != nil {
+		conds = append(conds, "s.amount >= ?")
+		args = append(args, *opts.MinAmount)
+	}
+	if opts.MaxAmount != nil {
+		conds = append(conds, "s.amount <= ?")
+		args = append(args, *opts.MaxAmount)
+	}
+	if opts.UpdatedSince != nil {
+		conds = append(conds, "s.updated_at >= ?")
+		args = append(args, *opts.UpdatedSince)
+	}
+
+	if opts.After != nil {
+		column, desc := opts.sortColumn()
+		op := ">"
+		if desc {
+			op = "<"
+		}
+		switch {
+		case column == "s.name":
+			conds = append(conds, "s.name "+op+" ?")
+			args = append(args, opts.After.Name)
+		case opts.After.Null && desc:
+			// NULL は最後に並ぶため、残りは NULL で名前が後の在庫だけ
+			conds = append(conds, "("+column+" IS NULL AND s.name > ?)")
+			args = append(args, opts.After.Name)
+		case opts.After.Null:
+			// NULL は最初に並ぶため、残りは NULL でない在庫と、NULL で名前が後の在庫
+			conds = append(conds, "("+column+" IS NOT NULL OR s.name > ?)")
+			args = append(args, opts.After.Name)
+		default:
+			// 同じ値の在庫は名前の昇順で並ぶ
+			conds = append(conds, "("+column+" "+op+" ? OR ("+column+" = ? AND s.name > ?))")
+			value := opts.After.sortValue()
+			args = append(args, value, value, opts.After.Name)
+		}
+	}
+	return conds, args
+}
+
+// orderBy は ORDER BY 句を返します。
+func (opts StockListOptions) orderBy() string {
+	column, desc := opts.sortColumn()
+	order := column
+	if desc {
+		order += " DESC"
+	}
+	if column != "s.name" {
+		order += ", s.name"
+	}
+	return " ORDER BY " + order
+}
+
+// sortColumn は並べ替えに使う列と、降順かどうかを返します。
+func (opts StockListOptions) sortColumn() (string, bool) {
+	sort := opts.Sort
+	if sort == "" {
+		sort = defaultStockSort
+	}
+	return stockSortColumns[strings.TrimPrefix(sort, "-")], strings.HasPrefix(sort, "-")
+}
+
+// escapeLike は LIKE のワイルドカード文字を ! でエスケープします。
+// SQLite には既定のエスケープ文字がないため、LIKE には ESCAPE '!' を付けてください。
+// MySQL では '\' が文字列リテラルのエスケープになり、どのデータベースでも同じ SQL にできないため ! を使います。
+func escapeLike(s string) string {
+	return strings.NewReplacer(`!`, `!!`, `%`, `!%`, `_`, `!_`).Replace(s)
+}
+
+// nextStockCursor は一覧のページ page の次のページのカーソルを作成します。
+// stocks が stockCursorer を実装していなければ、ページの最後の在庫の並べ替えキーをカーソルにします。
+func nextStockCursor(stocks StockRepository, opts StockListOptions, page []Stock) string {
+	if cursorer, ok := stocks.(stockCursorer); ok {
+		return cursorer.NextCursor(opts, page)
+	}
+	return stockCursorFor(page[len(page)-1], opts.Sort)
+}
+
+// stockCursorFor は在庫の並べ替えキーからカーソルを作成します。
+// 名前順のカーソルは名前だけを持ちます。
+func stockCursorFor(stock Stock, sort string) string {
+	if sort == "" || sort == defaultStockSort {
+		return encodeCursor(stock.Name)
+	}
+	return newStockCursor(stock, sort).encode()
+}
+
+// newStockCursor は在庫の並べ替えキーを持つカーソルを作成します。
+func newStockCursor(stock Stock, sort string) stockCursor {
+	cursor := stockCursor{Sort: sort, Name: stock.Name}
+	switch strings.TrimPrefix(sort, "-") {
+	case "amount":
+		cursor.Value = strconv.Itoa(stock.Amount)
+	case "updated_at":
+		if stock.UpdatedAt != nil {
+			cursor.Value = stock.UpdatedAt.UTC().Format(time.RFC3339Nano)
+		} else {
+			cursor.Null = true
+		}
+	}
+	return cursor
+}
+
+// encode はカーソルを JSON にしてクライアントに渡す形式に変換します。
+func (c stockCursor) encode() string {
+	encoded, _ := json.Marshal(c)
+	return encodeCursor(string(encoded))
+}
+
+// decodeStockCursor はカーソルを読み込み、指定された並べ替え順で作成されたものか確認します。
+func decodeStockCursor(v, sort string) (stockCursor, error) {
+	key, err := decodeCursor(v)
+	if err != nil {
+		return stockCursor{}, err
+	}
+
+	var cursor stockCursor
+	err = json.Unmarshal([]byte(key), &cursor)
+	if sort == defaultStockSort && (err != nil || cursor.Sort != sort || cursor.Shards == nil) {
+		// 名前順のカーソルは名前そのもので、パーティションごとの位置を持つカーソルだけを JSON にする
+		return stockCursor{Sort: sort, Name: key}, nil
+	}
+	if err != nil || cursor.Sort != sort {
+		return stockCursor{}, errInvalidCursor
+	}
+	if _, err := cursor.parseValue(); err != nil {
+		return stockCursor{}, errInvalidCursor
+	}
+	for i := range cursor.Shards {
+		if shard, ok := cursor.shard(i); ok {
+			if _, err := shard.parseValue(); err != nil {
+				return stockCursor{}, errInvalidCursor
+			}
+		}
+	}
+	return cursor, nil
+}
+
+// shard は i 番目のパーティションの位置を、同じ並べ替え順のカーソルとして返します。
+// そのパーティションの在庫をまだ返していない場合は false を返します。
+func (c stockCursor) shard(i int) (stockCursor, bool) {
+	if i >= len(c.Shards) || c.Shards[i] == nil {
+		return stockCursor{}, false
+	}
+	position := c.Shards[i]
+	return stockCursor{Sort: c.Sort, Value: position.Value, Null: position.Null, Name: position.Name}, true
+}
+
+// sortValue はカーソルの並べ替えキーを SQL のプレースホルダに渡す値に変換します。
+func (c stockCursor) sortValue() interface{} {
+	v, _ := c.parseValue()
+	return v
+}
+
+// Null のカーソルは nil を返します。Null にできるのは updated_at のカーソルだけです。
+func (c stockCursor) parseValue() (interface{}, error) {
+	if c.Null {
+		if strings.TrimPrefix(c.Sort, "-") != "updated_at" {
+			return nil, errInvalidCursor
+		}
+		return nil, nil
+	}
+	switch strings.TrimPrefix(c.Sort, "-") {
+	case "amount":
+		return strconv.Atoi(c.Value)
+	case "updated_at":
+		return time.Parse(time.RFC3339Nano, c.Value)
+	}
+	return c.Value, nil
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllStocksHandlerFilters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	stockColumns := []string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}
+	updatedAt := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		query        url.Values
+		mockSetup    func(mock sqlmock.Sqlmock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "前方一致と数量の範囲で絞り込み、数量の降順に並べる",
+			query: url.Values{
+				"prefix":     {"ap"},
+				"min_amount": {"1"},
+				"max_amount": {"10"},
+				"sort":       {"-amount"},
+			},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("WHERE s.deleted_at IS NULL AND s.name LIKE \\? ESCAPE '!' AND s.amount >= \\? AND s.amount <= \\? GROUP BY (.+) ORDER BY s.amount DESC, s.name LIMIT \\?").
+					WithArgs(sqlmock.AnyArg(), "ap%", 1, 10, defaultStockPageLimit+1).
+					WillReturnRows(sqlmock.NewRows(stockColumns).
+						AddRow("apricot", 8, 0, 1, updatedAt, nil).
+						AddRow("apple", 3, 0, 1, updatedAt, nil))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"name":"apricot"`,
+		},
+		{
+			name:  "部分一致のワイルドカード文字はエスケープする",
+			query: url.Values{"contains": {"50%_off"}, "updated_since": {"2025-04-01T00:00:00Z"}},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("WHERE s.deleted_at IS NULL AND s.name LIKE \\? ESCAPE '!' AND s.updated_at >= \\? GROUP BY (.+) ORDER BY s.name LIMIT \\?").
+					WithArgs(sqlmock.AnyArg(), `%50!%!_off%`, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), defaultStockPageLimit+1).
+					WillReturnRows(sqlmock.NewRows(stockColumns))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "データが存在しません",
+		},
+		{
+			name: "数量順のカーソルは数量と名前で続きの位置を決める",
+			query: url.Values{
+				"sort":   {"-amount"},
+				"limit":  {"1"},
+				"cursor": {stockCursorFor(Stock{Name: "banana", Amount: 5}, "-amount")},
+			},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("WHERE s.deleted_at IS NULL AND \\(s.amount < \\? OR \\(s.amount = \\? AND s.name > \\?\\)\\) GROUP BY (.+) ORDER BY s.amount DESC, s.name LIMIT \\?").
+					WithArgs(sqlmock.AnyArg(), 5, 5, "banana", 2).
+					WillReturnRows(sqlmock.NewRows(stockColumns).
+						AddRow("cherry", 5, 0, 1, updatedAt, nil).
+						AddRow("apple", 3, 0, 1, updatedAt, nil))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"next_cursor":"` + stockCursorFor(Stock{Name: "cherry", Amount: 5}, "-amount") + `"`,
+		},
+		{
+			name: "更新日時のない在庫のカーソルはNULLの後ろから続きを読む",
+			query: url.Values{
+				"sort":   {"updated_at"},
+				"limit":  {"1"},
+				"cursor": {stockCursorFor(Stock{Name: "banana"}, "updated_at")},
+			},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("WHERE s.deleted_at IS NULL AND \\(s.updated_at IS NOT NULL OR s.name > \\?\\) GROUP BY (.+) ORDER BY s.updated_at, s.name LIMIT \\?").
+					WithArgs(sqlmock.AnyArg(), "banana", 2).
+					WillReturnRows(sqlmock.NewRows(stockColumns).
+						AddRow("apple", 3, 0, 1, updatedAt, nil))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"name":"apple"`,
+		},
+		{
+			name:         "並べ替え順が異なるカーソルは400",
+			query:        url.Values{"sort": {"amount"}, "cursor": {stockCursorFor(Stock{Name: "banana", Amount: 5}, "-amount")}},
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "invalid cursor",
+		},
+		{
+			name:         "不正な並べ替えキーは400",
+			query:        url.Values{"sort": {"name; DROP TABLE stocks"}},
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "sort must be one of",
+		},
+		{
+			name:         "min_amountがmax_amountより大きい場合は400",
+			query:        url.Values{"min_amount": {"10"}, "max_amount": {"1"}},
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "min_amount must not be greater than max_amount",
+		},
+		{
+			name:         "updated_sinceが不正な形式の場合は400",
+			query:        url.Values{"updated_since": {"yesterday"}},
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "updated_since must be an RFC 3339 timestamp",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			router := gin.Default()
+			router.GET("/stocks", getAllStocksHandler(newMySQLStockRepository(&SQLDB{DB: db})))
+
+			req, _ := http.NewRequest(http.MethodGet, "/stocks?"+tc.query.Encode(), nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			responseBody := w.Body.String()
+			t.Logf("テストケース: %s", tc.name)
+			t.Logf("レスポンスボディ: %s", responseBody)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Contains(t, responseBody, tc.expectedBody)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetAllStocksHandlerPagesNilUpdatedAt(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	updatedAt := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	stocks := newMemoryStockRepository(
+		Stock{Name: "apple", Amount: 1},
+		Stock{Name: "banana", Amount: 2, UpdatedAt: &updatedAt},
+		Stock{Name: "cherry", Amount: 3},
+	)
+	router := gin.New()
+	router.GET("/stocks", getAllStocksHandler(stocks))
+
+	testCases := []struct {
+		name     string
+		sort     string
+		expected []string
+	}{
+		{name: "昇順では更新日時のない在庫を先に並べる", sort: "updated_at", expected: []string{"apple", "cherry", "banana"}},
+		{name: "降順では更新日時のない在庫を後に並べる", sort: "-updated_at", expected: []string{"banana", "apple", "cherry"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var names []string
+			query := url.Values{"sort": {tc.sort}, "limit": {"1"}}
+			for {
+				req, _ := http.NewRequest(http.MethodGet, "/stocks?"+query.Encode(), nil)
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+				var page StockPage
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+				for _, stock := range page.Stocks {
+					names = append(names, stock.Name)
+				}
+				if page.NextCursor == "" {
+					break
+				}
+				query.Set("cursor", page.NextCursor)
+			}
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
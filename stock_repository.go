@@ -0,0 +1,529 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// StockRepository は在庫の読み書きを行うリポジトリです。
+// 在庫のハンドラーは SQL を扱わず、このインターフェースだけに依存します。
+// 書き込みのメソッドは、在庫数の変更と在庫移動の記録を 1 つのトランザクションで行います。
+// match が指定された場合は、在庫のバージョンが一致しなければ errPreconditionFailed を返します。
+type StockRepository interface {
+	// Get は名前を指定して在庫を 1 件取得し、紐付く商品があれば Product に設定します。
+	// 在庫が存在しない場合は errStockNotFound を返します。includeDeleted が false の場合、論理削除された在庫は存在しないものとして扱います。
+	Get(ctx context.Context, name string, includeDeleted bool) (Stock, error)
+	// List は opts の条件に一致する在庫を opts.Sort の順に取得します。
+	List(ctx context.Context, opts StockListOptions) ([]Stock, error)
+	// Upsert は在庫数に amount を加算し、加算後の在庫を返します。在庫が存在しない場合は作成します。
+	// 論理削除された在庫の場合は errStockDeleted を返します。
+	Upsert(ctx context.Context, name string, amount int, match *versionMatch, meta MovementMeta) (Stock, error)
+	// UpsertAll は items の在庫数をまとめて加算し、加算後の在庫数を items と同じ順に返します。
+	// 全ての在庫を一度に加算し、1 件でも失敗した場合は何も加算せずに、失敗した在庫の位置を *batchItemError で返します。
+	UpsertAll(ctx context.Context, items []Stock, meta MovementMeta) ([]int, error)
+	// Set は在庫数を amount に置き換え、変更後の在庫を返します。在庫が存在しない場合は作成し、created に true を返します。
+	// createOnly が true で在庫が既に存在する場合は errStockExists、論理削除された在庫の場合は errStockDeleted を返します。
+	Set(ctx context.Context, name string, amount int, createOnly bool, match *versionMatch, meta MovementMeta) (stock Stock, created bool, err error)
+	// Adjust は在庫数に符号付きの増減を適用し、調整結果と調整後のバージョンを返します。
+	// AllowNegative が false の場合、調整後の在庫数が負になると errInsufficientStock を返します。
+	Adjust(ctx context.Context, name string, req AdjustmentRequest, match *versionMatch, meta MovementMeta) (AdjustmentResult, int64, error)
+	// Delete は在庫を論理削除します。有効な引当予約が残っている場合は errStockReserved を返します。
+	Delete(ctx context.Context, name string, match *versionMatch, meta MovementMeta) error
+	// Restore は論理削除された在庫を元に戻し、在庫を返します。削除されていない在庫に対しては何もしません。
+	Restore(ctx context.Context, name string, match *versionMatch, meta MovementMeta) (Stock, error)
+	// Allocate は在庫数から amount を引き当て、引き当て後の在庫を返します。
+	// 引当可能数が足りない場合は現在の在庫と errInsufficientStock を返します。
+	Allocate(ctx context.Context, name string, amount int, match *versionMatch, meta MovementMeta) (Stock, error)
+	// Thresholds は在庫の発注点と安全在庫を返します。在庫が存在しないか論理削除されている場合は errStockNotFound を返します。
+	Thresholds(ctx context.Context, name string) (StockThresholds, error)
+	// SetThresholds は在庫の発注点と安全在庫を置き換えます。nil のしきい値は解除します。
+	// 在庫が存在しないか論理削除されている場合は errStockNotFound を返します。
+	SetThresholds(ctx context.Context, thresholds StockThresholds) error
+}
+
+// StockLedger は在庫移動の履歴と在庫アラートを読み込むリポジトリです。
+// 在庫移動と在庫アラートは在庫数の変更と同じトランザクションで記録されるため、StockRepository と同じ保存先のものを使います。
+type StockLedger interface {
+	// Movements は全てのロケーションの在庫移動を新しい順に取得します。
+	// beforeID が 0 より大きい場合は、その ID より前の移動のみを返します。
+	Movements(ctx context.Context, name string, beforeID int64, limit int) ([]Movement, error)
+	// Alerts は在庫アラートを新しい順に取得します。status は open・acknowledged・all のいずれかで、name が空でなければその在庫のアラートのみを返します。
+	// beforeID が 0 より大きい場合は、その ID より前のアラートのみを返します。
+	Alerts(ctx context.Context, status, name string, beforeID int64, limit int) ([]Alert, error)
+	// AcknowledgeAlert は在庫アラートを確認済みにして返します。確認済みのアラートは変更せずに返します。
+	// アラートが存在しない場合は errAlertNotFound を返します。
+	AcknowledgeAlert(ctx context.Context, id int64, actor string, now time.Time) (Alert, error)
+}
+
+// stockRepositoryFromEnv は環境変数 STOCK_STORE で選んだ保存先の StockRepository を作成します。
+// 未指定の場合は db の SQL データベース、dynamodb の場合は DynamoDB のテーブルを使います。
+// 未知の値や DynamoDB の設定の誤りは、別の保存先に切り替えずにエラーを返します。
+func stockRepositoryFromEnv(db Storer) (StockRepository, error) {
+	switch store := os.Getenv("STOCK_STORE"); store {
+	case "", "sql":
+		return newStockRepository(db), nil
+	case "dynamodb":
+		stocks, err := newDynamoStockRepositoryFromEnv(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure DynamoDB: %w", err)
+		}
+		return stocks, nil
+	default:
+		return nil, fmt.Errorf("unknown STOCK_STORE %q", store)
+	}
+}
+
+// newStockRepository は db のドライバーに合わせた StockRepository を作成します。
+// PostgreSQL または SQLite に接続した SQLDB 以外では MySQL のリポジトリを使います。
+func newStockRepository(db Storer) StockRepository {
+	if sqlDB, ok := db.(*SQLDB); ok && (sqlDB.Driver == driverPostgres || sqlDB.Driver == driverSQLite) {
+		return newPostgresStockRepository(db)
+	}
+	return newMySQLStockRepository(db)
+}
+
+// stockLedgerFor は stocks と同じ保存先の StockLedger を返します。
+// stocks が StockLedger を実装していなければ、db の SQL データベースのテーブルを使います。
+func stockLedgerFor(db Storer, stocks StockRepository) StockLedger {
+	if ledger, ok := stocks.(StockLedger); ok {
+		return ledger
+	}
+	return sqlStockLedger{db: db}
+}
+
+// sqlStockLedger は SQL の stock_movements テーブルと stock_alerts テーブルを使う StockLedger です。
+type sqlStockLedger struct {
+	db Storer
+}
+
+func (l sqlStockLedger) Movements(ctx context.Context, name string, beforeID int64, limit int) ([]Movement, error) {
+	return getMovements(ctx, l.db, name, beforeID, limit)
+}
+
+func (l sqlStockLedger) Alerts(ctx context.Context, status, name string, beforeID int64, limit int) ([]Alert, error) {
+	return getAlerts(ctx, l.db, status, name, beforeID, limit)
+}
+
+func (l sqlStockLedger) AcknowledgeAlert(ctx context.Context, id int64, actor string, now time.Time) (Alert, error) {
+	return acknowledgeAlert(ctx, l.db, id, actor, now)
+}
+
+// mysqlStockRepository は MySQL の stocks テーブルを使う StockRepository です。
+type mysqlStockRepository struct {
+	db Storer
+}
+
+func newMySQLStockRepository(db Storer) *mysqlStockRepository {
+	return &mysqlStockRepository{db: db}
+}
+
+func (r *mysqlStockRepository) Get(ctx context.Context, name string, includeDeleted bool) (Stock, error) {
+	return findStock(ctx, r.db, name, includeDeleted)
+}
+
+func (r *mysqlStockRepository) List(ctx context.Context, opts StockListOptions) ([]Stock, error) {
+	return getAllStocks(ctx, r.db, opts)
+}
+
+func (r *mysqlStockRepository) Each(ctx context.Context, opts StockListOptions, fn func(Stock) error) error {
+	return eachStock(ctx, r.db, opts, fn)
+}
+
+func (r *mysqlStockRepository) Upsert(ctx context.Context, name string, amount int, match *versionMatch, meta MovementMeta) (Stock, error) {
+	return updateStock(ctx, r.db, Stock{Name: name, Amount: amount}, match, meta, addStock)
+}
+
+func (r *mysqlStockRepository) UpsertAll(ctx context.Context, items []Stock, meta MovementMeta) ([]int, error) {
+	return upsertStocks(ctx, r.db, items, func(tx Querier, item Stock, now time.Time) (int, error) {
+		return addStock(ctx, tx, item, meta, now)
+	})
+}
+
+func (r *mysqlStockRepository) Set(ctx context.Context, name string, amount int, createOnly bool, match *versionMatch, meta MovementMeta) (Stock, bool, error) {
+	return setStock(ctx, r.db, name, amount, createOnly, match, meta)
+}
+
+func (r *mysqlStockRepository) Adjust(ctx context.Context, name string, req AdjustmentRequest, match *versionMatch, meta MovementMeta) (AdjustmentResult, int64, error) {
+	return adjustStock(ctx, r.db, name, req, match, meta)
+}
+
+func (r *mysqlStockRepository) Delete(ctx context.Context, name string, match *versionMatch, meta MovementMeta) error {
+	return deleteStock(ctx, r.db, name, match, meta)
+}
+
+func (r *mysqlStockRepository) Restore(ctx context.Context, name string, match *versionMatch, meta MovementMeta) (Stock, error) {
+	return restoreStock(ctx, r.db, name, match, meta)
+}
+
+func (r *mysqlStockRepository) Allocate(ctx context.Context, name string, amount int, match *versionMatch, meta MovementMeta) (Stock, error) {
+	return allocateStock(ctx, r.db, name, amount, match, meta)
+}
+
+func (r *mysqlStockRepository) Thresholds(ctx context.Context, name string) (StockThresholds, error) {
+	return getStockThresholds(ctx, r.db, name)
+}
+
+func (r *mysqlStockRepository) SetThresholds(ctx context.Context, thresholds StockThresholds) error {
+	return setStockThresholds(ctx, r.db, thresholds)
+}
+
+// stockSelectQuery は在庫数（amount）と、有効期限内の引当予約数（reserved）、行のバージョン、更新日時、削除日時を取得するクエリです。
+// 最初のプレースホルダには現在時刻を渡します。
+const stockSelectQuery = "SELECT s.name, s.amount, COALESCE(SUM(r.amount), 0), s.version, s.updated_at, s.deleted_at FROM stocks s " +
+	"LEFT JOIN stock_reservations r ON r.name = s.name AND r.status = 'active' AND r.expires_at > ?"
+
+const stockGroupBy = " GROUP BY s.name, s.amount, s.version, s.updated_at, s.deleted_at"
+
+// stockNotDeleted は論理削除された在庫を除外する条件です。
+const stockNotDeleted = "s.deleted_at IS NULL"
+
+// scanStock は stockSelectQuery の結果を Stock に読み込み、引当可能数を計算します。
+func scanStock(row rowScanner, stock *Stock) error {
+	var updatedAt, deletedAt sql.NullTime
+	if err := row.Scan(&stock.Name, &stock.Amount, &stock.Reserved, &stock.Version, &updatedAt, &deletedAt); err != nil {
+		return err
+	}
+	if updatedAt.Valid {
+		stock.UpdatedAt = &updatedAt.Time
+	}
+	if deletedAt.Valid {
+		stock.DeletedAt = &deletedAt.Time
+	}
+	stock.Available = stock.Amount - stock.Reserved
+	return nil
+}
+
+// updateStock は在庫数を加算し、同じトランザクションで在庫移動を記録します。
+// match が指定された場合は、在庫行のバージョンが一致しなければ errPreconditionFailed を返します。
+// 論理削除された在庫の場合は errStockDeleted を返し、加算をロールバックします。
+// 加算後の在庫は同じトランザクション内で読み込むため、他の更新の影響を受けません。
+// add にはドライバーに合わせた加算処理（addStock など）を渡します。
+func updateStock(ctx context.Context, db Storer, stockReq Stock, match *versionMatch, meta MovementMeta,
+	add func(ctx context.Context, tx Querier, stockReq Stock, meta MovementMeta, now time.Time) (int, error)) (Stock, error) {
+	var stock Stock
+	err := db.WithTx(ctx, func(tx Querier) error {
+		if err := checkVersion(ctx, tx, stockReq.Name, match); err != nil {
+			return err
+		}
+		if _, err := add(ctx, tx, stockReq, meta, time.Now()); err != nil {
+			return err
+		}
+		var err error
+		stock, err = getStock(ctx, tx, stockReq.Name)
+		return err
+	})
+	return stock, err
+}
+
+// upsertStocks は 1 つのトランザクションで items を名前の昇順に add で加算し、加算後の在庫数を items と同じ順に返します。
+// 在庫行を名前の昇順で更新し、同時に処理される一括登録や注文とのデッドロックを防ぎます。
+func upsertStocks(ctx context.Context, db Storer, items []Stock, add func(tx Querier, item Stock, now time.Time) (int, error)) ([]int, error) {
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return items[order[a]].Name < items[order[b]].Name
+	})
+
+	now := time.Now()
+	amounts := make([]int, len(items))
+	err := db.WithTx(ctx, func(tx Querier) error {
+		for _, i := range order {
+			amount, err := add(tx, items[i], now)
+			if err != nil {
+				return &batchItemError{Index: i, Err: err}
+			}
+			amounts[i] = amount
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return amounts, nil
+}
+
+// addStock はトランザクション内で在庫数を加算して在庫移動を記録し、加算後の在庫数を返します。
+// 在庫が存在しない場合は作成します。
+// PostgreSQL と SQLite は ON DUPLICATE KEY UPDATE に対応していないため、ON CONFLICT で加算します。
+func addStock(ctx context.Context, tx Querier, stockReq Stock, meta MovementMeta, now time.Time) (int, error) {
+	query := "INSERT INTO stocks (name, amount) VALUES (?, ?) ON DUPLICATE KEY UPDATE amount = amount + ?, version = version + 1"
+	if queryDriver(tx) != driverMySQL {
+		query = "INSERT INTO stocks (name, amount) VALUES (?, ?) ON CONFLICT (name) DO UPDATE SET amount = stocks.amount + ?, version = stocks.version + 1"
+	}
+	_, err := tx.ExecContext(ctx, query, stockReq.Name, stockReq.Amount, stockReq.Amount)
+	if err != nil {
+		return 0, err
+	}
+	amount, err := currentAmount(ctx, tx, stockReq.Name)
+	if err != nil {
+		return 0, err
+	}
+	return amount, recordMovement(ctx, tx, stockReq.Name, stockReq.Amount, amount, meta, now)
+}
+
+// setStock は在庫数を指定した値に置き換え、差分を在庫移動として記録します。
+// 在庫数がしきい値を下回った場合は、同じトランザクションで在庫アラートを記録します。
+// 在庫が存在しない場合は作成し、created に true を返します。
+// createOnly が true で在庫が既に存在する場合は errStockExists を返します。
+// 置き換え後の在庫は同じトランザクション内で読み込むため、他の更新の影響を受けません。
+func setStock(ctx context.Context, db Storer, name string, amount int, createOnly bool, match *versionMatch, meta MovementMeta) (Stock, bool, error) {
+	var (
+		stock   Stock
+		created bool
+	)
+	err := db.WithTx(ctx, func(tx Querier) error {
+		if err := checkVersion(ctx, tx, name, match); err != nil {
+			return err
+		}
+
+		var (
+			previous int
+			deleted  bool
+		)
+		err := tx.QueryRowContext(ctx, "SELECT amount, deleted_at IS NOT NULL FROM stocks WHERE name = ? FOR UPDATE", name).Scan(&previous, &deleted)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			if _, err := tx.ExecContext(ctx, "INSERT INTO stocks (name, amount) VALUES (?, ?)", name, amount); err != nil {
+				if isDuplicateKey(err) {
+					// 同時に作成された場合
+					return errStockExists
+				}
+				return err
+			}
+			created = true
+		case err != nil:
+			return err
+		case deleted:
+			return errStockDeleted
+		case createOnly:
+			return errStockExists
+		default:
+			if _, err := tx.ExecContext(ctx, "UPDATE stocks SET amount = ?, version = version + 1 WHERE name = ?", amount, name); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		if err := recordMovement(ctx, tx, name, amount-previous, amount, meta, now); err != nil {
+			return err
+		}
+		if err := checkLowStock(ctx, tx, name, previous, amount, now); err != nil {
+			return err
+		}
+		stock, err = getStock(ctx, tx, name)
+		return err
+	})
+	if err != nil {
+		return Stock{}, false, err
+	}
+	return stock, created, nil
+}
+
+// deleteStock は在庫を論理削除し、在庫移動として記録します。
+// 有効な引当予約が残っている在庫は削除できません。
+func deleteStock(ctx context.Context, db Storer, name string, match *versionMatch, meta MovementMeta) error {
+	now := time.Now()
+	return db.WithTx(ctx, func(tx Querier) error {
+		if err := checkVersion(ctx, tx, name, match); err != nil {
+			return err
+		}
+		stock, err := lockStock(ctx, tx, name, now)
+		if err != nil {
+			return err
+		}
+		if stock.Reserved > 0 {
+			return errStockReserved
+		}
+
+		if _, err := tx.ExecContext(ctx, "UPDATE stocks SET deleted_at = ?, version = version + 1 WHERE name = ?", now, name); err != nil {
+			return err
+		}
+		return recordMovement(ctx, tx, name, 0, stock.Amount, meta, now)
+	})
+}
+
+// restoreStock は論理削除された在庫を元に戻し、在庫移動として記録します。
+// 削除されていない在庫に対しては何もせず、現在の在庫を返します。
+// 復元後の在庫は同じトランザクション内で読み込むため、他の更新の影響を受けません。
+func restoreStock(ctx context.Context, db Storer, name string, match *versionMatch, meta MovementMeta) (Stock, error) {
+	var stock Stock
+	err := db.WithTx(ctx, func(tx Querier) error {
+		if err := checkVersion(ctx, tx, name, match); err != nil {
+			return err
+		}
+
+		var (
+			amount  int
+			deleted bool
+		)
+		err := tx.QueryRowContext(ctx, "SELECT amount, deleted_at IS NOT NULL FROM stocks WHERE name = ? FOR UPDATE", name).Scan(&amount, &deleted)
+		if errors.Is(err, sql.ErrNoRows) {
+			return errStockNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if deleted {
+			if _, err := tx.ExecContext(ctx, "UPDATE stocks SET deleted_at = NULL, version = version + 1 WHERE name = ?", name); err != nil {
+				return err
+			}
+			if err := recordMovement(ctx, tx, name, 0, amount, meta, time.Now()); err != nil {
+				return err
+			}
+		}
+		stock, err = getStock(ctx, tx, name)
+		return err
+	})
+	return stock, err
+}
+
+// findStock は名前を指定して在庫と紐付いた商品を取得します。在庫が存在しない場合は errStockNotFound を返します。
+// includeDeleted が false の場合、論理削除された在庫は存在しないものとして扱います。
+func findStock(ctx context.Context, db Querier, name string, includeDeleted bool) (Stock, error) {
+	query := stockSelectQuery + " WHERE s.name = ?"
+	if !includeDeleted {
+		query += " AND " + stockNotDeleted
+	}
+	var stock Stock
+	err := scanStock(db.QueryRowContext(ctx, query+stockGroupBy, time.Now(), name), &stock)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Stock{}, errStockNotFound
+	}
+	if err != nil {
+		return Stock{}, err
+	}
+	stock.Product, err = getStockProduct(ctx, db, name)
+	return stock, err
+}
+
+// getStock は名前を指定して論理削除されていない在庫を 1 件取得します。
+func getStock(ctx context.Context, db Querier, name string) (Stock, error) {
+	var stock Stock
+	err := scanStock(db.QueryRowContext(ctx, stockSelectQuery+" WHERE s.name = ? AND "+stockNotDeleted+stockGroupBy, time.Now(), name), &stock)
+	return stock, err
+}
+
+// getAllStocks は opts の条件に一致する在庫を取得します。
+// 論理削除された在庫は opts.IncludeDeleted が true の場合のみ返します。
+func getAllStocks(ctx context.Context, db Storer, opts StockListOptions) ([]Stock, error) {
+	var stocks []Stock
+	err := eachStock(ctx, db, opts, func(stock Stock) error {
+		stocks = append(stocks, stock)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stocks, nil
+}
+
+// stockStreamer は在庫を 1 件ずつ読み込みながら処理できるリポジトリです。
+type stockStreamer interface {
+	Each(ctx context.Context, opts StockListOptions, fn func(Stock) error) error
+}
+
+// eachStockIn は stocks から opts の条件に一致する在庫を 1 件ずつ読み込み、fn を呼び出します。
+// stocks が stockStreamer を実装していなければ、maxStockPageLimit 件ずつ List でページを読み進めます。
+func eachStockIn(ctx context.Context, stocks StockRepository, opts StockListOptions, fn func(Stock) error) error {
+	if streamer, ok := stocks.(stockStreamer); ok {
+		return streamer.Each(ctx, opts, fn)
+	}
+	opts.Limit = maxStockPageLimit
+	for {
+		page, err := stocks.List(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for _, stock := range page {
+			if err := fn(stock); err != nil {
+				return err
+			}
+		}
+		if len(page) < opts.Limit {
+			return nil
+		}
+		cursor, err := decodeStockCursor(nextStockCursor(stocks, opts, page), opts.Sort)
+		if err != nil {
+			return err
+		}
+		opts.After = &cursor
+	}
+}
+
+// eachStock は opts の条件に一致する在庫を 1 件ずつ読み込み、fn を呼び出します。
+// 全件をメモリに載せずに処理できるため、CSV エクスポートのような大量の出力に使います。
+// 条件の値はすべてプレースホルダで渡します。
+func eachStock(ctx context.Context, db Storer, opts StockListOptions, fn func(Stock) error) error {
+	conds, condArgs := opts.where()
+	args := append([]interface{}{time.Now()}, condArgs...)
+
+	query := stockSelectQuery
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	query += stockGroupBy + opts.orderBy()
+	if opts.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, opts.Limit)
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var stock Stock
+		if err := scanStock(rows, &stock); err != nil {
+			return err
+		}
+		if err := fn(stock); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// adjustStock は在庫数に符号付きの増減を適用し、同じトランザクションで在庫移動を記録します。
+// AllowNegative が false の場合、調整後の在庫数が負になると errInsufficientStock を返します。
+// 戻り値の version は調整後の在庫行のバージョンです。
+func adjustStock(ctx context.Context, db Storer, name string, req AdjustmentRequest, match *versionMatch, meta MovementMeta) (AdjustmentResult, int64, error) {
+	now := time.Now()
+	result := AdjustmentResult{Name: name, Delta: req.Delta, Reason: req.Reason}
+	var version int64
+	err := db.WithTx(ctx, func(tx Querier) error {
+		if err := checkVersion(ctx, tx, name, match); err != nil {
+			return err
+		}
+		stock, err := lockStock(ctx, tx, name, now)
+		if err != nil {
+			return err
+		}
+
+		result.PreviousAmount = stock.Amount
+		result.Amount = stock.Amount + req.Delta
+		if result.Amount < 0 && !req.AllowNegative {
+			return errInsufficientStock
+		}
+
+		if _, err := tx.ExecContext(ctx, "UPDATE stocks SET amount = ?, version = version + 1 WHERE name = ?", result.Amount, name); err != nil {
+			return err
+		}
+		version = stock.Version + 1
+		if err := recordMovement(ctx, tx, name, req.Delta, result.Amount, meta, now); err != nil {
+			return err
+		}
+		return checkLowStock(ctx, tx, name, result.PreviousAmount, result.Amount, now)
+	})
+	return result, version, err
+}
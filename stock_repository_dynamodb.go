@@ -0,0 +1,962 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	defaultDynamoStockTable = "stocks"
+	// defaultDynamoLedgerTable は在庫移動・outbox のイベント・在庫アラート・Idempotency-Key を保存するテーブルの既定の名前です。
+	defaultDynamoLedgerTable = "stock_ledger"
+	// dynamoStockShards は一覧用のグローバルセカンダリインデックスのパーティションキー（gsi_pk）を分ける数です。
+	// すべての在庫を 1 つのパーティションに書き込むと、インデックスへの書き込みがそのパーティションの上限で頭打ちになるため、
+	// 名前のハッシュで gsi_pk を分散させ、一覧では全てのパーティションを Query して結果を並べ直します。
+	dynamoStockShards = 8
+	// dynamoTimeLayout は日時を文字列として保存する形式です。
+	// UTC で桁数を固定し、文字列の比較が時刻の比較と一致するようにします。
+	dynamoTimeLayout = "2006-01-02T15:04:05.000000000Z"
+	// dynamoMaxTransactItems は 1 回のトランザクションで読み書きできる項目数の上限です。
+	dynamoMaxTransactItems = 100
+	// dynamoTransactRetries は同時に更新されてトランザクションが取り消された場合に、読み込みからやり直す回数です。
+	// 同じ在庫への同時の書き込みは 1 回に 1 つしか成功しないため、同時に書き込まれうる数を目安にします。
+	dynamoTransactRetries = 10
+	// dynamoRetryDelay はやり直す前に待つ時間の上限の単位で、やり直すたびに上限を長くします。
+	dynamoRetryDelay = 10 * time.Millisecond
+)
+
+// dynamoStockIndex は一覧の並べ替えに使うグローバルセカンダリインデックスです。
+type dynamoStockIndex struct {
+	Name    string
+	SortKey string
+}
+
+// dynamoStockIndexes は sort パラメータのキーと、並べ替えに使うインデックスの対応です。
+// どのインデックスもパーティションキーは gsi_pk で、すべての属性を射影します。
+var dynamoStockIndexes = map[string]dynamoStockIndex{
+	"name":       {Name: "name-index", SortKey: "name"},
+	"amount":     {Name: "amount-index", SortKey: "amount"},
+	"updated_at": {Name: "updated_at-index", SortKey: "updated_at"},
+}
+
+// dynamoAttributeNames は式で使う属性名のプレースホルダです。
+// name や value は DynamoDB の予約語のため、すべての属性をプレースホルダで参照します。
+var dynamoAttributeNames = map[string]string{
+	"#name":       "name",
+	"#amount":     "amount",
+	"#version":    "version",
+	"#updated_at": "updated_at",
+	"#deleted_at": "deleted_at",
+	"#gsi_pk":     "gsi_pk",
+
+	"#reorder_point": "reorder_point",
+	"#safety_stock":  "safety_stock",
+
+	// 台帳のテーブルの属性
+	"#pk":              "pk",
+	"#sk":              "sk",
+	"#value":           "value",
+	"#attempts":        "attempts",
+	"#last_error":      "last_error",
+	"#acknowledged_at": "acknowledged_at",
+	"#acknowledged_by": "acknowledged_by",
+	"#status_code":     "status_code",
+	"#headers":         "headers",
+	"#body":            "body",
+	"#expires_at":      "expires_at",
+	"#ttl":             "ttl",
+}
+
+// errDynamoConditionFailed は同時に更新され続けて、読み込んだ在庫を条件どおりに書き込めなかったことを表します。
+var errDynamoConditionFailed = errors.New("conditional check failed")
+
+// dynamoDBAPI は dynamoStockRepository が使う DynamoDB の操作です。
+// *dynamodb.Client のほか、テストではプロセス内のフェイクを渡します。
+type dynamoDBAPI interface {
+	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
+	DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	TransactGetItems(ctx context.Context, params *dynamodb.TransactGetItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactGetItemsOutput, error)
+	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
+}
+
+// dynamoStockRepository は DynamoDB のテーブルを使う StockRepository です。
+// 在庫の項目を強い整合性で読み込み、読み込んだときのバージョンを条件に在庫数を更新する UpdateItem と、
+// 在庫移動・outbox のイベント・在庫アラートの Put を 1 回の TransactWriteItems で書き込みます。
+// 同時に更新されて条件を満たさなかった場合は読み込みからやり直すため、複数の Lambda から同時に更新しても
+// 在庫数が負になったり、在庫数の変更と在庫移動の記録が食い違ったりすることはありません。
+// 在庫移動などは台帳のテーブル（ledgerTable）に保存し、StockLedger・Outbox・idempotencyStore としても使います。
+// 引当予約と商品の紐付けは SQL のテーブルにあるため扱いません（Reserved は常に 0 です）。
+type dynamoStockRepository struct {
+	client      dynamoDBAPI
+	table       string
+	ledgerTable string
+}
+
+func newDynamoStockRepository(client dynamoDBAPI, table, ledgerTable string) *dynamoStockRepository {
+	return &dynamoStockRepository{client: client, table: table, ledgerTable: ledgerTable}
+}
+
+// newDynamoStockRepositoryFromEnv は AWS の既定の設定（環境変数や Lambda の実行ロール）で DynamoDB に接続するリポジトリを作成します。
+// テーブル名は DYNAMODB_TABLE と DYNAMODB_LEDGER_TABLE、接続先は DYNAMODB_ENDPOINT（DynamoDB Local の URL など）で変更できます。
+func newDynamoStockRepositoryFromEnv(ctx context.Context) (*dynamoStockRepository, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// リージョンがないと最初のリクエストまで失敗に気づかないため、作成時に確認する
+	if cfg.Region == "" {
+		return nil, errors.New("AWS_REGION is not set")
+	}
+	client := dynamodb.NewFromConfig(cfg, func(o *dynamodb.Options) {
+		if endpoint := os.Getenv("DYNAMODB_ENDPOINT"); endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+	})
+	return newDynamoStockRepository(client, getEnv("DYNAMODB_TABLE", defaultDynamoStockTable), getEnv("DYNAMODB_LEDGER_TABLE", defaultDynamoLedgerTable)), nil
+}
+
+func (r *dynamoStockRepository) Get(ctx context.Context, name string, includeDeleted bool) (Stock, error) {
+	out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(r.table),
+		Key:            dynamoStockKey(name),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return Stock{}, err
+	}
+	if out.Item == nil {
+		return Stock{}, errStockNotFound
+	}
+	stock, err := stockFromItem(out.Item)
+	if err != nil {
+		return Stock{}, err
+	}
+	if stock.DeletedAt != nil && !includeDeleted {
+		return Stock{}, errStockNotFound
+	}
+	return stock, nil
+}
+
+// List は gsi_pk をパーティションキーとするインデックスをパーティションごとに Query し、
+// 各パーティションの結果を opts.Sort の順にマージして在庫を取得します。
+// 名前の前方一致はキー条件、その他の条件はフィルター式で絞り込みます。
+// 並べ替えキーが同じ在庫は、インデックスの順序に合わせて名前の順に並べます。
+// opts.After はパーティションごとの位置（NextCursor で作成したカーソル）が必要で、位置のないカーソルは errInvalidCursor を返します。
+func (r *dynamoStockRepository) List(ctx context.Context, opts StockListOptions) ([]Stock, error) {
+	sort := opts.Sort
+	if sort == "" {
+		sort = defaultStockSort
+	}
+	sortKey := strings.TrimPrefix(sort, "-")
+	index, ok := dynamoStockIndexes[sortKey]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sort: %s", sort)
+	}
+	if opts.After != nil && len(opts.After.Shards) != dynamoStockShards {
+		return nil, errInvalidCursor
+	}
+
+	keyCondition := "#gsi_pk = :gsi_pk"
+	values := make(map[string]types.AttributeValue)
+	var filters []string
+	if opts.Prefix != "" {
+		if sortKey == "name" {
+			keyCondition += " AND begins_with(#name, :prefix)"
+		} else {
+			filters = append(filters, "begins_with(#name, :prefix)")
+		}
+		values[":prefix"] = &types.AttributeValueMemberS{Value: opts.Prefix}
+	}
+	if !opts.IncludeDeleted {
+		filters = append(filters, "attribute_not_exists(#deleted_at)")
+	}
+	if opts.Contains != "" {
+		filters = append(filters, "contains(#name, :contains)")
+		values[":contains"] = &types.AttributeValueMemberS{Value: opts.Contains}
+	}
+	if opts.MinAmount != nil {
+		filters = append(filters, "#amount >= :min_amount")
+		values[":min_amount"] = dynamoNumber(int64(*opts.MinAmount))
+	}
+	if opts.MaxAmount != nil {
+		filters = append(filters, "#amount <= :max_amount")
+		values[":max_amount"] = dynamoNumber(int64(*opts.MaxAmount))
+	}
+	if opts.UpdatedSince != nil {
+		filters = append(filters, "#updated_at >= :updated_since")
+		values[":updated_since"] = dynamoTime(*opts.UpdatedSince)
+	}
+
+	input := dynamodb.QueryInput{
+		TableName:              aws.String(r.table),
+		IndexName:              aws.String(index.Name),
+		KeyConditionExpression: aws.String(keyCondition),
+		ScanIndexForward:       aws.Bool(!strings.HasPrefix(sort, "-")),
+	}
+	expressions := []string{keyCondition}
+	if len(filters) > 0 {
+		filter := strings.Join(filters, " AND ")
+		input.FilterExpression = aws.String(filter)
+		expressions = append(expressions, filter)
+	}
+	input.ExpressionAttributeNames = dynamoExpressionNames(expressions...)
+
+	// パーティションごとに、カーソルに記録した最後に返した在庫の位置から読み込む
+	readers := make([]*dynamoShardReader, dynamoStockShards)
+	for shard := range readers {
+		reader := &dynamoShardReader{input: input}
+		reader.input.ExpressionAttributeValues = maps.Clone(values)
+		reader.input.ExpressionAttributeValues[":gsi_pk"] = dynamoStockShardPartition(shard)
+		if opts.After != nil {
+			if position, ok := opts.After.shard(shard); ok {
+				startKey, err := dynamoCursorKey(position, index, dynamoStockShardPartition(shard))
+				if err != nil {
+					return nil, err
+				}
+				reader.input.ExclusiveStartKey = startKey
+			}
+		}
+		readers[shard] = reader
+	}
+
+	// 最初は全てのパーティションを並行して読み込み、その後は読み込んだ在庫を使い切ったパーティションだけを読み進める
+	errs := make([]error, dynamoStockShards)
+	var wg sync.WaitGroup
+	for shard, reader := range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[shard] = reader.fill(ctx, r.client, dynamoShardPageSize(opts.Limit))
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	forward := aws.ToBool(input.ScanIndexForward)
+	var stocks []Stock
+	for opts.Limit == 0 || len(stocks) < opts.Limit {
+		var next *dynamoShardReader
+		for _, reader := range readers {
+			if err := reader.fill(ctx, r.client, dynamoShardPageSize(opts.Limit-len(stocks))); err != nil {
+				return nil, err
+			}
+			if len(reader.stocks) == 0 {
+				continue
+			}
+			if next == nil {
+				next = reader
+				continue
+			}
+			c := compareDynamoStocks(reader.stocks[0], next.stocks[0], index.SortKey)
+			if (forward && c < 0) || (!forward && c > 0) {
+				next = reader
+			}
+		}
+		if next == nil {
+			break
+		}
+		stocks = append(stocks, next.stocks[0])
+		next.stocks = next.stocks[1:]
+	}
+	return stocks, nil
+}
+
+// NextCursor は opts.After のパーティションごとの位置を、page の在庫のうちパーティションごとに最後の在庫の位置で置き換えたカーソルを作成します。
+// 位置はどれも実際に読み込んだ項目のキーのため、次のページはパーティションごとにその項目の次から読み込みます。
+func (r *dynamoStockRepository) NextCursor(opts StockListOptions, page []Stock) string {
+	sort := opts.Sort
+	if sort == "" {
+		sort = defaultStockSort
+	}
+	cursor := newStockCursor(page[len(page)-1], sort)
+	cursor.Shards = make([]*stockShardPosition, dynamoStockShards)
+	if opts.After != nil {
+		copy(cursor.Shards, opts.After.Shards)
+	}
+	for _, stock := range page {
+		position := newStockCursor(stock, sort)
+		cursor.Shards[dynamoStockShard(stock.Name)] = &stockShardPosition{Value: position.Value, Null: position.Null, Name: position.Name}
+	}
+	return cursor.encode()
+}
+
+// dynamoShardReader は 1 つのパーティションの Query の結果を、並べ替えの順に少しずつ読み込みます。
+type dynamoShardReader struct {
+	input  dynamodb.QueryInput
+	stocks []Stock
+	done   bool
+}
+
+// fill は読み込んだ在庫を使い切っていれば、在庫を 1 件以上読み込むかパーティションの最後まで次のページを Query します。
+// フィルター式は Limit 件を読み込んだ後に適用されるため、1 回の Query で在庫を読み込めないことがあります。
+// limit が 0 の場合は 1 回の Query で読み込む件数を制限しません。
+func (s *dynamoShardReader) fill(ctx context.Context, client dynamoDBAPI, limit int) error {
+	for len(s.stocks) == 0 && !s.done {
+		if limit > 0 {
+			s.input.Limit = aws.Int32(int32(limit))
+		}
+		out, err := client.Query(ctx, &s.input)
+		if err != nil {
+			return err
+		}
+		for _, item := range out.Items {
+			stock, err := stockFromItem(item)
+			if err != nil {
+				return err
+			}
+			s.stocks = append(s.stocks, stock)
+		}
+		s.input.ExclusiveStartKey = out.LastEvaluatedKey
+		s.done = len(out.LastEvaluatedKey) == 0
+	}
+	return nil
+}
+
+// dynamoShardPageSize は残り remaining 件の在庫を読み込む際に、1 つのパーティションを 1 回に Query する件数です。
+// 在庫は名前のハッシュでパーティションに分かれるため、各パーティションから均等に読み込む件数に 1 件の余裕を持たせます。
+func dynamoShardPageSize(remaining int) int {
+	if remaining <= 0 {
+		return 0
+	}
+	return remaining/dynamoStockShards + 1
+}
+
+// compareDynamoStocks はインデックスと同じく、sortKey の値、同じ値の場合は名前の順に在庫を比較します。
+func compareDynamoStocks(a, b Stock, sortKey string) int {
+	var c int
+	switch sortKey {
+	case "amount":
+		c = cmp.Compare(a.Amount, b.Amount)
+	case "updated_at":
+		c = compareTimes(a.UpdatedAt, b.UpdatedAt)
+	}
+	if c != 0 {
+		return c
+	}
+	return strings.Compare(a.Name, b.Name)
+}
+
+// Upsert は在庫数を if_not_exists で 0 から加算するため、在庫が存在しない場合は作成します。
+func (r *dynamoStockRepository) Upsert(ctx context.Context, name string, amount int, match *versionMatch, meta MovementMeta) (Stock, error) {
+	now := time.Now()
+	var stock Stock
+	err := r.transact(ctx, []string{name}, meta, now, func(current map[string]map[string]types.AttributeValue) ([]types.TransactWriteItem, error) {
+		item := current[name]
+		if err := checkItemVersion(item, match); err != nil {
+			return nil, err
+		}
+		previous, err := stockFromItem(item)
+		if err != nil {
+			return nil, err
+		}
+		if previous.DeletedAt != nil {
+			return nil, errStockDeleted
+		}
+
+		stock = Stock{Name: name, Amount: previous.Amount + amount, Available: previous.Amount + amount, Version: previous.Version + 1, UpdatedAt: &now}
+		return r.stockChange(name, item,
+			"SET #amount = if_not_exists(#amount, :zero) + :amount, #version = if_not_exists(#version, :zero) + :one, #updated_at = :now, #gsi_pk = :gsi_pk",
+			map[string]types.AttributeValue{
+				":zero":   dynamoNumber(0),
+				":amount": dynamoNumber(int64(amount)),
+				":one":    dynamoNumber(1),
+				":now":    dynamoTime(now),
+				":gsi_pk": dynamoStockPartition(name),
+			}, stock.Version, amount, stock.Amount, meta, now)
+	})
+	if err != nil {
+		return Stock{}, err
+	}
+	return stock, nil
+}
+
+// UpsertAll は名前ごとに加算後の在庫数を求め、TransactWriteItems で全ての在庫と在庫移動を一度に書き込みます。
+// 在庫の項目ごとの更新に加えて、加算ごとに在庫移動と outbox のイベントを書き込むため、
+// 1 回のトランザクションで書き込める項目数を超える場合は errBatchTooLarge を返します。
+func (r *dynamoStockRepository) UpsertAll(ctx context.Context, items []Stock, meta MovementMeta) ([]int, error) {
+	var names []string
+	first := make(map[string]int)
+	for i, item := range items {
+		if _, ok := first[item.Name]; !ok {
+			first[item.Name] = i
+			names = append(names, item.Name)
+		}
+	}
+	writes := len(names) + 2*len(items)
+	if meta.idempotencyKey != "" {
+		writes++
+	}
+	if writes > dynamoMaxTransactItems {
+		return nil, fmt.Errorf("%w: DynamoDB writes at most %d items in one transaction", errBatchTooLarge, dynamoMaxTransactItems)
+	}
+
+	now := time.Now()
+	amounts := make([]int, len(items))
+	err := r.transact(ctx, names, meta, now, func(current map[string]map[string]types.AttributeValue) ([]types.TransactWriteItem, error) {
+		after := make(map[string]int)
+		versions := make(map[string]int64)
+		for _, name := range names {
+			previous, err := stockFromItem(current[name])
+			if err != nil {
+				return nil, err
+			}
+			if previous.DeletedAt != nil {
+				return nil, &batchItemError{Index: first[name], Err: errStockDeleted}
+			}
+			after[name] = previous.Amount
+			versions[name] = previous.Version
+		}
+
+		// 在庫移動の ID は加算ごとのバージョンのため、同じ在庫を複数回加算した場合はその回数だけバージョンを進める
+		var ledger []types.TransactWriteItem
+		for i, item := range items {
+			after[item.Name] += item.Amount
+			versions[item.Name]++
+			amounts[i] = after[item.Name]
+			movement, err := r.movementItems(item.Name, versions[item.Name], item.Amount, amounts[i], meta, now)
+			if err != nil {
+				return nil, err
+			}
+			ledger = append(ledger, movement...)
+		}
+
+		writes := make([]types.TransactWriteItem, 0, len(names)+len(ledger))
+		for _, name := range names {
+			writes = append(writes, r.updateStock(name, current[name],
+				"SET #amount = :amount, #version = :version, #updated_at = :now, #gsi_pk = :gsi_pk",
+				map[string]types.AttributeValue{
+					":amount":  dynamoNumber(int64(after[name])),
+					":version": dynamoNumber(versions[name]),
+					":now":     dynamoTime(now),
+					":gsi_pk":  dynamoStockPartition(name),
+				}))
+		}
+		return append(writes, ledger...), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return amounts, nil
+}
+
+// Set は在庫数を置き換えた結果がしきい値を下回った場合、同じトランザクションで在庫アラートを書き込みます。
+func (r *dynamoStockRepository) Set(ctx context.Context, name string, amount int, createOnly bool, match *versionMatch, meta MovementMeta) (Stock, bool, error) {
+	now := time.Now()
+	var (
+		stock   Stock
+		created bool
+	)
+	err := r.transact(ctx, []string{name}, meta, now, func(current map[string]map[string]types.AttributeValue) ([]types.TransactWriteItem, error) {
+		item := current[name]
+		if err := checkItemVersion(item, match); err != nil {
+			return nil, err
+		}
+		previous, err := stockFromItem(item)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case previous.DeletedAt != nil:
+			return nil, errStockDeleted
+		case createOnly && item != nil:
+			return nil, errStockExists
+		}
+
+		stock = Stock{Name: name, Amount: amount, Available: amount, Version: previous.Version + 1, UpdatedAt: &now}
+		created = item == nil
+		writes, err := r.stockChange(name, item,
+			"SET #amount = :amount, #version = if_not_exists(#version, :zero) + :one, #updated_at = :now, #gsi_pk = :gsi_pk",
+			map[string]types.AttributeValue{
+				":amount": dynamoNumber(int64(amount)),
+				":zero":   dynamoNumber(0),
+				":one":    dynamoNumber(1),
+				":now":    dynamoTime(now),
+				":gsi_pk": dynamoStockPartition(name),
+			}, stock.Version, amount-previous.Amount, amount, meta, now)
+		if err != nil {
+			return nil, err
+		}
+		return r.appendAlertItems(ctx, writes, item, name, previous.Amount, amount, now)
+	})
+	if err != nil {
+		return Stock{}, false, err
+	}
+	return stock, created, nil
+}
+
+// Adjust は AllowNegative が false の場合、読み込んだ在庫数に増減を適用して 0 以上になることを確認してから更新します。
+func (r *dynamoStockRepository) Adjust(ctx context.Context, name string, req AdjustmentRequest, match *versionMatch, meta MovementMeta) (AdjustmentResult, int64, error) {
+	now := time.Now()
+	result := AdjustmentResult{Name: name, Delta: req.Delta, Reason: req.Reason}
+	var version int64
+	err := r.transact(ctx, []string{name}, meta, now, func(current map[string]map[string]types.AttributeValue) ([]types.TransactWriteItem, error) {
+		item := current[name]
+		if err := checkItemVersion(item, match); err != nil {
+			return nil, err
+		}
+		previous, err := stockFromItem(item)
+		if err != nil {
+			return nil, err
+		}
+		if item == nil || previous.DeletedAt != nil {
+			return nil, fmt.Errorf("%w: %s", errStockNotFound, name)
+		}
+		result.PreviousAmount = previous.Amount
+		result.Amount = previous.Amount + req.Delta
+		if !req.AllowNegative && result.Amount < 0 {
+			return nil, errInsufficientStock
+		}
+
+		version = previous.Version + 1
+		writes, err := r.stockChange(name, item, "SET #amount = #amount + :delta, #version = #version + :one, #updated_at = :now",
+			map[string]types.AttributeValue{
+				":delta": dynamoNumber(int64(req.Delta)),
+				":one":   dynamoNumber(1),
+				":now":   dynamoTime(now),
+			}, version, req.Delta, result.Amount, meta, now)
+		if err != nil {
+			return nil, err
+		}
+		return r.appendAlertItems(ctx, writes, item, name, result.PreviousAmount, result.Amount, now)
+	})
+	if err != nil {
+		return result, 0, err
+	}
+	return result, version, nil
+}
+
+func (r *dynamoStockRepository) Delete(ctx context.Context, name string, match *versionMatch, meta MovementMeta) error {
+	now := time.Now()
+	return r.transact(ctx, []string{name}, meta, now, func(current map[string]map[string]types.AttributeValue) ([]types.TransactWriteItem, error) {
+		item := current[name]
+		if err := checkItemVersion(item, match); err != nil {
+			return nil, err
+		}
+		previous, err := stockFromItem(item)
+		if err != nil {
+			return nil, err
+		}
+		if item == nil || previous.DeletedAt != nil {
+			return nil, fmt.Errorf("%w: %s", errStockNotFound, name)
+		}
+		return r.stockChange(name, item, "SET #deleted_at = :now, #version = #version + :one, #updated_at = :now",
+			map[string]types.AttributeValue{
+				":one": dynamoNumber(1),
+				":now": dynamoTime(now),
+			}, previous.Version+1, 0, previous.Amount, meta, now)
+	})
+}
+
+// Restore は削除されていない在庫に対しては何も書き込まずに、現在の在庫を返します。
+func (r *dynamoStockRepository) Restore(ctx context.Context, name string, match *versionMatch, meta MovementMeta) (Stock, error) {
+	now := time.Now()
+	var stock Stock
+	err := r.transact(ctx, []string{name}, meta, now, func(current map[string]map[string]types.AttributeValue) ([]types.TransactWriteItem, error) {
+		item := current[name]
+		if err := checkItemVersion(item, match); err != nil {
+			return nil, err
+		}
+		previous, err := stockFromItem(item)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case item == nil:
+			return nil, errStockNotFound
+		case previous.DeletedAt == nil:
+			stock = previous
+			return nil, nil
+		}
+
+		stock = Stock{Name: name, Amount: previous.Amount, Available: previous.Amount, Version: previous.Version + 1, UpdatedAt: &now}
+		return r.stockChange(name, item, "SET #version = #version + :one, #updated_at = :now REMOVE #deleted_at",
+			map[string]types.AttributeValue{
+				":one": dynamoNumber(1),
+				":now": dynamoTime(now),
+			}, stock.Version, 0, stock.Amount, meta, now)
+	})
+	if err != nil {
+		return Stock{}, err
+	}
+	return stock, nil
+}
+
+// Allocate は読み込んだ在庫数が amount 以上であることを確認してから減算します。
+func (r *dynamoStockRepository) Allocate(ctx context.Context, name string, amount int, match *versionMatch, meta MovementMeta) (Stock, error) {
+	now := time.Now()
+	var stock Stock
+	err := r.transact(ctx, []string{name}, meta, now, func(current map[string]map[string]types.AttributeValue) ([]types.TransactWriteItem, error) {
+		item := current[name]
+		if err := checkItemVersion(item, match); err != nil {
+			return nil, err
+		}
+		previous, err := stockFromItem(item)
+		if err != nil {
+			return nil, err
+		}
+		if item == nil || previous.DeletedAt != nil {
+			return nil, fmt.Errorf("%w: %s", errStockNotFound, name)
+		}
+		if previous.Amount < amount {
+			stock = previous
+			return nil, errInsufficientStock
+		}
+
+		stock = Stock{Name: name, Amount: previous.Amount - amount, Available: previous.Amount - amount, Version: previous.Version + 1, UpdatedAt: &now}
+		writes, err := r.stockChange(name, item, "SET #amount = #amount - :amount, #version = #version + :one, #updated_at = :now",
+			map[string]types.AttributeValue{
+				":amount": dynamoNumber(int64(amount)),
+				":one":    dynamoNumber(1),
+				":now":    dynamoTime(now),
+			}, stock.Version, -amount, stock.Amount, meta, now)
+		if err != nil {
+			return nil, err
+		}
+		return r.appendAlertItems(ctx, writes, item, name, previous.Amount, stock.Amount, now)
+	})
+	if errors.Is(err, errInsufficientStock) {
+		return stock, err
+	}
+	if err != nil {
+		return Stock{}, err
+	}
+	return stock, nil
+}
+
+func (r *dynamoStockRepository) Thresholds(ctx context.Context, name string) (StockThresholds, error) {
+	out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(r.table),
+		Key:            dynamoStockKey(name),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return StockThresholds{}, err
+	}
+	if _, deleted := out.Item["deleted_at"]; out.Item == nil || deleted {
+		return StockThresholds{}, errStockNotFound
+	}
+	return thresholdsFromItem(name, out.Item)
+}
+
+// SetThresholds はしきい値を項目の属性として保存し、nil のしきい値は属性を削除します。
+// 在庫数は変わらないため、バージョンと更新日時は変えません。
+func (r *dynamoStockRepository) SetThresholds(ctx context.Context, thresholds StockThresholds) error {
+	var set, remove []string
+	values := make(map[string]types.AttributeValue)
+	for _, threshold := range []struct {
+		attribute string
+		value     *int
+	}{
+		{"reorder_point", thresholds.ReorderPoint},
+		{"safety_stock", thresholds.SafetyStock},
+	} {
+		if threshold.value == nil {
+			remove = append(remove, "#"+threshold.attribute)
+			continue
+		}
+		set = append(set, "#"+threshold.attribute+" = :"+threshold.attribute)
+		values[":"+threshold.attribute] = dynamoNumber(int64(*threshold.value))
+	}
+	var update []string
+	if len(set) > 0 {
+		update = append(update, "SET "+strings.Join(set, ", "))
+	}
+	if len(remove) > 0 {
+		update = append(update, "REMOVE "+strings.Join(remove, ", "))
+	}
+	// DynamoDB は空の ExpressionAttributeValues を受け付けない
+	if len(values) == 0 {
+		values = nil
+	}
+
+	expression := strings.Join(update, " ")
+	condition := "attribute_exists(#name) AND attribute_not_exists(#deleted_at)"
+	_, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(r.table),
+		Key:                       dynamoStockKey(thresholds.Name),
+		UpdateExpression:          aws.String(expression),
+		ConditionExpression:       aws.String(condition),
+		ExpressionAttributeNames:  dynamoExpressionNames(expression, condition),
+		ExpressionAttributeValues: values,
+	})
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		return errStockNotFound
+	}
+	return err
+}
+
+// dynamoWriter は読み込んだ在庫の項目から、1 回のトランザクションで書き込む項目を作ります。
+// current は名前ごとの在庫の項目で、存在しない在庫は含みません。何も書き込まない場合は空の項目を返します。
+type dynamoWriter func(current map[string]map[string]types.AttributeValue) ([]types.TransactWriteItem, error)
+
+// transact は names の在庫の項目を強い整合性で読み込み、write が返した項目を 1 回の TransactWriteItems で書き込みます。
+// meta に処理中の Idempotency-Key があれば、同じトランザクションでキーを保持します。
+// 読み込んだ後に別の更新があってトランザクションが取り消された場合は、少し待ってから読み込みからやり直し、
+// dynamoTransactRetries 回やり直しても書き込めなければ errDynamoConditionFailed を返します。
+func (r *dynamoStockRepository) transact(ctx context.Context, names []string, meta MovementMeta, now time.Time, write dynamoWriter) error {
+	for attempt := 0; ; attempt++ {
+		current, err := r.getItems(ctx, names)
+		if err != nil {
+			return err
+		}
+		items, err := write(current)
+		if err != nil || len(items) == 0 {
+			return err
+		}
+		if meta.idempotencyKey != "" {
+			items = append(items, r.holdIdempotencyKey(meta.idempotencyKey, now))
+		}
+
+		_, err = r.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: items})
+		var canceled *types.TransactionCanceledException
+		if !errors.As(err, &canceled) {
+			return err
+		}
+		if attempt == dynamoTransactRetries {
+			return errDynamoConditionFailed
+		}
+		// 同時に更新した他のリクエストと同じ間隔でやり直し続けないよう、待つ時間をばらつかせる
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(rand.N(dynamoRetryDelay * time.Duration(attempt+1))):
+		}
+	}
+}
+
+// getItems は names の在庫の項目を強い整合性で読み込み、名前ごとに返します。存在しない在庫は含みません。
+func (r *dynamoStockRepository) getItems(ctx context.Context, names []string) (map[string]map[string]types.AttributeValue, error) {
+	current := make(map[string]map[string]types.AttributeValue)
+	if len(names) == 1 {
+		out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+			TableName:      aws.String(r.table),
+			Key:            dynamoStockKey(names[0]),
+			ConsistentRead: aws.Bool(true),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(out.Item) > 0 {
+			current[names[0]] = out.Item
+		}
+		return current, nil
+	}
+
+	gets := make([]types.TransactGetItem, len(names))
+	for i, name := range names {
+		gets[i] = types.TransactGetItem{Get: &types.Get{TableName: aws.String(r.table), Key: dynamoStockKey(name)}}
+	}
+	out, err := r.client.TransactGetItems(ctx, &dynamodb.TransactGetItemsInput{TransactItems: gets})
+	if err != nil {
+		return nil, err
+	}
+	for i, response := range out.Responses {
+		if len(response.Item) > 0 {
+			current[names[i]] = response.Item
+		}
+	}
+	return current, nil
+}
+
+// stockChange は在庫の項目を更新する項目と、その変更の在庫移動と outbox のイベントを書き込む項目を返します。
+// version は変更後のバージョン、amountAfter は変更後の在庫数です。
+func (r *dynamoStockRepository) stockChange(name string, previous map[string]types.AttributeValue, update string, values map[string]types.AttributeValue,
+	version int64, delta, amountAfter int, meta MovementMeta, now time.Time) ([]types.TransactWriteItem, error) {
+	movement, err := r.movementItems(name, version, delta, amountAfter, meta, now)
+	if err != nil {
+		return nil, err
+	}
+	return append([]types.TransactWriteItem{r.updateStock(name, previous, update, values)}, movement...), nil
+}
+
+// updateStock は読み込んだときの在庫の項目 previous のバージョンを条件に、在庫の項目を update で更新する項目を返します。
+// previous が nil の場合は、項目が存在しないことを条件に作成します。
+func (r *dynamoStockRepository) updateStock(name string, previous map[string]types.AttributeValue, update string, values map[string]types.AttributeValue) types.TransactWriteItem {
+	condition := "attribute_not_exists(#name)"
+	if previous != nil {
+		condition = "#version = :previous_version"
+		values[":previous_version"] = previous["version"]
+	}
+	return types.TransactWriteItem{Update: &types.Update{
+		TableName:                 aws.String(r.table),
+		Key:                       dynamoStockKey(name),
+		UpdateExpression:          aws.String(update),
+		ConditionExpression:       aws.String(condition),
+		ExpressionAttributeNames:  dynamoExpressionNames(update, condition),
+		ExpressionAttributeValues: values,
+	}}
+}
+
+// checkItemVersion は読み込んだ在庫の項目が If-Match の条件を満たすかを確認し、満たさなければ errPreconditionFailed を返します。
+// 項目は読み込んだときのバージョンを条件に更新するため、確認した後に別の更新があった場合は書き込まれません。
+func checkItemVersion(item map[string]types.AttributeValue, match *versionMatch) error {
+	if match == nil {
+		return nil
+	}
+	version, err := dynamoItemNumber(item, "version")
+	if err != nil {
+		return err
+	}
+	if item == nil || !match.matches(version) {
+		return errPreconditionFailed
+	}
+	return nil
+}
+
+// dynamoStockKey は在庫の項目のプライマリキーを返します。
+func dynamoStockKey(name string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{"name": &types.AttributeValueMemberS{Value: name}}
+}
+
+// dynamoStockPartition は在庫の項目の gsi_pk の値を、名前のハッシュで決めます。
+func dynamoStockPartition(name string) types.AttributeValue {
+	return dynamoStockShardPartition(dynamoStockShard(name))
+}
+
+// dynamoStockShard は名前のハッシュから、在庫を書き込むパーティションの番号を決めます。
+func dynamoStockShard(name string) int {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return int(h.Sum32() % dynamoStockShards)
+}
+
+// dynamoStockShardPartition は shard 番目のパーティションの gsi_pk の値を返します。
+func dynamoStockShardPartition(shard int) types.AttributeValue {
+	return &types.AttributeValueMemberS{Value: "stock#" + strconv.Itoa(shard)}
+}
+
+// dynamoCursorKey はパーティションごとの位置のカーソルを、gsi_pk が partition のパーティションを Query する際の ExclusiveStartKey に変換します。
+func dynamoCursorKey(cursor stockCursor, index dynamoStockIndex, partition types.AttributeValue) (map[string]types.AttributeValue, error) {
+	key := map[string]types.AttributeValue{
+		"gsi_pk": partition,
+		"name":   &types.AttributeValueMemberS{Value: cursor.Name},
+	}
+	value, err := cursor.parseValue()
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	switch v := value.(type) {
+	case int:
+		key[index.SortKey] = dynamoNumber(int64(v))
+	case time.Time:
+		key[index.SortKey] = dynamoTime(v)
+	case nil:
+		// 更新日時のない項目はインデックスに含まれないため、そのカーソルは作成されない
+		return nil, errInvalidCursor
+	}
+	return key, nil
+}
+
+// dynamoExpressionNames は式で使われている属性名のプレースホルダだけを返します。
+// DynamoDB は使われていないプレースホルダを指定するとエラーを返します。
+func dynamoExpressionNames(expressions ...string) map[string]string {
+	names := make(map[string]string)
+	for placeholder, name := range dynamoAttributeNames {
+		for _, expression := range expressions {
+			if strings.Contains(expression, placeholder) {
+				names[placeholder] = name
+			}
+		}
+	}
+	return names
+}
+
+// stockFromItem は DynamoDB の項目を Stock に変換します。item が nil の場合は空の Stock を返します。
+func stockFromItem(item map[string]types.AttributeValue) (Stock, error) {
+	var stock Stock
+	if name, ok := item["name"].(*types.AttributeValueMemberS); ok {
+		stock.Name = name.Value
+	}
+	amount, err := dynamoItemNumber(item, "amount")
+	if err != nil {
+		return Stock{}, err
+	}
+	stock.Amount = int(amount)
+	if stock.Version, err = dynamoItemNumber(item, "version"); err != nil {
+		return Stock{}, err
+	}
+	if stock.UpdatedAt, err = dynamoItemTime(item, "updated_at"); err != nil {
+		return Stock{}, err
+	}
+	if stock.DeletedAt, err = dynamoItemTime(item, "deleted_at"); err != nil {
+		return Stock{}, err
+	}
+	stock.Available = stock.Amount
+	return stock, nil
+}
+
+// thresholdsFromItem は項目の発注点と安全在庫を読み込みます。属性がないしきい値は nil です。
+func thresholdsFromItem(name string, item map[string]types.AttributeValue) (StockThresholds, error) {
+	thresholds := StockThresholds{Name: name}
+	for key, threshold := range map[string]**int{"reorder_point": &thresholds.ReorderPoint, "safety_stock": &thresholds.SafetyStock} {
+		if _, ok := item[key]; !ok {
+			continue
+		}
+		n, err := dynamoItemNumber(item, key)
+		if err != nil {
+			return StockThresholds{}, err
+		}
+		value := int(n)
+		*threshold = &value
+	}
+	return thresholds, nil
+}
+
+func dynamoNumber(n int64) types.AttributeValue {
+	return &types.AttributeValueMemberN{Value: strconv.FormatInt(n, 10)}
+}
+
+func dynamoTime(t time.Time) types.AttributeValue {
+	return &types.AttributeValueMemberS{Value: t.UTC().Format(dynamoTimeLayout)}
+}
+
+// dynamoItemNumber は数値の属性を読み込みます。属性がない場合は 0 を返します。
+func dynamoItemNumber(item map[string]types.AttributeValue, key string) (int64, error) {
+	attr, ok := item[key]
+	if !ok {
+		return 0, nil
+	}
+	n, ok := attr.(*types.AttributeValueMemberN)
+	if !ok {
+		return 0, fmt.Errorf("attribute %s is not a number", key)
+	}
+	return strconv.ParseInt(n.Value, 10, 64)
+}
+
+// dynamoItemTime は日時の属性を読み込みます。属性がない場合は nil を返します。
+func dynamoItemTime(item map[string]types.AttributeValue, key string) (*time.Time, error) {
+	attr, ok := item[key]
+	if !ok {
+		return nil, nil
+	}
+	s, ok := attr.(*types.AttributeValueMemberS)
+	if !ok {
+		return nil, fmt.Errorf("attribute %s is not a string", key)
+	}
+	t, err := time.Parse(dynamoTimeLayout, s.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,188 @@
+//go:build integration
+// +build integration
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// setupDynamoDBLocal は DynamoDB Local のコンテナを起動し、dynamodb_stocks_table.json と dynamodb_ledger_table.json のテーブルを作成します。
+func setupDynamoDBLocal(t *testing.T) (*dynamodb.Client, func(), error) {
+	ctx := context.Background()
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "amazon/dynamodb-local:2.5.2",
+			Cmd:          []string{"-jar", "DynamoDBLocal.jar", "-inMemory"},
+			ExposedPorts: []string{"8000/tcp"},
+			WaitingFor:   wait.ForListeningPort("8000/tcp"),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("DynamoDB Localコンテナの起動に失敗しました: %v", err)
+	}
+
+	cleanup := func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("DynamoDB Localコンテナの終了に失敗しました: %v", err)
+		}
+	}
+
+	endpoint, err := container.PortEndpoint(ctx, "8000/tcp", "http")
+	if err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("接続先の取得に失敗しました: %v", err)
+	}
+	client := dynamodb.New(dynamodb.Options{
+		Region:       "ap-northeast-1",
+		Credentials:  credentials.NewStaticCredentialsProvider("local", "local", ""),
+		BaseEndpoint: aws.String(endpoint),
+	})
+
+	for _, file := range []string{"dynamodb_stocks_table.json", "dynamodb_ledger_table.json"} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		var table dynamodb.CreateTableInput
+		if err := json.Unmarshal(data, &table); err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		if _, err := client.CreateTable(ctx, &table); err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("テーブル %s の作成に失敗しました: %v", aws.ToString(table.TableName), err)
+		}
+	}
+	return client, cleanup, nil
+}
+
+func TestDynamoStockRepositoryIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	client, cleanup, err := setupDynamoDBLocal(t)
+	require.NoError(t, err)
+	defer cleanup()
+
+	stocks := newDynamoStockRepository(client, defaultDynamoStockTable, defaultDynamoLedgerTable)
+	router := newStockRouter(stocks)
+
+	// 各ステップは前のステップの結果に依存するため、順に実行する
+	steps := []struct {
+		name         string
+		method       string
+		path         string
+		requestBody  string
+		ifMatch      string
+		expectedCode int
+		expectedBody string
+	}{
+		{name: "在庫を登録する", method: http.MethodPost, path: "/stocks", requestBody: `{"name":"apple","amount":5}`,
+			expectedCode: http.StatusOK, expectedBody: `"name":"apple","amount":5`},
+		{name: "既存の在庫に加算する", method: http.MethodPost, path: "/stocks", requestBody: `{"name":"apple","amount":3}`,
+			expectedCode: http.StatusOK, expectedBody: `"amount":8`},
+		{name: "在庫数を指定して作成する", method: http.MethodPut, path: "/stocks/banana", requestBody: `{"amount":3}`,
+			expectedCode: http.StatusCreated, expectedBody: `"name":"banana","amount":3`},
+		{name: "create_onlyで既存の在庫は409", method: http.MethodPut, path: "/stocks/banana?create_only=true", requestBody: `{"amount":1}`,
+			expectedCode: http.StatusConflict, expectedBody: "stock already exists"},
+		{name: "If-Matchが一致しなければ412", method: http.MethodPatch, path: "/stocks/banana", requestBody: `{"delta":-1,"reason":"damage"}`,
+			ifMatch: `"9"`, expectedCode: http.StatusPreconditionFailed},
+		{name: "在庫数が負になる調整は409", method: http.MethodPatch, path: "/stocks/banana", requestBody: `{"delta":-4,"reason":"damage"}`,
+			expectedCode: http.StatusConflict, expectedBody: `"amount":3`},
+		{name: "在庫を調整する", method: http.MethodPatch, path: "/stocks/banana", requestBody: `{"delta":-1,"reason":"damage"}`,
+			ifMatch: `"1"`, expectedCode: http.StatusOK, expectedBody: `"previous_amount":3,"amount":2`},
+		{name: "引当可能数を超える引き当ては409", method: http.MethodPost, path: "/stocks/apple/allocate", requestBody: `{"amount":9}`,
+			expectedCode: http.StatusConflict, expectedBody: `"available":8`},
+		{name: "在庫を論理削除する", method: http.MethodDelete, path: "/stocks/banana",
+			expectedCode: http.StatusNoContent},
+		{name: "論理削除された在庫は一覧に含めない", method: http.MethodGet, path: "/stocks?sort=-amount",
+			expectedCode: http.StatusOK, expectedBody: `{"stocks":[{"name":"apple","amount":8`},
+		{name: "論理削除された在庫を元に戻す", method: http.MethodPost, path: "/stocks/banana/restore",
+			expectedCode: http.StatusOK, expectedBody: `"name":"banana","amount":2`},
+	}
+
+	for _, step := range steps {
+		if !t.Run(step.name, func(t *testing.T) {
+			req, _ := http.NewRequest(step.method, step.path, newJSONReader(step.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			if step.ifMatch != "" {
+				req.Header.Set("If-Match", step.ifMatch)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, step.expectedCode, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), step.expectedBody)
+		}) {
+			return
+		}
+	}
+
+	t.Run("名前順の2件目以降をカーソルで取得する", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/stocks?limit=1", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var page StockPage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		require.NotEmpty(t, page.NextCursor)
+
+		req, _ = http.NewRequest(http.MethodGet, "/stocks?cursor="+page.NextCursor, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `{"stocks":[{"name":"banana"`)
+	})
+
+	t.Run("同時に引き当てても在庫数は負にならない", func(t *testing.T) {
+		ctx := context.Background()
+		_, _, err := stocks.Set(ctx, "cherry", 5, false, nil, MovementMeta{})
+		require.NoError(t, err)
+
+		var (
+			wg        sync.WaitGroup
+			mu        sync.Mutex
+			allocated int
+		)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := stocks.Allocate(ctx, "cherry", 1, nil, MovementMeta{}); err == nil {
+					mu.Lock()
+					allocated++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		stock, err := stocks.Get(ctx, "cherry", false)
+		require.NoError(t, err)
+		assert.Equal(t, 5, allocated)
+		assert.Equal(t, 0, stock.Amount)
+		// 在庫数の変更ごとに在庫移動が 1 件ずつ記録される
+		movements, err := stocks.Movements(ctx, "cherry", 0, 100)
+		require.NoError(t, err)
+		assert.Len(t, movements, 1+allocated)
+	})
+}
@@ -0,0 +1,1149 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// fakeDynamoDB は dynamoStockRepository が使う式だけを解釈する、プロセス内の DynamoDB のフェイクです。
+// 条件式・キー条件式・フィルター式は AND で結んだ比較と関数、更新式は SET と REMOVE に対応します。
+// DynamoDB と同じく、使われていないプレースホルダを指定するとエラーを返します。
+// 台帳のテーブル（defaultDynamoLedgerTable）の項目は ledger、それ以外のテーブルの項目は在庫として items に保存します。
+type fakeDynamoDB struct {
+	mu    sync.Mutex
+	items map[string]map[string]types.AttributeValue
+	// ledger は台帳のテーブルの項目で、pk と sk を連結した文字列をキーにします。
+	ledger map[string]map[string]types.AttributeValue
+	// pageSize は Query が 1 回に読み込む項目数の上限で、1 MB の制限の代わりに使います。0 の場合は制限しません。
+	pageSize int
+	// evaluated は Query がフィルター式を適用する前に読み込んだ項目数の合計です。
+	evaluated int
+	// beforeTransactWrite は TransactWriteItems が条件を確認する前に呼び出され、同時に行われた更新を再現します。
+	beforeTransactWrite func(items map[string]map[string]types.AttributeValue)
+}
+
+// fakeUpdatedAt は更新日時を指定せずに登録した在庫の更新日時です。
+var fakeUpdatedAt = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// newFakeDynamoDB は stocks を登録したフェイクを作成します。
+// バージョンが 0 の在庫は 1、更新日時がない在庫は fakeUpdatedAt として登録します。
+func newFakeDynamoDB(stocks ...Stock) *fakeDynamoDB {
+	f := &fakeDynamoDB{items: make(map[string]map[string]types.AttributeValue), ledger: make(map[string]map[string]types.AttributeValue)}
+	for _, stock := range stocks {
+		if stock.Version == 0 {
+			stock.Version = 1
+		}
+		if stock.UpdatedAt == nil {
+			stock.UpdatedAt = &fakeUpdatedAt
+		}
+		item := map[string]types.AttributeValue{
+			"name":       &types.AttributeValueMemberS{Value: stock.Name},
+			"amount":     dynamoNumber(int64(stock.Amount)),
+			"version":    dynamoNumber(stock.Version),
+			"updated_at": dynamoTime(*stock.UpdatedAt),
+			"gsi_pk":     dynamoStockPartition(stock.Name),
+		}
+		if stock.DeletedAt != nil {
+			item["deleted_at"] = dynamoTime(*stock.DeletedAt)
+		}
+		f.items[stock.Name] = item
+	}
+	return f
+}
+
+func (f *fakeDynamoDB) GetItem(ctx context.Context, in *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &dynamodb.GetItemOutput{Item: maps.Clone(f.table(in.TableName)[fakeItemKey(in.Key)])}, nil
+}
+
+func (f *fakeDynamoDB) PutItem(ctx context.Context, in *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	condition := aws.ToString(in.ConditionExpression)
+	if err := fakeCheckPlaceholders(in.ExpressionAttributeNames, in.ExpressionAttributeValues, condition); err != nil {
+		return nil, err
+	}
+	items, key := f.table(in.TableName), fakeItemKey(fakePrimaryKey(in.TableName, in.Item))
+	e := fakeExpression{names: in.ExpressionAttributeNames, values: in.ExpressionAttributeValues, item: items[key]}
+	if condition != "" && !e.condition(condition) {
+		return nil, &types.ConditionalCheckFailedException{Message: aws.String("The conditional request failed")}
+	}
+	items[key] = maps.Clone(in.Item)
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (f *fakeDynamoDB) DeleteItem(ctx context.Context, in *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	condition := aws.ToString(in.ConditionExpression)
+	if err := fakeCheckPlaceholders(in.ExpressionAttributeNames, in.ExpressionAttributeValues, condition); err != nil {
+		return nil, err
+	}
+	items, key := f.table(in.TableName), fakeItemKey(in.Key)
+	e := fakeExpression{names: in.ExpressionAttributeNames, values: in.ExpressionAttributeValues, item: items[key]}
+	if condition != "" && !e.condition(condition) {
+		return nil, &types.ConditionalCheckFailedException{Message: aws.String("The conditional request failed")}
+	}
+	delete(items, key)
+	return &dynamodb.DeleteItemOutput{}, nil
+}
+
+func (f *fakeDynamoDB) UpdateItem(ctx context.Context, in *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	update, condition := aws.ToString(in.UpdateExpression), aws.ToString(in.ConditionExpression)
+	if err := fakeCheckPlaceholders(in.ExpressionAttributeNames, in.ExpressionAttributeValues, update, condition); err != nil {
+		return nil, err
+	}
+
+	items, key := f.table(in.TableName), fakeItemKey(in.Key)
+	current := items[key]
+	e := fakeExpression{names: in.ExpressionAttributeNames, values: in.ExpressionAttributeValues, item: current}
+	if condition != "" && !e.condition(condition) {
+		failed := &types.ConditionalCheckFailedException{Message: aws.String("The conditional request failed")}
+		if in.ReturnValuesOnConditionCheckFailure == types.ReturnValuesOnConditionCheckFailureAllOld {
+			failed.Item = maps.Clone(current)
+		}
+		return nil, failed
+	}
+
+	updated := e.update(update)
+	maps.Copy(updated, in.Key)
+	items[key] = updated
+
+	out := &dynamodb.UpdateItemOutput{}
+	switch in.ReturnValues {
+	case types.ReturnValueAllNew, types.ReturnValueUpdatedNew:
+		out.Attributes = maps.Clone(updated)
+	case types.ReturnValueAllOld:
+		out.Attributes = maps.Clone(current)
+	}
+	return out, nil
+}
+
+func (f *fakeDynamoDB) TransactGetItems(ctx context.Context, in *dynamodb.TransactGetItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactGetItemsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	out := &dynamodb.TransactGetItemsOutput{Responses: make([]types.ItemResponse, len(in.TransactItems))}
+	for i, get := range in.TransactItems {
+		out.Responses[i].Item = maps.Clone(f.table(get.Get.TableName)[fakeItemKey(get.Get.Key)])
+	}
+	return out, nil
+}
+
+// TransactWriteItems は全ての条件を満たす場合のみ、全ての項目に Update・Put・Delete を適用します。
+func (f *fakeDynamoDB) TransactWriteItems(ctx context.Context, in *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.beforeTransactWrite != nil {
+		f.beforeTransactWrite(f.items)
+	}
+
+	writes := make([]fakeWrite, len(in.TransactItems))
+	seen := make(map[string]bool)
+	canceled := false
+	reasons := make([]types.CancellationReason, len(in.TransactItems))
+	for i, item := range in.TransactItems {
+		w := newFakeWrite(item)
+		if err := fakeCheckPlaceholders(w.names, w.values, w.update, w.condition); err != nil {
+			return nil, err
+		}
+		key := aws.ToString(w.table) + "/" + fakeItemKey(w.key)
+		if seen[key] {
+			return nil, fmt.Errorf("ValidationException: transaction includes multiple operations on item %s", key)
+		}
+		seen[key] = true
+		writes[i] = w
+
+		reasons[i].Code = aws.String("None")
+		e := fakeExpression{names: w.names, values: w.values, item: f.table(w.table)[fakeItemKey(w.key)]}
+		if w.condition != "" && !e.condition(w.condition) {
+			reasons[i].Code = aws.String("ConditionalCheckFailed")
+			canceled = true
+		}
+	}
+	if canceled {
+		return nil, &types.TransactionCanceledException{Message: aws.String("Transaction cancelled"), CancellationReasons: reasons}
+	}
+
+	for _, w := range writes {
+		items, key := f.table(w.table), fakeItemKey(w.key)
+		switch {
+		case w.delete:
+			delete(items, key)
+		case w.item != nil:
+			items[key] = maps.Clone(w.item)
+		default:
+			e := fakeExpression{names: w.names, values: w.values, item: items[key]}
+			updated := e.update(w.update)
+			maps.Copy(updated, w.key)
+			items[key] = updated
+		}
+	}
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+// fakeWrite は TransactWriteItem の Update・Put・Delete のいずれか 1 つです。
+type fakeWrite struct {
+	table     *string
+	key       map[string]types.AttributeValue
+	update    string
+	item      map[string]types.AttributeValue
+	delete    bool
+	condition string
+	names     map[string]string
+	values    map[string]types.AttributeValue
+}
+
+func newFakeWrite(item types.TransactWriteItem) fakeWrite {
+	switch {
+	case item.Update != nil:
+		u := item.Update
+		return fakeWrite{table: u.TableName, key: u.Key, update: aws.ToString(u.UpdateExpression), condition: aws.ToString(u.ConditionExpression), names: u.ExpressionAttributeNames, values: u.ExpressionAttributeValues}
+	case item.Put != nil:
+		p := item.Put
+		return fakeWrite{table: p.TableName, key: fakePrimaryKey(p.TableName, p.Item), item: p.Item, condition: aws.ToString(p.ConditionExpression), names: p.ExpressionAttributeNames, values: p.ExpressionAttributeValues}
+	case item.Delete != nil:
+		d := item.Delete
+		return fakeWrite{table: d.TableName, key: d.Key, delete: true, condition: aws.ToString(d.ConditionExpression), names: d.ExpressionAttributeNames, values: d.ExpressionAttributeValues}
+	}
+	panic("unsupported transact write item")
+}
+
+func (f *fakeDynamoDB) Query(ctx context.Context, in *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	keyCondition, filter := aws.ToString(in.KeyConditionExpression), aws.ToString(in.FilterExpression)
+	if err := fakeCheckPlaceholders(in.ExpressionAttributeNames, in.ExpressionAttributeValues, keyCondition, filter); err != nil {
+		return nil, err
+	}
+	// インデックスを指定しない Query は台帳のテーブルを sk の順に、インデックスの Query は在庫をインデックスのソートキーの順に読み込む
+	source, sortKey, keys := f.ledger, "sk", []string{"pk"}
+	if in.IndexName != nil {
+		if aws.ToBool(in.ConsistentRead) {
+			return nil, errors.New("ValidationException: consistent reads are not supported on global secondary indexes")
+		}
+		for _, i := range dynamoStockIndexes {
+			if i.Name == *in.IndexName {
+				source, sortKey, keys = f.items, i.SortKey, []string{"name", "gsi_pk"}
+			}
+		}
+		if sortKey == "sk" {
+			return nil, fmt.Errorf("ValidationException: unknown index %q", *in.IndexName)
+		}
+	}
+
+	// ソートキー、同じ値の場合は名前の順に並べる
+	forward := in.ScanIndexForward == nil || *in.ScanIndexForward
+	compare := func(a, b map[string]types.AttributeValue) int {
+		c := fakeCompare(a[sortKey], b[sortKey])
+		if c == 0 && in.IndexName != nil {
+			c = fakeCompare(a["name"], b["name"])
+		}
+		if !forward {
+			c = -c
+		}
+		return c
+	}
+	var items []map[string]types.AttributeValue
+	for _, item := range source {
+		e := fakeExpression{names: in.ExpressionAttributeNames, values: in.ExpressionAttributeValues, item: item}
+		if _, ok := item[sortKey]; ok && e.condition(keyCondition) {
+			items = append(items, item)
+		}
+	}
+	slices.SortFunc(items, compare)
+	if in.ExclusiveStartKey != nil {
+		// 読み込んだ項目のキー以外から読み込もうとしていないことを確認する
+		if !slices.ContainsFunc(items, func(item map[string]types.AttributeValue) bool { return compare(item, in.ExclusiveStartKey) == 0 }) {
+			return nil, errors.New("ValidationException: the exclusive start key does not match any item in the partition")
+		}
+		items = slices.DeleteFunc(items, func(item map[string]types.AttributeValue) bool {
+			return compare(item, in.ExclusiveStartKey) <= 0
+		})
+	}
+
+	// Limit はフィルター式を適用する前に読み込む件数
+	limit := len(items)
+	if in.Limit != nil {
+		limit = min(limit, int(*in.Limit))
+	}
+	if f.pageSize > 0 {
+		limit = min(limit, f.pageSize)
+	}
+	f.evaluated += limit
+	out := &dynamodb.QueryOutput{}
+	for _, item := range items[:limit] {
+		e := fakeExpression{names: in.ExpressionAttributeNames, values: in.ExpressionAttributeValues, item: item}
+		if filter == "" || e.condition(filter) {
+			out.Items = append(out.Items, maps.Clone(item))
+		}
+	}
+	if limit > 0 && (limit < len(items) || (in.Limit != nil && limit == int(*in.Limit))) {
+		last := items[limit-1]
+		out.LastEvaluatedKey = map[string]types.AttributeValue{sortKey: last[sortKey]}
+		for _, key := range keys {
+			out.LastEvaluatedKey[key] = last[key]
+		}
+	}
+	return out, nil
+}
+
+// table はテーブル名に対応する項目を返します。
+func (f *fakeDynamoDB) table(name *string) map[string]map[string]types.AttributeValue {
+	if aws.ToString(name) == defaultDynamoLedgerTable {
+		return f.ledger
+	}
+	return f.items
+}
+
+// fakePrimaryKey は項目からプライマリキーの属性を取り出します。
+func fakePrimaryKey(table *string, item map[string]types.AttributeValue) map[string]types.AttributeValue {
+	if aws.ToString(table) == defaultDynamoLedgerTable {
+		return map[string]types.AttributeValue{"pk": item["pk"], "sk": item["sk"]}
+	}
+	return map[string]types.AttributeValue{"name": item["name"]}
+}
+
+// fakeItemKey はプライマリキーを、項目を保存する map のキーに変換します。在庫の項目は名前をそのまま使います。
+func fakeItemKey(key map[string]types.AttributeValue) string {
+	if pk, ok := key["pk"]; ok {
+		return pk.(*types.AttributeValueMemberS).Value + "\x00" + key["sk"].(*types.AttributeValueMemberS).Value
+	}
+	return key["name"].(*types.AttributeValueMemberS).Value
+}
+
+// fakeCheckPlaceholders は指定されたプレースホルダがすべて式で使われているか確認します。
+func fakeCheckPlaceholders(names map[string]string, values map[string]types.AttributeValue, expressions ...string) error {
+	all := strings.Join(expressions, " ")
+	for placeholder := range names {
+		if !strings.Contains(all, placeholder) {
+			return fmt.Errorf("ValidationException: unused expression attribute name %s", placeholder)
+		}
+	}
+	for placeholder := range values {
+		if !strings.Contains(all, placeholder) {
+			return fmt.Errorf("ValidationException: unused expression attribute value %s", placeholder)
+		}
+	}
+	return nil
+}
+
+// fakeExpression は項目に対して式を評価します。
+type fakeExpression struct {
+	names  map[string]string
+	values map[string]types.AttributeValue
+	item   map[string]types.AttributeValue
+}
+
+// condition は AND で結んだ条件をすべて満たすかを返します。
+func (e fakeExpression) condition(expression string) bool {
+	for _, term := range strings.Split(expression, " AND ") {
+		if !e.term(strings.TrimSpace(term)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e fakeExpression) term(term string) bool {
+	if fn, args, ok := fakeFunction(term); ok {
+		switch fn {
+		case "attribute_exists":
+			return e.operand(args[0]) != nil
+		case "attribute_not_exists":
+			return e.operand(args[0]) == nil
+		case "begins_with", "contains":
+			s, ok := e.operand(args[0]).(*types.AttributeValueMemberS)
+			sub := e.operand(args[1]).(*types.AttributeValueMemberS)
+			if fn == "begins_with" {
+				return ok && strings.HasPrefix(s.Value, sub.Value)
+			}
+			return ok && strings.Contains(s.Value, sub.Value)
+		}
+	}
+	if left, list, ok := strings.Cut(term, " IN "); ok {
+		v := e.operand(left)
+		for _, candidate := range strings.Split(strings.Trim(list, "()"), ",") {
+			if v != nil && fakeCompare(v, e.operand(candidate)) == 0 {
+				return true
+			}
+		}
+		return false
+	}
+	for _, op := range []string{" >= ", " <= ", " < ", " > ", " = "} {
+		if left, right, ok := strings.Cut(term, op); ok {
+			a, b := e.operand(left), e.operand(right)
+			if a == nil || b == nil {
+				return false
+			}
+			c := fakeCompare(a, b)
+			switch op {
+			case " >= ":
+				return c >= 0
+			case " <= ":
+				return c <= 0
+			case " < ":
+				return c < 0
+			case " > ":
+				return c > 0
+			}
+			return c == 0
+		}
+	}
+	panic("unsupported condition: " + term)
+}
+
+// update は SET と REMOVE を適用した新しい項目を返します。右辺は更新前の項目で評価します。
+func (e fakeExpression) update(expression string) map[string]types.AttributeValue {
+	updated := maps.Clone(e.item)
+	if updated == nil {
+		updated = make(map[string]types.AttributeValue)
+	}
+	set, remove, _ := strings.Cut(" "+expression, " REMOVE ")
+	if assignments, ok := strings.CutPrefix(strings.TrimSpace(set), "SET "); ok {
+		for _, assignment := range fakeSplit(assignments) {
+			left, right, _ := strings.Cut(assignment, " = ")
+			updated[e.names[strings.TrimSpace(left)]] = e.value(right)
+		}
+	}
+	if remove != "" {
+		for _, placeholder := range strings.Split(remove, ",") {
+			delete(updated, e.names[strings.TrimSpace(placeholder)])
+		}
+	}
+	return updated
+}
+
+// value は加算・減算を含む更新式の右辺を評価します。
+func (e fakeExpression) value(expression string) types.AttributeValue {
+	for _, op := range []string{" + ", " - "} {
+		if left, right, ok := strings.Cut(expression, op); ok {
+			a, b := fakeNumber(e.operand(left)), fakeNumber(e.operand(right))
+			if op == " - " {
+				b = -b
+			}
+			return dynamoNumber(a + b)
+		}
+	}
+	return e.operand(expression)
+}
+
+// operand は属性名か値のプレースホルダ、または if_not_exists を評価します。属性がない場合は nil を返します。
+func (e fakeExpression) operand(s string) types.AttributeValue {
+	s = strings.TrimSpace(s)
+	if fn, args, ok := fakeFunction(s); ok && fn == "if_not_exists" {
+		if v := e.operand(args[0]); v != nil {
+			return v
+		}
+		return e.operand(args[1])
+	}
+	switch {
+	case strings.HasPrefix(s, "#"):
+		name, ok := e.names[s]
+		if !ok {
+			panic("undefined expression attribute name: " + s)
+		}
+		return e.item[name]
+	case strings.HasPrefix(s, ":"):
+		v, ok := e.values[s]
+		if !ok {
+			panic("undefined expression attribute value: " + s)
+		}
+		return v
+	}
+	panic("unsupported operand: " + s)
+}
+
+// fakeFunction は fn(a, b) の形式の式を関数名と引数に分けます。
+func fakeFunction(s string) (string, []string, bool) {
+	fn, rest, ok := strings.Cut(s, "(")
+	if !ok || strings.ContainsAny(fn, " #:") || !strings.HasSuffix(rest, ")") {
+		return "", nil, false
+	}
+	args := strings.Split(strings.TrimSuffix(rest, ")"), ",")
+	for i := range args {
+		args[i] = strings.TrimSpace(args[i])
+	}
+	return fn, args, true
+}
+
+// fakeSplit は括弧の外にあるカンマで式を分割します。
+func fakeSplit(s string) []string {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, strings.TrimSpace(s[start:]))
+}
+
+func fakeNumber(v types.AttributeValue) int64 {
+	n, err := strconv.ParseInt(v.(*types.AttributeValueMemberN).Value, 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// fakeCompare は同じ型の値を比較します。数値は数値として、文字列は文字列として比較します。
+func fakeCompare(a, b types.AttributeValue) int {
+	switch a := a.(type) {
+	case *types.AttributeValueMemberN:
+		return cmp.Compare(fakeNumber(a), fakeNumber(b))
+	case *types.AttributeValueMemberS:
+		return strings.Compare(a.Value, b.(*types.AttributeValueMemberS).Value)
+	}
+	panic(fmt.Sprintf("unsupported attribute value: %T", a))
+}
+
+func TestStockHandlersWithDynamoRepository(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	deletedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	seed := func() *fakeDynamoDB {
+		return newFakeDynamoDB(
+			Stock{Name: "apple", Amount: 10, Version: 3},
+			Stock{Name: "banana", Amount: 3},
+			Stock{Name: "cherry", Amount: 5, DeletedAt: &deletedAt},
+		)
+	}
+
+	testCases := []struct {
+		name         string
+		method       string
+		path         string
+		requestBody  string
+		ifMatch      string
+		expectedCode int
+		expectedBody string
+		expectedETag string
+	}{
+		{name: "在庫を取得する", method: http.MethodGet, path: "/stocks/apple",
+			expectedCode: http.StatusOK, expectedBody: `[{"name":"apple","amount":10,"reserved":0,"available":10,"updated_at":"2026-01-01T00:00:00Z"}]`, expectedETag: `"3"`},
+		{name: "存在しない在庫はメッセージを返す", method: http.MethodGet, path: "/stocks/durian",
+			expectedCode: http.StatusOK, expectedBody: `{"message":"データが存在しません"}`},
+		{name: "論理削除された在庫はinclude_deletedで取得する", method: http.MethodGet, path: "/stocks/cherry?include_deleted=true",
+			expectedCode: http.StatusOK, expectedBody: `[{"name":"cherry","amount":5,"reserved":0,"available":5,"updated_at":"2026-01-01T00:00:00Z","deleted_at":"2026-01-02T03:04:05Z"}]`},
+		{name: "既存の在庫に加算する", method: http.MethodPost, path: "/stocks", requestBody: `{"name":"apple","amount":5}`,
+			expectedCode: http.StatusOK, expectedETag: `"4"`},
+		{name: "存在しない在庫は作成する", method: http.MethodPost, path: "/stocks", requestBody: `{"name":"durian"}`,
+			expectedCode: http.StatusOK, expectedETag: `"1"`},
+		{name: "論理削除された在庫への加算は409", method: http.MethodPost, path: "/stocks", requestBody: `{"name":"cherry","amount":1}`,
+			expectedCode: http.StatusConflict, expectedBody: `{"error":"stock is deleted","name":"cherry"}`},
+		{name: "If-Matchが一致しなければ412", method: http.MethodPost, path: "/stocks", requestBody: `{"name":"apple","amount":1}`, ifMatch: `"2"`,
+			expectedCode: http.StatusPreconditionFailed, expectedBody: `{"error":"precondition failed"}`},
+		{name: "If-Matchが一致すれば加算する", method: http.MethodPost, path: "/stocks", requestBody: `{"name":"apple","amount":1}`, ifMatch: `"2", "3"`,
+			expectedCode: http.StatusOK, expectedETag: `"4"`},
+		{name: "存在しない在庫へのIf-Matchは412", method: http.MethodPost, path: "/stocks", requestBody: `{"name":"durian","amount":1}`, ifMatch: `*`,
+			expectedCode: http.StatusPreconditionFailed, expectedBody: `{"error":"precondition failed"}`},
+		{name: "存在しない在庫を指定した値で作成して201", method: http.MethodPut, path: "/stocks/durian", requestBody: `{"amount":0}`,
+			expectedCode: http.StatusCreated, expectedETag: `"1"`},
+		{name: "在庫数を置き換える", method: http.MethodPut, path: "/stocks/apple", requestBody: `{"amount":7}`,
+			expectedCode: http.StatusOK, expectedETag: `"4"`},
+		{name: "create_onlyで既存の在庫は409", method: http.MethodPut, path: "/stocks/apple?create_only=true", requestBody: `{"amount":1}`,
+			expectedCode: http.StatusConflict, expectedBody: `{"error":"stock already exists","name":"apple"}`},
+		{name: "論理削除された在庫の置き換えは409", method: http.MethodPut, path: "/stocks/cherry", requestBody: `{"amount":1}`,
+			expectedCode: http.StatusConflict, expectedBody: `{"error":"stock is deleted","name":"cherry"}`},
+		{name: "在庫数が負になる調整は409", method: http.MethodPatch, path: "/stocks/banana", requestBody: `{"delta":-4,"reason":"damage"}`,
+			expectedCode: http.StatusConflict, expectedBody: `{"error":"insufficient stock","name":"banana","amount":3,"delta":-4}`},
+		{name: "在庫を調整する", method: http.MethodPatch, path: "/stocks/banana", requestBody: `{"delta":-3,"reason":"damage"}`,
+			expectedCode: http.StatusOK, expectedBody: `{"name":"banana","delta":-3,"reason":"damage","previous_amount":3,"amount":0}`, expectedETag: `"2"`},
+		{name: "allow_negativeで在庫数を負にする", method: http.MethodPatch, path: "/stocks/banana", requestBody: `{"delta":-4,"reason":"damage","allow_negative":true}`,
+			expectedCode: http.StatusOK, expectedBody: `{"name":"banana","delta":-4,"reason":"damage","previous_amount":3,"amount":-1}`},
+		{name: "存在しない在庫の調整は404", method: http.MethodPatch, path: "/stocks/durian", requestBody: `{"delta":1,"reason":"damage"}`,
+			expectedCode: http.StatusNotFound, expectedBody: `{"error":"stock not found: durian"}`},
+		{name: "在庫を論理削除する", method: http.MethodDelete, path: "/stocks/apple",
+			expectedCode: http.StatusNoContent},
+		{name: "論理削除済みの在庫の削除は404", method: http.MethodDelete, path: "/stocks/cherry",
+			expectedCode: http.StatusNotFound, expectedBody: `{"error":"stock not found: cherry"}`},
+		{name: "論理削除された在庫を元に戻す", method: http.MethodPost, path: "/stocks/cherry/restore",
+			expectedCode: http.StatusOK, expectedETag: `"2"`},
+		{name: "削除されていない在庫の復元は何もしない", method: http.MethodPost, path: "/stocks/apple/restore",
+			expectedCode: http.StatusOK, expectedBody: `{"name":"apple","amount":10,"reserved":0,"available":10,"updated_at":"2026-01-01T00:00:00Z"}`, expectedETag: `"3"`},
+		{name: "在庫を引き当てる", method: http.MethodPost, path: "/stocks/apple/allocate", requestBody: `{"amount":4}`,
+			expectedCode: http.StatusOK, expectedBody: `{"name":"apple","allocated":4,"amount":6,"available":6}`, expectedETag: `"4"`},
+		{name: "引当可能数を超える引き当ては409", method: http.MethodPost, path: "/stocks/banana/allocate", requestBody: `{"amount":4}`,
+			expectedCode: http.StatusConflict, expectedBody: `{"error":"insufficient stock","name":"banana","requested":4,"available":3}`},
+		{name: "論理削除された在庫の引き当ては404", method: http.MethodPost, path: "/stocks/cherry/allocate", requestBody: `{"amount":1}`,
+			expectedCode: http.StatusNotFound, expectedBody: `{"error":"stock not found: cherry"}`},
+		{name: "引き当てでIf-Matchが一致しなければ412", method: http.MethodPost, path: "/stocks/apple/allocate", requestBody: `{"amount":1}`, ifMatch: `"2"`,
+			expectedCode: http.StatusPreconditionFailed, expectedBody: `{"error":"precondition failed"}`},
+		{name: "在庫ごとに一括で加算する", method: http.MethodPost, path: "/stocks:batch", requestBody: `[{"name":"cherry","amount":1},{"name":"apple","amount":2},{"name":"durian"}]`,
+			expectedCode: http.StatusOK, expectedBody: `{"atomic":false,"succeeded":2,"failed":1,"results":[{"index":0,"name":"cherry","status":409,"error":"stock is deleted"},{"index":1,"name":"apple","status":200,"amount":12},{"index":2,"name":"durian","status":200,"amount":1}]}`},
+		{name: "atomicで同じ在庫を複数回加算する", method: http.MethodPost, path: "/stocks:batch?atomic=true", requestBody: `[{"name":"apple","amount":2},{"name":"durian"},{"name":"apple","amount":3}]`,
+			expectedCode: http.StatusOK, expectedBody: `{"atomic":true,"succeeded":3,"failed":0,"results":[{"index":0,"name":"apple","status":200,"amount":12},{"index":1,"name":"durian","status":200,"amount":1},{"index":2,"name":"apple","status":200,"amount":15}]}`},
+		{name: "atomicで論理削除された在庫を含む場合は何も加算せず409", method: http.MethodPost, path: "/stocks:batch?atomic=true", requestBody: `[{"name":"apple","amount":2},{"name":"cherry","amount":1}]`,
+			expectedCode: http.StatusConflict, expectedBody: `{"error":"batch item failed: index 1: stock is deleted","results":[{"index":0,"name":"apple","status":424,"error":"not applied"},{"index":1,"name":"cherry","status":409,"error":"stock is deleted"}]}`},
+		{name: "しきい値を設定する", method: http.MethodPut, path: "/stocks/apple/thresholds", requestBody: `{"reorder_point":5,"safety_stock":2}`,
+			expectedCode: http.StatusOK, expectedBody: `{"name":"apple","reorder_point":5,"safety_stock":2}`},
+		{name: "しきい値を設定していない在庫はnullを返す", method: http.MethodGet, path: "/stocks/apple/thresholds",
+			expectedCode: http.StatusOK, expectedBody: `{"name":"apple","reorder_point":null,"safety_stock":null}`},
+		{name: "論理削除された在庫のしきい値は404", method: http.MethodPut, path: "/stocks/cherry/thresholds", requestBody: `{"reorder_point":5}`,
+			expectedCode: http.StatusNotFound, expectedBody: `{"error":"stock not found"}`},
+		{name: "存在しない在庫のしきい値は404", method: http.MethodGet, path: "/stocks/durian/thresholds",
+			expectedCode: http.StatusNotFound, expectedBody: `{"error":"stock not found"}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newStockRouter(newDynamoStockRepository(seed(), defaultDynamoStockTable, defaultDynamoLedgerTable))
+
+			req, _ := http.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedCode, w.Code, w.Body.String())
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, w.Body.String())
+			}
+			if tc.expectedETag != "" {
+				assert.Equal(t, tc.expectedETag, w.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestDynamoStockRepositoryList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	deletedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	updatedAt := func(day int) *time.Time {
+		t := time.Date(2026, 2, day, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	fake := newFakeDynamoDB(
+		Stock{Name: "apple", Amount: 10, UpdatedAt: updatedAt(5)},
+		Stock{Name: "banana", Amount: 3, UpdatedAt: updatedAt(1)},
+		Stock{Name: "blueberry", Amount: 8, UpdatedAt: updatedAt(3)},
+		Stock{Name: "cherry", Amount: 7, UpdatedAt: updatedAt(4)},
+		Stock{Name: "date", Amount: 1, UpdatedAt: updatedAt(2)},
+		Stock{Name: "elderberry", Amount: 9, DeletedAt: &deletedAt},
+	)
+	// 在庫は名前のハッシュで複数のパーティションに分かれ、一覧ではパーティションをまたいで並べ直す
+	partitions := make(map[string]bool)
+	for _, item := range fake.items {
+		partitions[item["gsi_pk"].(*types.AttributeValueMemberS).Value] = true
+	}
+	require.Greater(t, len(partitions), 1)
+	// 1 回の Query で 2 件ずつ読み込み、フィルター式で除外された分を続けて取得することを確認する
+	fake.pageSize = 2
+	stocks := newDynamoStockRepository(fake, defaultDynamoStockTable, defaultDynamoLedgerTable)
+	router := newStockRouter(stocks)
+
+	// カーソルをたどって全てのページを読み込む
+	for _, tc := range []struct {
+		sort     string
+		expected []string
+	}{
+		{sort: "name", expected: []string{"apple", "banana", "blueberry", "cherry", "date"}},
+		{sort: "-amount", expected: []string{"apple", "blueberry", "cherry", "banana", "date"}},
+		{sort: "updated_at", expected: []string{"banana", "date", "blueberry", "cherry", "apple"}},
+	} {
+		var names []string
+		path := "/stocks?limit=2&sort=" + tc.sort
+		for i := 0; i < 4 && path != ""; i++ {
+			req, _ := http.NewRequest(http.MethodGet, path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var page StockPage
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+			for _, stock := range page.Stocks {
+				names = append(names, stock.Name)
+			}
+			path = ""
+			if page.NextCursor != "" {
+				path = "/stocks?limit=2&sort=" + tc.sort + "&cursor=" + page.NextCursor
+			}
+		}
+		assert.Equal(t, tc.expected, names, tc.sort)
+	}
+
+	ctx := context.Background()
+	listNames := func(opts StockListOptions) []string {
+		t.Helper()
+		list, err := stocks.List(ctx, opts)
+		require.NoError(t, err)
+		var names []string
+		for _, stock := range list {
+			names = append(names, stock.Name)
+		}
+		return names
+	}
+
+	minAmount := 5
+	assert.Equal(t, []string{"blueberry"}, listNames(StockListOptions{Prefix: "b", MinAmount: &minAmount}))
+	assert.Equal(t, []string{"banana", "blueberry"}, listNames(StockListOptions{Sort: "amount", Prefix: "b"}))
+	assert.Equal(t, []string{"blueberry", "elderberry"}, listNames(StockListOptions{Contains: "berry", IncludeDeleted: true}))
+	assert.Equal(t, []string{"apple", "cherry", "blueberry"}, listNames(StockListOptions{Sort: "-updated_at", UpdatedSince: updatedAt(3)}))
+
+	// 次のページはパーティションごとに、最後に返した在庫の次から読み込む
+	opts := StockListOptions{Sort: "updated_at", Limit: 2}
+	page, err := stocks.List(ctx, opts)
+	require.NoError(t, err)
+	after, err := decodeStockCursor(stocks.NextCursor(opts, page), "updated_at")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"blueberry", "cherry"}, listNames(StockListOptions{Sort: "updated_at", After: &after, Limit: 2}))
+
+	// パーティションごとの位置がないカーソルでは読み込まない
+	_, err = stocks.List(ctx, StockListOptions{Sort: "amount", After: &stockCursor{Sort: "amount", Value: "5", Name: "apple"}})
+	assert.ErrorIs(t, err, errInvalidCursor)
+	req, _ := http.NewRequest(http.MethodGet, "/stocks?cursor="+encodeCursor("apple"), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDynamoStockRepositoryListReads(t *testing.T) {
+	ctx := context.Background()
+	stocks := make([]Stock, 200)
+	for i := range stocks {
+		stocks[i] = Stock{Name: fmt.Sprintf("stock-%03d", i), Amount: i}
+	}
+	fake := newFakeDynamoDB(stocks...)
+	repo := newDynamoStockRepository(fake, defaultDynamoStockTable, defaultDynamoLedgerTable)
+
+	// 各パーティションからは残りの件数を均等に分けた分だけ読み込み、足りないパーティションだけを読み進める
+	opts := StockListOptions{Sort: "-amount", Limit: 40}
+	var names []string
+	for i := 0; i < 5; i++ {
+		page, err := repo.List(ctx, opts)
+		require.NoError(t, err)
+		require.Len(t, page, 40)
+		for _, stock := range page {
+			names = append(names, stock.Name)
+		}
+		after, err := decodeStockCursor(repo.NextCursor(opts, page), opts.Sort)
+		require.NoError(t, err)
+		opts.After = &after
+	}
+	for i, name := range names {
+		require.Equal(t, fmt.Sprintf("stock-%03d", 199-i), name)
+	}
+	// 1 ページで読み込む項目はページの件数とパーティションごとに数件の余裕まで
+	assert.LessOrEqual(t, fake.evaluated, 5*(40+2*dynamoStockShards))
+}
+
+func TestDynamoStockRepositoryExport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	stocks := make([]Stock, maxStockPageLimit+1)
+	for i := range stocks {
+		stocks[i] = Stock{Name: fmt.Sprintf("stock-%03d", i), Amount: i}
+	}
+	repo := newDynamoStockRepository(newFakeDynamoDB(stocks...), defaultDynamoStockTable, defaultDynamoLedgerTable)
+	router := gin.New()
+	router.GET("/stocks/export.csv", exportStocksHandler(repo))
+
+	// ページの件数を超える在庫も、next_cursor で読み進めて名前の順に全て書き出す
+	req, _ := http.NewRequest(http.MethodGet, "/stocks/export.csv", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	records, err := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(w.Body.Bytes(), utf8BOM))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, len(stocks)+1)
+	assert.Equal(t, stockCSVHeader, records[0])
+	for i, record := range records[1:] {
+		assert.Equal(t, stocks[i].Name, record[0])
+		assert.Equal(t, strconv.Itoa(stocks[i].Amount), record[1])
+	}
+}
+
+// dynamoTestEvents は outbox の在庫イベントを配信し、配信した在庫イベントのデータを配信順に返します。
+func dynamoTestEvents(t *testing.T, stocks *dynamoStockRepository) []StockEventData {
+	t.Helper()
+	publisher := newMemoryPublisher()
+	_, more, err := stocks.RelayBatch(context.Background(), publisher)
+	require.NoError(t, err)
+	require.False(t, more)
+	var events []StockEventData
+	for _, event := range publisher.Events() {
+		var data StockEventData
+		require.NoError(t, json.Unmarshal(event.Data, &data))
+		events = append(events, data)
+	}
+	return events
+}
+
+func TestDynamoStockRepositoryEvents(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeDynamoDB(Stock{Name: "apple", Amount: 10}, Stock{Name: "banana", Amount: 3})
+	stocks := newDynamoStockRepository(fake, defaultDynamoStockTable, defaultDynamoLedgerTable)
+	meta := MovementMeta{Reason: movementReceipt, Actor: "tester"}
+
+	_, err := stocks.Upsert(ctx, "apple", 5, nil, meta)
+	require.NoError(t, err)
+	_, _, err = stocks.Set(ctx, "apple", 12, false, nil, meta)
+	require.NoError(t, err)
+	// 条件を満たさず更新されなかった場合はイベントを追加しない
+	_, err = stocks.Allocate(ctx, "apple", 20, nil, meta)
+	require.ErrorIs(t, err, errInsufficientStock)
+
+	if events := dynamoTestEvents(t, stocks); assert.Len(t, events, 2) {
+		assert.Equal(t, StockEventData{Name: "apple", Location: defaultLocation, Delta: 5, Amount: 15, Reason: movementReceipt, Actor: "tester"}, events[0])
+		assert.Equal(t, StockEventData{Name: "apple", Location: defaultLocation, Delta: -3, Amount: 12, Reason: movementReceipt, Actor: "tester"}, events[1])
+	}
+	// 配信したイベントは outbox から削除される
+	assert.Empty(t, dynamoTestEvents(t, stocks))
+
+	// 配信に失敗したイベントは試行回数を記録して残し、それ以降のイベントも次の配信まで残す
+	_, err = stocks.Upsert(ctx, "apple", 1, nil, meta)
+	require.NoError(t, err)
+	_, err = stocks.Upsert(ctx, "banana", 1, nil, meta)
+	require.NoError(t, err)
+	publisher := &failingPublisher{failAt: 1}
+	published, _, err := stocks.RelayBatch(ctx, publisher)
+	assert.EqualError(t, err, "event bus is unavailable")
+	assert.Zero(t, published)
+	var attempts []int64
+	for _, item := range fake.ledger {
+		if strings.HasPrefix(dynamoItemString(item, "pk"), dynamoOutboxPartition) {
+			attempts = append(attempts, fakeNumber(item["attempts"]))
+		}
+	}
+	assert.ElementsMatch(t, []int64{1, 0}, attempts)
+	if events := dynamoTestEvents(t, stocks); assert.Len(t, events, 2) {
+		assert.Equal(t, "apple", events[0].Name)
+		assert.Equal(t, "banana", events[1].Name)
+	}
+}
+
+func TestDynamoStockRepositoryUpsertAll(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeDynamoDB(Stock{Name: "apple", Amount: 10})
+	stocks := newDynamoStockRepository(fake, defaultDynamoStockTable, defaultDynamoLedgerTable)
+	meta := MovementMeta{Reason: movementReceipt, Actor: "tester"}
+
+	// 読み込んだ後に在庫が更新された場合は、読み込みからやり直す
+	concurrent := 1
+	fake.beforeTransactWrite = func(items map[string]map[string]types.AttributeValue) {
+		if concurrent > 0 {
+			concurrent--
+			items["apple"]["amount"] = dynamoNumber(11)
+			items["apple"]["version"] = dynamoNumber(2)
+		}
+	}
+	amounts, err := stocks.UpsertAll(ctx, []Stock{{Name: "apple", Amount: 2}, {Name: "banana", Amount: 1}, {Name: "apple", Amount: 3}}, meta)
+	require.NoError(t, err)
+	assert.Equal(t, []int{13, 1, 16}, amounts)
+
+	// 在庫移動は加算ごとに記録するため、同じ在庫を 2 回加算するとバージョンも 2 つ進む
+	apple, err := stocks.Get(ctx, "apple", false)
+	require.NoError(t, err)
+	assert.Equal(t, 16, apple.Amount)
+	assert.Equal(t, int64(4), apple.Version)
+	if events := dynamoTestEvents(t, stocks); assert.Len(t, events, 3) {
+		assert.Equal(t, StockEventData{Name: "apple", Location: defaultLocation, Delta: 2, Amount: 13, Reason: movementReceipt, Actor: "tester"}, events[slices.IndexFunc(events, func(e StockEventData) bool { return e.Delta == 2 })])
+		assert.Equal(t, StockEventData{Name: "apple", Location: defaultLocation, Delta: 3, Amount: 16, Reason: movementReceipt, Actor: "tester"}, events[slices.IndexFunc(events, func(e StockEventData) bool { return e.Delta == 3 })])
+	}
+	movements, err := stocks.Movements(ctx, "apple", 0, 10)
+	require.NoError(t, err)
+	if assert.Len(t, movements, 2) {
+		assert.Equal(t, []int64{4, 3}, []int64{movements[0].ID, movements[1].ID})
+	}
+
+	// 更新され続ける場合はやり直しをあきらめ、在庫移動も記録しない
+	fake.beforeTransactWrite = func(items map[string]map[string]types.AttributeValue) {
+		items["apple"]["version"] = dynamoNumber(fakeNumber(items["apple"]["version"]) + 1)
+	}
+	_, err = stocks.UpsertAll(ctx, []Stock{{Name: "apple", Amount: 1}}, meta)
+	assert.ErrorIs(t, err, errDynamoConditionFailed)
+	assert.Empty(t, dynamoTestEvents(t, stocks))
+
+	// 在庫移動と outbox のイベントも同じトランザクションで書き込むため、加算が多すぎる場合は書き込まない
+	items := make([]Stock, dynamoMaxTransactItems/3+1)
+	for i := range items {
+		items[i] = Stock{Name: fmt.Sprintf("stock-%d", i), Amount: 1}
+	}
+	_, err = stocks.UpsertAll(ctx, items, meta)
+	assert.ErrorIs(t, err, errBatchTooLarge)
+}
+
+func TestDynamoStockRepositoryLedger(t *testing.T) {
+	ctx := context.Background()
+	stocks := newDynamoStockRepository(newFakeDynamoDB(Stock{Name: "apple", Amount: 10}), defaultDynamoStockTable, defaultDynamoLedgerTable)
+	reorderPoint, safetyStock := 5, 2
+	require.NoError(t, stocks.SetThresholds(ctx, StockThresholds{Name: "apple", ReorderPoint: &reorderPoint, SafetyStock: &safetyStock}))
+
+	_, _, err := stocks.Adjust(ctx, "apple", AdjustmentRequest{Delta: -6, Reason: "damage"}, nil, MovementMeta{Reason: "damage"})
+	require.NoError(t, err)
+	_, err = stocks.Allocate(ctx, "apple", 3, nil, MovementMeta{Reason: movementAllocation})
+	require.NoError(t, err)
+
+	// 在庫移動は在庫のバージョンを ID として新しい順に読み込む
+	movements, err := stocks.Movements(ctx, "apple", 0, 10)
+	require.NoError(t, err)
+	var recorded []string
+	for _, m := range movements {
+		recorded = append(recorded, fmt.Sprintf("%d %d %d %s", m.ID, m.Delta, m.AmountAfter, m.Reason))
+	}
+	assert.Equal(t, []string{"3 -3 1 " + movementAllocation, "2 -6 4 damage"}, recorded)
+	movements, err = stocks.Movements(ctx, "apple", 3, 10)
+	require.NoError(t, err)
+	assert.Len(t, movements, 1)
+
+	// 下回ったしきい値ごとに在庫アラートが記録される
+	alerts, err := stocks.Alerts(ctx, "open", "apple", 0, 10)
+	require.NoError(t, err)
+	var opened []string
+	for _, alert := range alerts {
+		opened = append(opened, fmt.Sprintf("%d %s %d %d", alert.ID, alert.Kind, alert.Threshold, alert.Amount))
+	}
+	assert.Equal(t, []string{"2 " + alertSafetyStock + " 2 1", "1 " + alertReorderPoint + " 5 4"}, opened)
+
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	acknowledged, err := stocks.AcknowledgeAlert(ctx, 1, "tester", now)
+	require.NoError(t, err)
+	assert.Equal(t, "tester", acknowledged.AcknowledgedBy)
+	// 確認済みのアラートを再度確認しても、最初に確認した日時と操作者を返す
+	again, err := stocks.AcknowledgeAlert(ctx, 1, "other", now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, acknowledged, again)
+	_, err = stocks.AcknowledgeAlert(ctx, 99, "tester", now)
+	assert.ErrorIs(t, err, errAlertNotFound)
+
+	alerts, err = stocks.Alerts(ctx, "acknowledged", "", 0, 10)
+	require.NoError(t, err)
+	if assert.Len(t, alerts, 1) {
+		assert.Equal(t, int64(1), alerts[0].ID)
+	}
+	alerts, err = stocks.Alerts(ctx, "open", "banana", 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, alerts)
+}
+
+func TestDynamoStockRepositoryIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	fake := newFakeDynamoDB()
+	stocks := newDynamoStockRepository(fake, defaultDynamoStockTable, defaultDynamoLedgerTable)
+	router := gin.New()
+	router.POST("/stocks", idempotencyMiddleware(idempotencyStoreFor(nil, stocks)), postStocksHandler(stocks))
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/stocks", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "key-1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := post(`{"name":"apple","amount":5}`)
+	require.Equal(t, http.StatusOK, first.Code, first.Body.String())
+	// 同じキーのリクエストは加算せずに保存したレスポンスを返す
+	second := post(`{"name":"apple","amount":5}`)
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+	apple, err := stocks.Get(context.Background(), "apple", false)
+	require.NoError(t, err)
+	assert.Equal(t, 5, apple.Amount)
+
+	// 異なるリクエストに同じキーを使うと 422
+	w := post(`{"name":"apple","amount":6}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+}
+
+func TestSQLStocksOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name         string
+		stocks       StockRepository
+		expectedCode int
+		expectedBody string
+	}{
+		{name: "DynamoDBの在庫では501", stocks: newDynamoStockRepository(newFakeDynamoDB(), defaultDynamoStockTable, defaultDynamoLedgerTable),
+			expectedCode: http.StatusNotImplemented, expectedBody: `{"error":"This endpoint is not available when stocks are stored in DynamoDB"}`},
+		{name: "SQLの在庫ではハンドラーを呼び出す", stocks: newStockRepository(newSQLiteDB(t)),
+			expectedCode: http.StatusOK, expectedBody: `{"ok":true}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := gin.New()
+			router.POST("/orders", sqlStocksOnly(tc.stocks), func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"ok": true})
+			})
+
+			req, _ := http.NewRequest(http.MethodPost, "/orders", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.JSONEq(t, tc.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestDynamoStockTableDefinition(t *testing.T) {
+	data, err := os.ReadFile("dynamodb_stocks_table.json")
+	require.NoError(t, err)
+	var table dynamodb.CreateTableInput
+	require.NoError(t, json.Unmarshal(data, &table))
+
+	assert.Equal(t, defaultDynamoStockTable, aws.ToString(table.TableName))
+	// 一覧で使うインデックスがすべて定義されていること
+	for sort, index := range dynamoStockIndexes {
+		i := slices.IndexFunc(table.GlobalSecondaryIndexes, func(gsi types.GlobalSecondaryIndex) bool {
+			return aws.ToString(gsi.IndexName) == index.Name
+		})
+		if !assert.NotEqual(t, -1, i, sort) {
+			continue
+		}
+		keys := table.GlobalSecondaryIndexes[i].KeySchema
+		if assert.Len(t, keys, 2) {
+			assert.Equal(t, "gsi_pk", aws.ToString(keys[0].AttributeName))
+			assert.Equal(t, index.SortKey, aws.ToString(keys[1].AttributeName))
+		}
+	}
+}
+
+func TestDynamoStockTableTemplate(t *testing.T) {
+	data, err := os.ReadFile("template.yaml")
+	require.NoError(t, err)
+	var template struct {
+		Conditions map[string]any `yaml:"Conditions"`
+		Resources  map[string]struct {
+			Type       string         `yaml:"Type"`
+			Condition  string         `yaml:"Condition"`
+			Properties map[string]any `yaml:"Properties"`
+		} `yaml:"Resources"`
+	}
+	require.NoError(t, yaml.Unmarshal(data, &template))
+	require.Contains(t, template.Conditions, "UseDynamoDBStocks")
+
+	tests := []struct {
+		name       string
+		resource   string
+		definition string
+		ttl        map[string]any
+	}{
+		{name: "在庫のテーブル", resource: "StocksTable", definition: "dynamodb_stocks_table.json"},
+		{name: "台帳のテーブル", resource: "StockLedgerTable", definition: "dynamodb_ledger_table.json",
+			ttl: map[string]any{"AttributeName": "ttl", "Enabled": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := os.ReadFile(tt.definition)
+			require.NoError(t, err)
+			var expected dynamodb.CreateTableInput
+			require.NoError(t, json.Unmarshal(data, &expected))
+
+			resource, ok := template.Resources[tt.resource]
+			require.True(t, ok, "template.yaml に %s がありません", tt.resource)
+			assert.Equal(t, "AWS::DynamoDB::Table", resource.Type)
+			// StockStore=sql ではテーブルを作成しない
+			assert.Equal(t, "UseDynamoDBStocks", resource.Condition)
+
+			// TTL は CreateTable ではなく UpdateTimeToLive で設定するため、CreateTableInput とは別に比べる
+			var ttl map[string]any
+			if spec, ok := resource.Properties["TimeToLiveSpecification"]; ok {
+				ttl, _ = spec.(map[string]any)
+			}
+			assert.Equal(t, tt.ttl, ttl)
+
+			// テーブル名は CloudFormation が決めるため、それ以外の定義が JSON のテーブル定義と一致すること
+			properties := maps.Clone(resource.Properties)
+			delete(properties, "TimeToLiveSpecification")
+			data, err = json.Marshal(properties)
+			require.NoError(t, err)
+			var actual dynamodb.CreateTableInput
+			require.NoError(t, json.Unmarshal(data, &actual))
+			expected.TableName = nil
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestStockRepositoryFromEnv(t *testing.T) {
+	db, _ := NewMockDB(t)
+	defer db.Close()
+	sqlDB := &SQLDB{DB: db}
+
+	t.Run("未指定の場合はSQLデータベース", func(t *testing.T) {
+		t.Setenv("STOCK_STORE", "")
+		stocks, err := stockRepositoryFromEnv(sqlDB)
+		require.NoError(t, err)
+		assert.IsType(t, &mysqlStockRepository{}, stocks)
+	})
+
+	t.Run("未知の保存先はエラー", func(t *testing.T) {
+		t.Setenv("STOCK_STORE", "unknown")
+		stocks, err := stockRepositoryFromEnv(sqlDB)
+		assert.EqualError(t, err, `unknown STOCK_STORE "unknown"`)
+		assert.Nil(t, stocks)
+	})
+
+	t.Run("DynamoDB", func(t *testing.T) {
+		t.Setenv("STOCK_STORE", "dynamodb")
+		t.Setenv("AWS_REGION", "ap-northeast-1")
+		t.Setenv("DYNAMODB_TABLE", "stock-table")
+		stocks, err := stockRepositoryFromEnv(sqlDB)
+		require.NoError(t, err)
+		if assert.IsType(t, &dynamoStockRepository{}, stocks) {
+			assert.Equal(t, "stock-table", stocks.(*dynamoStockRepository).table)
+			assert.Equal(t, defaultDynamoLedgerTable, stocks.(*dynamoStockRepository).ledgerTable)
+		}
+	})
+
+	t.Run("DynamoDBのリージョンがない場合はエラー", func(t *testing.T) {
+		t.Setenv("STOCK_STORE", "dynamodb")
+		t.Setenv("AWS_REGION", "")
+		t.Setenv("AWS_DEFAULT_REGION", "")
+		t.Setenv("AWS_CONFIG_FILE", os.DevNull)
+		stocks, err := stockRepositoryFromEnv(sqlDB)
+		assert.EqualError(t, err, "failed to configure DynamoDB: AWS_REGION is not set")
+		assert.Nil(t, stocks)
+	})
+}
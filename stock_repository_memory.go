@@ -0,0 +1,339 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memoryStockRepository はメモリ上で在庫を管理する StockRepository です。
+// DB を使わずに在庫のハンドラーをテストするために使います。
+// 引当予約、商品の紐付け、在庫移動の記録と在庫イベントの発行は扱いません（Reserved は常に 0 です）。
+type memoryStockRepository struct {
+	mu         sync.Mutex
+	stocks     map[string]Stock
+	thresholds map[string]StockThresholds
+	now        func() time.Time
+}
+
+// newMemoryStockRepository は stocks を登録したリポジトリを作成します。
+// バージョンが 0 の在庫は 1 として登録します。
+func newMemoryStockRepository(stocks ...Stock) *memoryStockRepository {
+	r := &memoryStockRepository{stocks: make(map[string]Stock), thresholds: make(map[string]StockThresholds), now: time.Now}
+	for _, stock := range stocks {
+		if stock.Version == 0 {
+			stock.Version = 1
+		}
+		stock.Available = stock.Amount - stock.Reserved
+		r.stocks[stock.Name] = stock
+	}
+	return r
+}
+
+func (r *memoryStockRepository) Get(ctx context.Context, name string, includeDeleted bool) (Stock, error) {
+	if err := ctx.Err(); err != nil {
+		return Stock{}, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stock, ok := r.stocks[name]
+	if !ok || (stock.DeletedAt != nil && !includeDeleted) {
+		return Stock{}, errStockNotFound
+	}
+	return stock, nil
+}
+
+func (r *memoryStockRepository) List(ctx context.Context, opts StockListOptions) ([]Stock, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var after *Stock
+	if opts.After != nil {
+		cursor, err := opts.After.stock()
+		if err != nil {
+			return nil, err
+		}
+		after = &cursor
+	}
+
+	var stocks []Stock
+	for _, stock := range r.stocks {
+		if opts.matches(stock) && (after == nil || opts.compare(stock, *after) > 0) {
+			stocks = append(stocks, stock)
+		}
+	}
+	slices.SortFunc(stocks, opts.compare)
+	if opts.Limit > 0 && len(stocks) > opts.Limit {
+		stocks = stocks[:opts.Limit]
+	}
+	return stocks, nil
+}
+
+func (r *memoryStockRepository) Upsert(ctx context.Context, name string, amount int, match *versionMatch, meta MovementMeta) (Stock, error) {
+	return r.update(ctx, name, match, func(current Stock, exists bool) (Stock, error) {
+		if !exists {
+			return Stock{Name: name, Amount: amount, Version: 1}, nil
+		}
+		if current.DeletedAt != nil {
+			return current, errStockDeleted
+		}
+		current.Amount += amount
+		current.Version++
+		return current, nil
+	})
+}
+
+func (r *memoryStockRepository) UpsertAll(ctx context.Context, items []Stock, meta MovementMeta) ([]int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// 全ての在庫を加算できることを確認してから保存する
+	updated := make(map[string]Stock)
+	amounts := make([]int, len(items))
+	for i, item := range items {
+		current, exists := updated[item.Name]
+		if !exists {
+			current, exists = r.stocks[item.Name]
+		}
+		switch {
+		case !exists:
+			current = Stock{Name: item.Name, Amount: item.Amount, Version: 1}
+		case current.DeletedAt != nil:
+			return nil, &batchItemError{Index: i, Err: errStockDeleted}
+		default:
+			current.Amount += item.Amount
+			current.Version++
+		}
+		now := r.now()
+		current.UpdatedAt = &now
+		current.Available = current.Amount - current.Reserved
+		updated[item.Name] = current
+		amounts[i] = current.Amount
+	}
+	maps.Copy(r.stocks, updated)
+	return amounts, nil
+}
+
+func (r *memoryStockRepository) Set(ctx context.Context, name string, amount int, createOnly bool, match *versionMatch, meta MovementMeta) (Stock, bool, error) {
+	created := false
+	stock, err := r.update(ctx, name, match, func(current Stock, exists bool) (Stock, error) {
+		switch {
+		case !exists:
+			created = true
+			return Stock{Name: name, Amount: amount, Version: 1}, nil
+		case current.DeletedAt != nil:
+			return current, errStockDeleted
+		case createOnly:
+			return current, errStockExists
+		}
+		current.Amount = amount
+		current.Version++
+		return current, nil
+	})
+	return stock, created, err
+}
+
+func (r *memoryStockRepository) Adjust(ctx context.Context, name string, req AdjustmentRequest, match *versionMatch, meta MovementMeta) (AdjustmentResult, int64, error) {
+	result := AdjustmentResult{Name: name, Delta: req.Delta, Reason: req.Reason}
+	stock, err := r.update(ctx, name, match, func(current Stock, exists bool) (Stock, error) {
+		if !exists || current.DeletedAt != nil {
+			return current, fmt.Errorf("%w: %s", errStockNotFound, name)
+		}
+		result.PreviousAmount = current.Amount
+		result.Amount = current.Amount + req.Delta
+		if result.Amount < 0 && !req.AllowNegative {
+			return current, errInsufficientStock
+		}
+		current.Amount = result.Amount
+		current.Version++
+		return current, nil
+	})
+	return result, stock.Version, err
+}
+
+func (r *memoryStockRepository) Delete(ctx context.Context, name string, match *versionMatch, meta MovementMeta) error {
+	_, err := r.update(ctx, name, match, func(current Stock, exists bool) (Stock, error) {
+		if !exists || current.DeletedAt != nil {
+			return current, fmt.Errorf("%w: %s", errStockNotFound, name)
+		}
+		if current.Reserved > 0 {
+			return current, errStockReserved
+		}
+		now := r.now()
+		current.DeletedAt = &now
+		current.Version++
+		return current, nil
+	})
+	return err
+}
+
+func (r *memoryStockRepository) Restore(ctx context.Context, name string, match *versionMatch, meta MovementMeta) (Stock, error) {
+	return r.update(ctx, name, match, func(current Stock, exists bool) (Stock, error) {
+		if !exists {
+			return current, errStockNotFound
+		}
+		if current.DeletedAt != nil {
+			current.DeletedAt = nil
+			current.Version++
+		}
+		return current, nil
+	})
+}
+
+func (r *memoryStockRepository) Allocate(ctx context.Context, name string, amount int, match *versionMatch, meta MovementMeta) (Stock, error) {
+	var insufficient Stock
+	stock, err := r.update(ctx, name, match, func(current Stock, exists bool) (Stock, error) {
+		if !exists || current.DeletedAt != nil {
+			return current, fmt.Errorf("%w: %s", errStockNotFound, name)
+		}
+		if current.Amount-current.Reserved < amount {
+			insufficient = current
+			return current, errInsufficientStock
+		}
+		current.Amount -= amount
+		current.Version++
+		return current, nil
+	})
+	if errors.Is(err, errInsufficientStock) {
+		return insufficient, err
+	}
+	return stock, err
+}
+
+func (r *memoryStockRepository) Thresholds(ctx context.Context, name string) (StockThresholds, error) {
+	if err := ctx.Err(); err != nil {
+		return StockThresholds{}, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stock, ok := r.stocks[name]; !ok || stock.DeletedAt != nil {
+		return StockThresholds{}, errStockNotFound
+	}
+	thresholds := r.thresholds[name]
+	thresholds.Name = name
+	return thresholds, nil
+}
+
+func (r *memoryStockRepository) SetThresholds(ctx context.Context, thresholds StockThresholds) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stock, ok := r.stocks[thresholds.Name]; !ok || stock.DeletedAt != nil {
+		return errStockNotFound
+	}
+	r.thresholds[thresholds.Name] = thresholds
+	return nil
+}
+
+// update は在庫をロックした状態で fn を呼び出し、fn がエラーを返さなければ結果を保存して返します。
+// match が指定された場合は、在庫が存在してバージョンが一致する場合のみ fn を呼び出します。
+func (r *memoryStockRepository) update(ctx context.Context, name string, match *versionMatch, fn func(current Stock, exists bool) (Stock, error)) (Stock, error) {
+	if err := ctx.Err(); err != nil {
+		return Stock{}, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, exists := r.stocks[name]
+	if match != nil && (!exists || !match.matches(current.Version)) {
+		return Stock{}, errPreconditionFailed
+	}
+	stock, err := fn(current, exists)
+	if err != nil {
+		return Stock{}, err
+	}
+	if stock.Version != current.Version {
+		now := r.now()
+		stock.UpdatedAt = &now
+	}
+	stock.Available = stock.Amount - stock.Reserved
+	r.stocks[name] = stock
+	return stock, nil
+}
+
+// matches は在庫が一覧の絞り込み条件を満たすかを返します。並び順とカーソルの条件は含みません。
+func (opts StockListOptions) matches(stock Stock) bool {
+	switch {
+	case stock.DeletedAt != nil && !opts.IncludeDeleted:
+		return false
+	case opts.Prefix != "" && !strings.HasPrefix(stock.Name, opts.Prefix):
+		return false
+	case opts.Contains != "" && !strings.Contains(stock.Name, opts.Contains):
+		return false
+	case opts.MinAmount != nil && stock.Amount < *opts.MinAmount:
+		return false
+	case opts.MaxAmount != nil && stock.Amount > *opts.MaxAmount:
+		return false
+	case opts.UpdatedSince != nil && (stock.UpdatedAt == nil || stock.UpdatedAt.Before(*opts.UpdatedSince)):
+		return false
+	}
+	return true
+}
+
+// compare は orderBy と同じ順序で在庫を比較します。
+// 名前順以外では、並べ替えキーが同じ在庫を名前の昇順で並べます。
+func (opts StockListOptions) compare(a, b Stock) int {
+	column, desc := opts.sortColumn()
+	var c int
+	switch column {
+	case "s.amount":
+		c = cmp.Compare(a.Amount, b.Amount)
+	case "s.updated_at":
+		c = compareTimes(a.UpdatedAt, b.UpdatedAt)
+	default:
+		c = strings.Compare(a.Name, b.Name)
+	}
+	if desc {
+		c = -c
+	}
+	if c != 0 || column == "s.name" {
+		return c
+	}
+	return strings.Compare(a.Name, b.Name)
+}
+
+// compareTimes は時刻を比較します。nil は他のどの時刻よりも前として扱います。
+func compareTimes(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	return a.Compare(*b)
+}
+
+// stock はカーソルの位置にある在庫を、並べ替えキーと名前だけを持つ Stock として返します。
+func (c stockCursor) stock() (Stock, error) {
+	value, err := c.parseValue()
+	if err != nil {
+		return Stock{}, errInvalidCursor
+	}
+	stock := Stock{Name: c.Name}
+	switch v := value.(type) {
+	case int:
+		stock.Amount = v
+	case time.Time:
+		stock.UpdatedAt = &v
+	}
+	return stock, nil
+}
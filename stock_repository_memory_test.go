@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// newStockRouter は在庫のハンドラーだけを登録したルーターを作成します。
+func newStockRouter(stocks StockRepository) *gin.Engine {
+	router := gin.New()
+	router.GET("/stocks", getAllStocksHandler(stocks))
+	router.GET("/stocks/:name", getStocksHandler(stocks))
+	router.POST("/stocks", postStocksHandler(stocks))
+	router.PUT("/stocks/:name", putStockHandler(stocks))
+	router.PATCH("/stocks/:name", adjustStockHandler(stocks))
+	router.DELETE("/stocks/:name", deleteStockHandler(stocks))
+	router.POST("/stocks/:name/restore", restoreStockHandler(stocks))
+	router.POST("/stocks/:name/allocate", allocateStockHandler(stocks))
+	router.POST("/stocks:method", customMethod("batch"), batchStocksHandler(stocks))
+	router.GET("/stocks/:name/thresholds", getStockThresholdsHandler(stocks))
+	router.PUT("/stocks/:name/thresholds", putStockThresholdsHandler(stocks))
+	return router
+}
+
+func TestStockHandlersWithMemoryRepository(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	deletedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	seed := func() *memoryStockRepository {
+		return newMemoryStockRepository(
+			Stock{Name: "apple", Amount: 10, Version: 3},
+			Stock{Name: "banana", Amount: 3},
+			Stock{Name: "cherry", Amount: 5, DeletedAt: &deletedAt},
+		)
+	}
+
+	testCases := []struct {
+		name         string
+		method       string
+		path         string
+		requestBody  string
+		ifMatch      string
+		expectedCode int
+		expectedBody string
+		expectedETag string
+	}{
+		{name: "在庫を取得する", method: http.MethodGet, path: "/stocks/apple",
+			expectedCode: http.StatusOK, expectedBody: `[{"name":"apple","amount":10,"reserved":0,"available":10}]`, expectedETag: `"3"`},
+		{name: "存在しない在庫はメッセージを返す", method: http.MethodGet, path: "/stocks/durian",
+			expectedCode: http.StatusOK, expectedBody: `{"message":"データが存在しません"}`},
+		{name: "論理削除された在庫はinclude_deletedで取得する", method: http.MethodGet, path: "/stocks/cherry?include_deleted=true",
+			expectedCode: http.StatusOK, expectedBody: `[{"name":"cherry","amount":5,"reserved":0,"available":5,"deleted_at":"2026-01-02T03:04:05Z"}]`},
+		{name: "既存の在庫に加算する", method: http.MethodPost, path: "/stocks", requestBody: `{"name":"apple","amount":5}`,
+			expectedCode: http.StatusOK, expectedETag: `"4"`},
+		{name: "存在しない在庫は作成する", method: http.MethodPost, path: "/stocks", requestBody: `{"name":"durian"}`,
+			expectedCode: http.StatusOK, expectedETag: `"1"`},
+		{name: "論理削除された在庫への加算は409", method: http.MethodPost, path: "/stocks", requestBody: `{"name":"cherry","amount":1}`,
+			expectedCode: http.StatusConflict, expectedBody: `{"error":"stock is deleted","name":"cherry"}`},
+		{name: "If-Matchが一致しなければ412", method: http.MethodPost, path: "/stocks", requestBody: `{"name":"apple","amount":1}`, ifMatch: `"2"`,
+			expectedCode: http.StatusPreconditionFailed, expectedBody: `{"error":"precondition failed"}`},
+		{name: "存在しない在庫を指定した値で作成して201", method: http.MethodPut, path: "/stocks/durian", requestBody: `{"amount":0}`,
+			expectedCode: http.StatusCreated, expectedETag: `"1"`},
+		{name: "create_onlyで既存の在庫は409", method: http.MethodPut, path: "/stocks/apple?create_only=true", requestBody: `{"amount":1}`,
+			expectedCode: http.StatusConflict, expectedBody: `{"error":"stock already exists","name":"apple"}`},
+		{name: "在庫数が負になる調整は409", method: http.MethodPatch, path: "/stocks/banana", requestBody: `{"delta":-4,"reason":"damage"}`,
+			expectedCode: http.StatusConflict, expectedBody: `{"error":"insufficient stock","name":"banana","amount":3,"delta":-4}`},
+		{name: "在庫を調整する", method: http.MethodPatch, path: "/stocks/banana", requestBody: `{"delta":-3,"reason":"damage"}`,
+			expectedCode: http.StatusOK, expectedBody: `{"name":"banana","delta":-3,"reason":"damage","previous_amount":3,"amount":0}`, expectedETag: `"2"`},
+		{name: "在庫を論理削除する", method: http.MethodDelete, path: "/stocks/apple",
+			expectedCode: http.StatusNoContent},
+		{name: "論理削除済みの在庫の削除は404", method: http.MethodDelete, path: "/stocks/cherry",
+			expectedCode: http.StatusNotFound, expectedBody: `{"error":"stock not found: cherry"}`},
+		{name: "論理削除された在庫を元に戻す", method: http.MethodPost, path: "/stocks/cherry/restore",
+			expectedCode: http.StatusOK, expectedETag: `"2"`},
+		{name: "在庫を引き当てる", method: http.MethodPost, path: "/stocks/apple/allocate", requestBody: `{"amount":4}`,
+			expectedCode: http.StatusOK, expectedBody: `{"name":"apple","allocated":4,"amount":6,"available":6}`, expectedETag: `"4"`},
+		{name: "引当可能数を超える引き当ては409", method: http.MethodPost, path: "/stocks/banana/allocate", requestBody: `{"amount":4}`,
+			expectedCode: http.StatusConflict, expectedBody: `{"error":"insufficient stock","name":"banana","requested":4,"available":3}`},
+		{name: "論理削除された在庫の引き当ては404", method: http.MethodPost, path: "/stocks/cherry/allocate", requestBody: `{"amount":1}`,
+			expectedCode: http.StatusNotFound, expectedBody: `{"error":"stock not found: cherry"}`},
+		{name: "在庫ごとに一括で加算する", method: http.MethodPost, path: "/stocks:batch", requestBody: `[{"name":"cherry","amount":1},{"name":"apple","amount":2},{"name":"durian"}]`,
+			expectedCode: http.StatusOK, expectedBody: `{"atomic":false,"succeeded":2,"failed":1,"results":[{"index":0,"name":"cherry","status":409,"error":"stock is deleted"},{"index":1,"name":"apple","status":200,"amount":12},{"index":2,"name":"durian","status":200,"amount":1}]}`},
+		{name: "atomicで同じ在庫を複数回加算する", method: http.MethodPost, path: "/stocks:batch?atomic=true", requestBody: `[{"name":"apple","amount":2},{"name":"durian"},{"name":"apple","amount":3}]`,
+			expectedCode: http.StatusOK, expectedBody: `{"atomic":true,"succeeded":3,"failed":0,"results":[{"index":0,"name":"apple","status":200,"amount":12},{"index":1,"name":"durian","status":200,"amount":1},{"index":2,"name":"apple","status":200,"amount":15}]}`},
+		{name: "atomicで論理削除された在庫を含む場合は何も加算せず409", method: http.MethodPost, path: "/stocks:batch?atomic=true", requestBody: `[{"name":"apple","amount":2},{"name":"cherry","amount":1}]`,
+			expectedCode: http.StatusConflict, expectedBody: `{"error":"batch item failed: index 1: stock is deleted","results":[{"index":0,"name":"apple","status":424,"error":"not applied"},{"index":1,"name":"cherry","status":409,"error":"stock is deleted"}]}`},
+		{name: "しきい値を設定する", method: http.MethodPut, path: "/stocks/apple/thresholds", requestBody: `{"reorder_point":5,"safety_stock":2}`,
+			expectedCode: http.StatusOK, expectedBody: `{"name":"apple","reorder_point":5,"safety_stock":2}`},
+		{name: "しきい値を設定していない在庫はnullを返す", method: http.MethodGet, path: "/stocks/apple/thresholds",
+			expectedCode: http.StatusOK, expectedBody: `{"name":"apple","reorder_point":null,"safety_stock":null}`},
+		{name: "論理削除された在庫のしきい値は404", method: http.MethodPut, path: "/stocks/cherry/thresholds", requestBody: `{"reorder_point":5}`,
+			expectedCode: http.StatusNotFound, expectedBody: `{"error":"stock not found"}`},
+		{name: "存在しない在庫のしきい値は404", method: http.MethodGet, path: "/stocks/durian/thresholds",
+			expectedCode: http.StatusNotFound, expectedBody: `{"error":"stock not found"}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newStockRouter(seed())
+
+			req, _ := http.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, w.Body.String())
+			}
+			if tc.expectedETag != "" {
+				assert.Equal(t, tc.expectedETag, w.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestMemoryStockRepositoryWrites(t *testing.T) {
+	ctx := context.Background()
+	stocks := newMemoryStockRepository()
+
+	stock, err := stocks.Upsert(ctx, "apple", 5, nil, MovementMeta{})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, stock.Amount)
+	assert.NotNil(t, stock.UpdatedAt)
+
+	stock, created, err := stocks.Set(ctx, "apple", 2, false, &versionMatch{Versions: []int64{1}}, MovementMeta{})
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, Stock{Name: "apple", Amount: 2, Available: 2, Version: 2, UpdatedAt: stock.UpdatedAt}, stock)
+
+	assert.NoError(t, stocks.Delete(ctx, "apple", nil, MovementMeta{}))
+	_, err = stocks.Get(ctx, "apple", false)
+	assert.ErrorIs(t, err, errStockNotFound)
+
+	stock, err = stocks.Restore(ctx, "apple", nil, MovementMeta{})
+	assert.NoError(t, err)
+	assert.Nil(t, stock.DeletedAt)
+	assert.Equal(t, int64(4), stock.Version)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = stocks.Upsert(canceled, "apple", 1, nil, MovementMeta{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestMemoryStockRepositoryList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	stocks := newMemoryStockRepository(
+		Stock{Name: "apple", Amount: 10},
+		Stock{Name: "banana", Amount: 3},
+		Stock{Name: "blueberry", Amount: 10},
+		Stock{Name: "cherry", Amount: 7},
+	)
+	router := newStockRouter(stocks)
+
+	// 在庫数の降順で 2 件ずつ取得し、同じ在庫数は名前の昇順で並ぶことを確認する
+	var names []string
+	path := "/stocks?sort=-amount&limit=2"
+	for i := 0; i < 3 && path != ""; i++ {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if !assert.Equal(t, http.StatusOK, w.Code) {
+			return
+		}
+
+		var page StockPage
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		for _, stock := range page.Stocks {
+			names = append(names, stock.Name)
+		}
+		path = ""
+		if page.NextCursor != "" {
+			path = "/stocks?sort=-amount&limit=2&cursor=" + page.NextCursor
+		}
+	}
+	assert.Equal(t, []string{"apple", "blueberry", "cherry", "banana"}, names)
+
+	minAmount := 5
+	list, err := stocks.List(context.Background(), StockListOptions{Prefix: "b", MinAmount: &minAmount})
+	assert.NoError(t, err)
+	if assert.Len(t, list, 1) {
+		assert.Equal(t, "blueberry", list[0].Name)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// postgresStockRepository は PostgreSQL の stocks テーブルを使う StockRepository です。
+// db には Driver が driverPostgres の SQLDB を渡してください。
+// SQLite も ON CONFLICT と RETURNING に対応しているため、Driver が driverSQLite の SQLDB にも使います。
+// 在庫の加算以外は MySQL と共通のヘルパーを使い、SQLDB がプレースホルダを置き換えて実行します。
+// stocks.updated_at は MySQL の ON UPDATE の代わりにトリガーが更新します。
+type postgresStockRepository struct {
+	db Storer
+}
+
+func newPostgresStockRepository(db Storer) *postgresStockRepository {
+	return &postgresStockRepository{db: db}
+}
+
+func (r *postgresStockRepository) Get(ctx context.Context, name string, includeDeleted bool) (Stock, error) {
+	return findStock(ctx, r.db, name, includeDeleted)
+}
+
+func (r *postgresStockRepository) List(ctx context.Context, opts StockListOptions) ([]Stock, error) {
+	return getAllStocks(ctx, r.db, opts)
+}
+
+func (r *postgresStockRepository) Each(ctx context.Context, opts StockListOptions, fn func(Stock) error) error {
+	return eachStock(ctx, r.db, opts, fn)
+}
+
+func (r *postgresStockRepository) Upsert(ctx context.Context, name string, amount int, match *versionMatch, meta MovementMeta) (Stock, error) {
+	return updateStock(ctx, r.db, Stock{Name: name, Amount: amount}, match, meta, addPostgresStock)
+}
+
+func (r *postgresStockRepository) UpsertAll(ctx context.Context, items []Stock, meta MovementMeta) ([]int, error) {
+	return upsertStocks(ctx, r.db, items, func(tx Querier, item Stock, now time.Time) (int, error) {
+		return addPostgresStock(ctx, tx, item, meta, now)
+	})
+}
+
+// addPostgresStock はトランザクション内で在庫数を加算して在庫移動を記録し、加算後の在庫数を返します。
+// 在庫が存在しない場合は作成します。
+// addStock と異なり、加算後の在庫数と削除状態を RETURNING で受け取るため、在庫行を読み直しません。
+func addPostgresStock(ctx context.Context, tx Querier, stockReq Stock, meta MovementMeta, now time.Time) (int, error) {
+	var (
+		amountAfter int
+		deleted     bool
+	)
+	err := tx.QueryRowContext(ctx, "INSERT INTO stocks (name, amount) VALUES (?, ?) "+
+		"ON CONFLICT (name) DO UPDATE SET amount = stocks.amount + EXCLUDED.amount, version = stocks.version + 1 "+
+		"RETURNING amount, deleted_at IS NOT NULL", stockReq.Name, stockReq.Amount).Scan(&amountAfter, &deleted)
+	if err != nil {
+		return 0, err
+	}
+	if deleted {
+		return 0, errStockDeleted
+	}
+	return amountAfter, recordMovement(ctx, tx, stockReq.Name, stockReq.Amount, amountAfter, meta, now)
+}
+
+func (r *postgresStockRepository) Set(ctx context.Context, name string, amount int, createOnly bool, match *versionMatch, meta MovementMeta) (Stock, bool, error) {
+	return setStock(ctx, r.db, name, amount, createOnly, match, meta)
+}
+
+func (r *postgresStockRepository) Adjust(ctx context.Context, name string, req AdjustmentRequest, match *versionMatch, meta MovementMeta) (AdjustmentResult, int64, error) {
+	return adjustStock(ctx, r.db, name, req, match, meta)
+}
+
+func (r *postgresStockRepository) Delete(ctx context.Context, name string, match *versionMatch, meta MovementMeta) error {
+	return deleteStock(ctx, r.db, name, match, meta)
+}
+
+func (r *postgresStockRepository) Restore(ctx context.Context, name string, match *versionMatch, meta MovementMeta) (Stock, error) {
+	return restoreStock(ctx, r.db, name, match, meta)
+}
+
+func (r *postgresStockRepository) Allocate(ctx context.Context, name string, amount int, match *versionMatch, meta MovementMeta) (Stock, error) {
+	return allocateStock(ctx, r.db, name, amount, match, meta)
+}
+
+func (r *postgresStockRepository) Thresholds(ctx context.Context, name string) (StockThresholds, error) {
+	return getStockThresholds(ctx, r.db, name)
+}
+
+func (r *postgresStockRepository) SetThresholds(ctx context.Context, thresholds StockThresholds) error {
+	return setStockThresholds(ctx, r.db, thresholds)
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+// expectPostgresStock は findStock が PostgreSQL で発行する在庫のクエリの期待値を設定します。
+func expectPostgresStock(mock sqlmock.Sqlmock, name string, amount int, version int64) {
+	mock.ExpectQuery("SELECT s.name, s.amount, (.+) FROM stocks s (.+) r.expires_at > \\$1 WHERE s.name = \\$2 AND s.deleted_at IS NULL GROUP BY").
+		WithArgs(sqlmock.AnyArg(), name).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "amount", "reserved", "version", "updated_at", "deleted_at"}).
+			AddRow(name, amount, 0, version, nil, nil))
+}
+
+func TestPostgresStockRepository(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name         string
+		method       string
+		path         string
+		requestBody  string
+		mockSetup    func(mock sqlmock.Sqlmock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:   "在庫を取得する",
+			method: http.MethodGet,
+			path:   "/stocks/apple",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectPostgresStock(mock, "apple", 10, 3)
+				mock.ExpectQuery("SELECT (.+) FROM stocks s JOIN products p ON p.id = s.product_id WHERE s.name = \\$1").
+					WithArgs("apple").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"name":"apple","amount":10,"reserved":0,"available":10}]`,
+		},
+		{
+			name:        "ON CONFLICTで在庫数を加算する",
+			method:      http.MethodPost,
+			path:        "/stocks",
+			requestBody: `{"name":"apple","amount":5}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO stocks \\(name, amount\\) VALUES \\(\\$1, \\$2\\) "+
+					"ON CONFLICT \\(name\\) DO UPDATE SET amount = stocks.amount \\+ EXCLUDED.amount, version = stocks.version \\+ 1 "+
+					"RETURNING amount, deleted_at IS NOT NULL").
+					WithArgs("apple", 5).
+					WillReturnRows(sqlmock.NewRows([]string{"amount", "deleted"}).AddRow(15, false))
+				mock.ExpectExec("INSERT INTO stock_movements (.+) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7, \\$8\\)").
+					WithArgs("apple", defaultLocation, 5, 15, movementReceipt, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO outbox_events (.+) VALUES \\(\\$1, \\$2, \\$3, \\$4\\)").
+					WithArgs(sqlmock.AnyArg(), eventTypeStockChanged, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO webhook_deliveries (.+) SELECT id, \\$1, \\$2, \\$3, \\$4, 0, '', \\$5, \\$6 FROM webhooks WHERE event_types = \\$7 OR (.+) \\$10").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				expectPostgresStock(mock, "apple", 15, 4)
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"name":"apple","amount":15,"reserved":0,"available":15}`,
+		},
+		{
+			name:        "論理削除された在庫への加算はロールバックして409",
+			method:      http.MethodPost,
+			path:        "/stocks",
+			requestBody: `{"name":"cherry","amount":1}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO stocks (.+) ON CONFLICT \\(name\\) DO UPDATE").
+					WithArgs("cherry", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"amount", "deleted"}).AddRow(6, true))
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: `{"error":"stock is deleted","name":"cherry"}`,
+		},
+		{
+			name:        "存在しない在庫を作成して201",
+			method:      http.MethodPut,
+			path:        "/stocks/durian",
+			requestBody: `{"amount":4}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT amount, deleted_at IS NOT NULL FROM stocks WHERE name = \\$1 FOR UPDATE").
+					WithArgs("durian").
+					WillReturnRows(sqlmock.NewRows([]string{"amount", "deleted"}))
+				mock.ExpectExec("INSERT INTO stocks \\(name, amount\\) VALUES \\(\\$1, \\$2\\)$").
+					WithArgs("durian", 4).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRecordMovement(mock, "durian", 4, 4, movementStocktake)
+				expectPostgresStock(mock, "durian", 4, 1)
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusCreated,
+			expectedBody: `{"name":"durian","amount":4,"reserved":0,"available":4}`,
+		},
+		{
+			name:        "同時に作成されて一意制約に違反した場合は409",
+			method:      http.MethodPut,
+			path:        "/stocks/durian",
+			requestBody: `{"amount":4}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT amount, deleted_at IS NOT NULL FROM stocks WHERE name = \\$1 FOR UPDATE").
+					WithArgs("durian").
+					WillReturnRows(sqlmock.NewRows([]string{"amount", "deleted"}))
+				mock.ExpectExec("INSERT INTO stocks").
+					WithArgs("durian", 4).
+					WillReturnError(&pq.Error{Code: "23505"})
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: `{"error":"stock already exists","name":"durian"}`,
+		},
+		{
+			name:        "在庫を調整する",
+			method:      http.MethodPatch,
+			path:        "/stocks/banana",
+			requestBody: `{"delta":-2,"reason":"damage"}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT amount, version FROM stocks WHERE name = \\$1 AND deleted_at IS NULL FOR UPDATE").
+					WithArgs("banana").
+					WillReturnRows(sqlmock.NewRows([]string{"amount", "version"}).AddRow(3, 1))
+				mock.ExpectQuery("SELECT COALESCE\\(SUM\\(amount\\), 0\\) FROM stock_reservations WHERE name = \\$1 AND status = 'active' AND expires_at > \\$2").
+					WithArgs("banana", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"reserved"}).AddRow(0))
+				mock.ExpectExec("UPDATE stocks SET amount = \\$1, version = version \\+ 1 WHERE name = \\$2").
+					WithArgs(1, "banana").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRecordMovement(mock, "banana", -2, 1, "damage")
+				mock.ExpectQuery("SELECT reorder_point, safety_stock FROM stocks WHERE name = \\$1$").
+					WithArgs("banana").
+					WillReturnRows(sqlmock.NewRows([]string{"reorder_point", "safety_stock"}).AddRow(nil, nil))
+				mock.ExpectCommit()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"name":"banana","delta":-2,"reason":"damage","previous_amount":3,"amount":1}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			router := newStockRouter(newStockRepository(&SQLDB{DB: db, Driver: driverPostgres}))
+			req, _ := http.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.JSONEq(t, tc.expectedBody, w.Body.String())
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
+
+func TestNewStockRepository(t *testing.T) {
+	db, _ := NewMockDB(t)
+	defer db.Close()
+
+	assert.IsType(t, &postgresStockRepository{}, newStockRepository(&SQLDB{DB: db, Driver: driverPostgres}))
+	assert.IsType(t, &mysqlStockRepository{}, newStockRepository(&SQLDB{DB: db}))
+	assert.IsType(t, &mysqlStockRepository{}, newStockRepository(NewMockStore()))
+}
+
+func TestUpsertQueriesOnPostgres(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mockSetup func(mock sqlmock.Sqlmock)
+		run       func(tx Querier) error
+	}{
+		{
+			name: "在庫数の加算はON CONFLICTで行う",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO stocks \\(name, amount\\) VALUES \\(\\$1, \\$2\\) ON CONFLICT \\(name\\) DO UPDATE SET amount = stocks.amount \\+ \\$3, version = stocks.version \\+ 1$").
+					WithArgs("apple", 5, 5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT amount, deleted_at IS NOT NULL FROM stocks WHERE name = \\$1$").
+					WithArgs("apple").
+					WillReturnRows(sqlmock.NewRows([]string{"amount", "deleted"}).AddRow(15, false))
+				mock.ExpectExec("INSERT INTO stock_movements").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO outbox_events").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO webhook_deliveries").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			run: func(tx Querier) error {
+				_, err := addStock(context.Background(), tx, Stock{Name: "apple", Amount: 5}, MovementMeta{}, time.Now())
+				return err
+			},
+		},
+		{
+			name: "ロケーションの在庫数の書き込みはON CONFLICTで行う",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO stock_levels \\(name, location, amount, updated_at\\) VALUES \\(\\$1, \\$2, \\$3, \\$4\\) ON CONFLICT \\(name, location\\) DO UPDATE SET amount = \\$5, updated_at = \\$6$").
+					WithArgs("apple", "tokyo", 3, sqlmock.AnyArg(), 3, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			run: func(tx Querier) error {
+				return writeStockLevel(context.Background(), tx, "apple", "tokyo", 3, time.Now())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			mock.ExpectBegin()
+			tc.mockSetup(mock)
+			mock.ExpectCommit()
+
+			sqlDB := &SQLDB{DB: db, Driver: driverPostgres}
+			assert.NoError(t, sqlDB.WithTx(context.Background(), tc.run))
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
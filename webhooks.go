@@ -0,0 +1,697 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Webhook で配信する在庫イベントの種類
+const (
+	eventStockChanged  = "stock.changed"
+	eventStockDeleted  = "stock.deleted"
+	eventStockRestored = "stock.restored"
+)
+
+var webhookEventTypes = []string{eventStockChanged, eventStockDeleted, eventStockRestored}
+
+// Webhook の配信結果
+const (
+	deliveryPending   = "pending"
+	deliverySucceeded = "succeeded"
+	deliveryFailed    = "failed"
+)
+
+const (
+	// webhookSignatureHeader はリクエストボディの HMAC-SHA256 署名を送るヘッダーです。
+	webhookSignatureHeader = "X-Webhook-Signature"
+
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 200
+	// webhookDeliveryBatchSize は deliverBatch が一度に確保して送信する配信ログの件数です。
+	webhookDeliveryBatchSize = 20
+	// webhookDeliveryLease は確保した配信ログを他のジョブが送信しない期間です。
+	// 1 バッチをすべてタイムアウトまで待っても切れないよう、件数 × HTTP クライアントのタイムアウトより長くします。
+	webhookDeliveryLease = 5 * time.Minute
+)
+
+var errWebhookNotFound = errors.New("webhook not found")
+
+// Webhook は在庫イベントの配信先です。
+// Secret は署名に使う共有鍵で、登録時のレスポンスでのみ返します。
+type Webhook struct {
+	ID         int64     `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Secret     string    `json:"secret,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// WebhookDelivery は Webhook の配信ログです。再試行を含めた 1 イベントの配信結果を 1 行に記録します。
+// 配信が終わるまでは Status が pending で、NextAttemptAt に次に送信する日時を記録します。
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	WebhookID      int64           `json:"webhook_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus *int            `json:"response_status,omitempty"`
+	Error          string          `json:"error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+}
+
+// WebhookDeliveryPage は GET /webhooks/:id/deliveries のレスポンスです。
+type WebhookDeliveryPage struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+// StockEvent は Webhook で配信する在庫イベントで、在庫移動 1 件に対応します。
+type StockEvent struct {
+	ID         string         `json:"id"`
+	Type       string         `json:"type"`
+	OccurredAt time.Time      `json:"occurred_at"`
+	Data       StockEventData `json:"data"`
+}
+
+// StockEventData は在庫イベントの内容です。
+type StockEventData struct {
+	Name      string `json:"name"`
+	Location  string `json:"location"`
+	Delta     int    `json:"delta"`
+	Amount    int    `json:"amount"`
+	Reason    string `json:"reason"`
+	Actor     string `json:"actor"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// newStockEvent は在庫移動から在庫イベントを作成します。
+func newStockEvent(name, location string, delta, amountAfter int, meta MovementMeta, now time.Time) StockEvent {
+	eventType := eventStockChanged
+	switch meta.Reason {
+	case movementDelete:
+		eventType = eventStockDeleted
+	case movementRestore:
+		eventType = eventStockRestored
+	}
+	return StockEvent{
+		ID:         uuid.NewString(),
+		Type:       eventType,
+		OccurredAt: now,
+		Data: StockEventData{
+			Name:      name,
+			Location:  location,
+			Delta:     delta,
+			Amount:    amountAfter,
+			Reason:    meta.Reason,
+			Actor:     meta.Actor,
+			RequestID: meta.RequestID,
+		},
+	}
+}
+
+// enqueueWebhookDeliveries は在庫イベントを購読している Webhook ごとに、配信待ちの配信ログを記録します。
+// 在庫を変更したトランザクション内で呼び出すため、コミットされた変更のイベントだけが漏れなく配信待ちになります。
+// 配信は定期実行ジョブの deliver_webhooks が行うため、レスポンスは Webhook の応答を待ちません。
+func enqueueWebhookDeliveries(ctx context.Context, tx Querier, event StockEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	// event_types はカンマ区切りのため、先頭・途中・末尾・単独のいずれかで一致する Webhook を選ぶ
+	_, err = tx.ExecContext(ctx, "INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload, status, attempts, error, created_at, next_attempt_at) "+
+		"SELECT id, ?, ?, ?, ?, 0, '', ?, ? FROM webhooks WHERE event_types = ? OR event_types LIKE ? OR event_types LIKE ? OR event_types LIKE ?",
+		event.ID, event.Type, string(payload), deliveryPending, event.OccurredAt, event.OccurredAt,
+		event.Type, event.Type+",%", "%,"+event.Type, "%,"+event.Type+",%")
+	return err
+}
+
+// webhookDispatcher は在庫イベントを購読している Webhook に配信します。
+// 配信は webhook_deliveries を配信待ちのキューとして使い、失敗した配信は指数バックオフで再試行します。
+type webhookDispatcher struct {
+	db          Storer
+	client      *http.Client
+	maxAttempts int
+	// baseDelay は最初の再試行までの待ち時間で、再試行のたびに 2 倍にします（maxDelay まで）。
+	baseDelay time.Duration
+	maxDelay  time.Duration
+}
+
+func newWebhookDispatcher(db Storer) *webhookDispatcher {
+	return &webhookDispatcher{
+		db:          db,
+		client:      newWebhookClient(),
+		maxAttempts: 8,
+		baseDelay:   time.Minute,
+		maxDelay:    time.Hour,
+	}
+}
+
+// newWebhookClient は Webhook の送信に使う HTTP クライアントを返します。
+// 名前解決した後の接続先がプライベート・ループバック・リンクローカルなどのアドレスであれば接続しません。
+// リダイレクトは追わず、3xx の応答は送信の失敗として扱います。
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second, Control: rejectInternalAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// rejectInternalAddress は net.Dialer の Control で、接続先が公開されたアドレスでなければエラーを返します。
+func rejectInternalAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !isPublicAddress(addr) {
+		return fmt.Errorf("webhook address %s is not allowed", addr)
+	}
+	return nil
+}
+
+// isPublicAddress は Webhook の送信先として許可するアドレスであれば true を返します。
+func isPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate()
+}
+
+// DeliverPending は送信日時を過ぎた配信待ちの配信ログがなくなるまで、記録した順に配信します。
+// 配信に失敗した Webhook には、順序を保つためそれ以降の在庫イベントも再試行の日時まで送信しません。
+// 送信後、結果を記録する前に失敗すると、リースが切れた後に同じイベントを再び送信します（at-least-once）。
+func (d *webhookDispatcher) DeliverPending(ctx context.Context) (int, error) {
+	delivered := 0
+	for {
+		n, more, err := d.deliverBatch(ctx, time.Now())
+		delivered += n
+		if err != nil || !more {
+			return delivered, err
+		}
+	}
+}
+
+// deliveryResult は deliverBatch で送信した 1 件の結果です。
+// sent が false の配信ログは、同じ Webhook への送信に失敗したため送信せずに nextAttemptAt まで遅らせます。
+type deliveryResult struct {
+	delivery      WebhookDelivery
+	sent          bool
+	nextAttemptAt *time.Time
+}
+
+// deliverBatch は配信待ちの配信ログを最大 webhookDeliveryBatchSize 件配信し、成功した件数を返します。
+// more は続きの配信ログが残っている可能性がある場合に true です。
+// 送信中にトランザクションとロックを保持しないよう、配信ログの確保・送信・結果の記録を分けて行います。
+func (d *webhookDispatcher) deliverBatch(ctx context.Context, now time.Time) (delivered int, more bool, err error) {
+	batch, err := d.claimDeliveries(ctx, now)
+	if err != nil {
+		return 0, false, err
+	}
+	more = len(batch) == webhookDeliveryBatchSize
+	if len(batch) == 0 {
+		return 0, false, nil
+	}
+
+	webhooks, err := getWebhooks(ctx, d.db, true)
+	if err != nil {
+		return 0, false, err
+	}
+	byID := make(map[int64]Webhook, len(webhooks))
+	for _, webhook := range webhooks {
+		byID[webhook.ID] = webhook
+	}
+
+	results := make([]deliveryResult, 0, len(batch))
+	blockedUntil := make(map[int64]time.Time)
+	for _, delivery := range batch {
+		if until, ok := blockedUntil[delivery.WebhookID]; ok {
+			results = append(results, deliveryResult{delivery: delivery, nextAttemptAt: &until})
+			continue
+		}
+		delivery.Attempts++
+		status, sendErr := d.send(ctx, byID[delivery.WebhookID], delivery)
+		delivery.ResponseStatus = nil
+		if status != 0 {
+			delivery.ResponseStatus = &status
+		}
+
+		result := deliveryResult{sent: true}
+		switch {
+		case sendErr == nil:
+			delivery.Status = deliverySucceeded
+			delivery.Error = ""
+			delivered++
+		case delivery.Attempts >= d.maxAttempts:
+			delivery.Status = deliveryFailed
+			delivery.Error = sendErr.Error()
+		default:
+			delivery.Status = deliveryPending
+			delivery.Error = sendErr.Error()
+			next := now.Add(d.retryDelay(delivery.Attempts))
+			result.nextAttemptAt = &next
+			blockedUntil[delivery.WebhookID] = next
+		}
+		result.delivery = delivery
+		results = append(results, result)
+	}
+
+	err = d.db.WithTx(ctx, func(tx Querier) error {
+		for _, result := range results {
+			if !result.sent {
+				if _, err := tx.ExecContext(ctx, "UPDATE webhook_deliveries SET next_attempt_at = ? WHERE id = ?", *result.nextAttemptAt, result.delivery.ID); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := updateDelivery(ctx, tx, result.delivery, result.nextAttemptAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	return delivered, more, nil
+}
+
+// claimDeliveries は送信日時を過ぎた配信待ちの配信ログを記録した順に確保し、コミットします。
+// 確保した配信ログは next_attempt_at を webhookDeliveryLease 後にずらすため、送信中に他のジョブが同じイベントを送信しません。
+func (d *webhookDispatcher) claimDeliveries(ctx context.Context, now time.Time) ([]WebhookDelivery, error) {
+	var batch []WebhookDelivery
+	err := d.db.WithTx(ctx, func(tx Querier) error {
+		batch = nil
+		// 他のジョブがロックしている配信ログは飛ばし、同じイベントを同時に確保しない
+		rows, err := tx.QueryContext(ctx, "SELECT id, webhook_id, event_id, event_type, payload, attempts FROM webhook_deliveries WHERE status = ? AND next_attempt_at <= ? ORDER BY id LIMIT ? FOR UPDATE SKIP LOCKED",
+			deliveryPending, now, webhookDeliveryBatchSize)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var (
+				delivery WebhookDelivery
+				payload  string
+			)
+			if err := rows.Scan(&delivery.ID, &delivery.WebhookID, &delivery.EventID, &delivery.EventType, &payload, &delivery.Attempts); err != nil {
+				rows.Close()
+				return err
+			}
+			delivery.Payload = json.RawMessage(payload)
+			batch = append(batch, delivery)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		placeholders := make([]string, len(batch))
+		args := []interface{}{now.Add(webhookDeliveryLease)}
+		for i, delivery := range batch {
+			placeholders[i] = "?"
+			args = append(args, delivery.ID)
+		}
+		_, err = tx.ExecContext(ctx, "UPDATE webhook_deliveries SET next_attempt_at = ? WHERE id IN ("+strings.Join(placeholders, ", ")+")", args...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// retryDelay は attempts 回送信した後、次に送信するまでの待ち時間を返します。
+func (d *webhookDispatcher) retryDelay(attempts int) time.Duration {
+	delay := d.baseDelay
+	for i := 1; i < attempts && delay < d.maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, d.maxDelay)
+}
+
+// send は署名した在庫イベントを 1 回送信し、応答のステータスコードを返します。
+// 再試行でも記録した時点のペイロードを送るため、署名も同じになります。
+func (d *webhookDispatcher) send(ctx context.Context, webhook Webhook, delivery WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", delivery.EventType)
+	req.Header.Set("X-Webhook-Delivery", delivery.EventID)
+	req.Header.Set(webhookSignatureHeader, signPayload(webhook.Secret, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// signPayload はリクエストボディの HMAC-SHA256 署名を "sha256=" に続く 16 進数で返します。
+// 受信側は同じ共有鍵で計算した署名と、X-Webhook-Signature ヘッダーの値を比較して検証します。
+func signPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// createWebhookHandler は POST /webhooks のリクエストを処理します。
+// secret を省略した場合は生成し、レスポンスでのみ返します。
+func createWebhookHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var webhook Webhook
+		if err := c.ShouldBindJSON(&webhook); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+		if err := validateWebhook(webhook); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if webhook.Secret == "" {
+			secret, err := generateWebhookSecret()
+			if err != nil {
+				writeServerError(c, err)
+				return
+			}
+			webhook.Secret = secret
+		}
+
+		webhook.CreatedAt = time.Now()
+		var err error
+		webhook.ID, err = insertReturningID(ctx, db, "INSERT INTO webhooks (url, event_types, secret, created_at) VALUES (?, ?, ?, ?)",
+			webhook.URL, strings.Join(webhook.EventTypes, ","), webhook.Secret, webhook.CreatedAt)
+		if err != nil {
+			writeServerError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, webhook)
+	}
+}
+
+// getWebhooksHandler は GET /webhooks のリクエストを処理します。
+func getWebhooksHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		webhooks, err := getWebhooks(ctx, db, false)
+		if err != nil {
+			writeServerError(c, err)
+			return
+		}
+		if webhooks == nil {
+			webhooks = []Webhook{}
+		}
+		c.JSON(http.StatusOK, gin.H{"webhooks": webhooks})
+	}
+}
+
+// getWebhookHandler は GET /webhooks/:id のリクエストを処理します。
+func getWebhookHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		id, ok := parseWebhookID(c)
+		if !ok {
+			return
+		}
+		webhook, err := getWebhook(ctx, db, id)
+		if err != nil {
+			writeWebhookError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, webhook)
+	}
+}
+
+// deleteWebhookHandler は DELETE /webhooks/:id のリクエストを処理します。配信ログも削除します。
+func deleteWebhookHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		id, ok := parseWebhookID(c)
+		if !ok {
+			return
+		}
+		err := db.WithTx(ctx, func(tx Querier) error {
+			if _, err := tx.ExecContext(ctx, "DELETE FROM webhook_deliveries WHERE webhook_id = ?", id); err != nil {
+				return err
+			}
+			result, err := tx.ExecContext(ctx, "DELETE FROM webhooks WHERE id = ?", id)
+			if err != nil {
+				return err
+			}
+			if affected, err := result.RowsAffected(); err != nil {
+				return err
+			} else if affected == 0 {
+				return errWebhookNotFound
+			}
+			return nil
+		})
+		if err != nil {
+			writeWebhookError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// getWebhookDeliveriesHandler は GET /webhooks/:id/deliveries のリクエストを処理します。
+// 配信ログを新しい順に返します。
+func getWebhookDeliveriesHandler(db Storer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		id, ok := parseWebhookID(c)
+		if !ok {
+			return
+		}
+		limit, err := parseLimit(c, defaultDeliveryLimit, maxDeliveryLimit)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var beforeID int64
+		if v := c.Query("cursor"); v != "" {
+			key, err := decodeCursor(v)
+			if err == nil {
+				beforeID, err = strconv.ParseInt(key, 10, 64)
+			}
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": errInvalidCursor.Error()})
+				return
+			}
+		}
+
+		if _, err := getWebhook(ctx, db, id); err != nil {
+			writeWebhookError(c, err)
+			return
+		}
+		deliveries, err := getWebhookDeliveries(ctx, db, id, beforeID, limit+1)
+		if err != nil {
+			writeServerError(c, err)
+			return
+		}
+
+		page := WebhookDeliveryPage{Deliveries: deliveries}
+		if len(deliveries) > limit {
+			page.Deliveries = deliveries[:limit]
+			page.NextCursor = encodeCursor(strconv.FormatInt(deliveries[limit-1].ID, 10))
+		}
+		if page.Deliveries == nil {
+			page.Deliveries = []WebhookDelivery{}
+		}
+		c.JSON(http.StatusOK, page)
+	}
+}
+
+func parseWebhookID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+func writeWebhookError(c *gin.Context, err error) {
+	if errors.Is(err, errWebhookNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	writeServerError(c, err)
+}
+
+// validateWebhook は Webhook の登録内容を検証します。
+func validateWebhook(webhook Webhook) error {
+	u, err := url.Parse(webhook.URL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return errors.New("URL must be an absolute https URL")
+	}
+	// ホスト名のアドレスは送信時に名前解決してから検証する
+	if addr, err := netip.ParseAddr(u.Hostname()); err == nil && !isPublicAddress(addr) {
+		return errors.New("URL must not point to a private, loopback or link-local address")
+	}
+	if len(webhook.EventTypes) == 0 {
+		return errors.New("At least one event type is required")
+	}
+	for _, t := range webhook.EventTypes {
+		known := false
+		for _, k := range webhookEventTypes {
+			if t == k {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("Unknown event type: %s", t)
+		}
+	}
+	return nil
+}
+
+// generateWebhookSecret は 32 バイトのランダムな共有鍵を 16 進数で返します。
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// getWebhook は ID で Webhook を取得します。共有鍵は返しません。
+func getWebhook(ctx context.Context, db Querier, id int64) (Webhook, error) {
+	var (
+		webhook    Webhook
+		eventTypes string
+	)
+	err := db.QueryRowContext(ctx, "SELECT id, url, event_types, created_at FROM webhooks WHERE id = ?", id).
+		Scan(&webhook.ID, &webhook.URL, &eventTypes, &webhook.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Webhook{}, errWebhookNotFound
+	}
+	if err != nil {
+		return Webhook{}, err
+	}
+	webhook.EventTypes = strings.Split(eventTypes, ",")
+	return webhook, nil
+}
+
+// getWebhooks は Webhook を ID 順に取得します。withSecret が true の場合は配信に使う共有鍵も読み込みます。
+func getWebhooks(ctx context.Context, db Querier, withSecret bool) ([]Webhook, error) {
+	rows, err := db.QueryContext(ctx, "SELECT id, url, event_types, secret, created_at FROM webhooks ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []Webhook
+	for rows.Next() {
+		var (
+			webhook    Webhook
+			eventTypes string
+		)
+		if err := rows.Scan(&webhook.ID, &webhook.URL, &eventTypes, &webhook.Secret, &webhook.CreatedAt); err != nil {
+			return nil, err
+		}
+		webhook.EventTypes = strings.Split(eventTypes, ",")
+		if !withSecret {
+			webhook.Secret = ""
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+// updateDelivery は送信した結果を配信ログに記録します。
+// nextAttemptAt が nil でない場合は再試行の日時として記録し、同じ Webhook のそれ以降の配信待ちもその日時まで遅らせます。
+func updateDelivery(ctx context.Context, tx Querier, delivery WebhookDelivery, nextAttemptAt *time.Time) error {
+	var responseStatus sql.NullInt64
+	if delivery.ResponseStatus != nil {
+		responseStatus = sql.NullInt64{Int64: int64(*delivery.ResponseStatus), Valid: true}
+	}
+	_, err := tx.ExecContext(ctx, "UPDATE webhook_deliveries SET status = ?, attempts = ?, response_status = ?, error = ?, next_attempt_at = ? WHERE id = ?",
+		delivery.Status, delivery.Attempts, responseStatus, delivery.Error, nextAttemptAt, delivery.ID)
+	if err != nil || nextAttemptAt == nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE webhook_deliveries SET next_attempt_at = ? WHERE webhook_id = ? AND status = ? AND id > ? AND next_attempt_at < ?",
+		*nextAttemptAt, delivery.WebhookID, deliveryPending, delivery.ID, *nextAttemptAt)
+	return err
+}
+
+// getWebhookDeliveries は Webhook の配信ログを新しい順に取得します。beforeID が 0 より大きい場合は、その ID より前の配信ログのみを返します。
+func getWebhookDeliveries(ctx context.Context, db Querier, webhookID, beforeID int64, limit int) ([]WebhookDelivery, error) {
+	query := "SELECT id, webhook_id, event_id, event_type, payload, status, attempts, response_status, error, created_at, next_attempt_at FROM webhook_deliveries WHERE webhook_id = ?"
+	args := []interface{}{webhookID}
+	if beforeID > 0 {
+		query += " AND id < ?"
+		args = append(args, beforeID)
+	}
+	query += " ORDER BY id DESC LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []WebhookDelivery
+	for rows.Next() {
+		var (
+			delivery       WebhookDelivery
+			payload        string
+			responseStatus sql.NullInt64
+			nextAttemptAt  sql.NullTime
+		)
+		if err := rows.Scan(&delivery.ID, &delivery.WebhookID, &delivery.EventID, &delivery.EventType, &payload,
+			&delivery.Status, &delivery.Attempts, &responseStatus, &delivery.Error, &delivery.CreatedAt, &nextAttemptAt); err != nil {
+			return nil, err
+		}
+		delivery.Payload = json.RawMessage(payload)
+		delivery.ResponseStatus = nullIntPtr(responseStatus)
+		if nextAttemptAt.Valid {
+			delivery.NextAttemptAt = &nextAttemptAt.Time
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
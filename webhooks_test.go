@@ -0,0 +1,427 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var webhookColumnNames = []string{"id", "url", "event_types", "secret", "created_at"}
+
+func TestSignPayload(t *testing.T) {
+	assert.Equal(t, "sha256=6146142a2ce0159e84c0767881e4ec80bc397da62526e7d19f70795eb79460c0", signPayload("secret", []byte(`{"id":"1"}`)))
+}
+
+func TestWebhookHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		method       string
+		path         string
+		requestBody  string
+		mockSetup    func(mock sqlmock.Sqlmock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:        "Webhookを登録すると共有鍵を返す",
+			method:      http.MethodPost,
+			path:        "/v1/webhooks",
+			requestBody: `{"url":"https://pos.example.com/hooks/stock","event_types":["stock.changed","stock.deleted"],"secret":"s3cret"}`,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO webhooks").
+					WithArgs("https://pos.example.com/hooks/stock", "stock.changed,stock.deleted", "s3cret", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(4, 1))
+			},
+			expectedCode: http.StatusCreated,
+			expectedBody: `{"id":4,"url":"https://pos.example.com/hooks/stock","event_types":["stock.changed","stock.deleted"],"secret":"s3cret"`,
+		},
+		{
+			name:         "URLが不正な場合は400",
+			method:       http.MethodPost,
+			path:         "/v1/webhooks",
+			requestBody:  `{"url":"pos.example.com/hooks","event_types":["stock.changed"]}`,
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "URL must be an absolute https URL",
+		},
+		{
+			name:         "httpのURLは400",
+			method:       http.MethodPost,
+			path:         "/v1/webhooks",
+			requestBody:  `{"url":"http://pos.example.com/hooks","event_types":["stock.changed"]}`,
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "URL must be an absolute https URL",
+		},
+		{
+			name:         "リンクローカルのアドレスは400",
+			method:       http.MethodPost,
+			path:         "/v1/webhooks",
+			requestBody:  `{"url":"https://169.254.169.254/latest/meta-data","event_types":["stock.changed"]}`,
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "URL must not point to a private, loopback or link-local address",
+		},
+		{
+			name:         "未知のイベントは400",
+			method:       http.MethodPost,
+			path:         "/v1/webhooks",
+			requestBody:  `{"url":"https://pos.example.com/hooks","event_types":["order.created"]}`,
+			mockSetup:    func(mock sqlmock.Sqlmock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "Unknown event type: order.created",
+		},
+		{
+			name:   "一覧では共有鍵を返さない",
+			method: http.MethodGet,
+			path:   "/v1/webhooks",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, url, event_types, secret, created_at FROM webhooks ORDER BY id").
+					WillReturnRows(sqlmock.NewRows(webhookColumnNames).
+						AddRow(4, "https://pos.example.com/hooks/stock", "stock.changed", "s3cret", now))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"webhooks":[{"id":4,"url":"https://pos.example.com/hooks/stock","event_types":["stock.changed"],"created_at":"2026-01-02T03:04:05Z"}]}`,
+		},
+		{
+			name:   "存在しないWebhookは削除できない",
+			method: http.MethodDelete,
+			path:   "/v1/webhooks/9",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM webhook_deliveries WHERE webhook_id = \\?").
+					WithArgs(int64(9)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("DELETE FROM webhooks WHERE id = \\?").
+					WithArgs(int64(9)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "webhook not found",
+		},
+		{
+			name:   "配信ログを新しい順に返す",
+			method: http.MethodGet,
+			path:   "/v1/webhooks/4/deliveries?limit=1",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, url, event_types, created_at FROM webhooks WHERE id = \\?").
+					WithArgs(int64(4)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "url", "event_types", "created_at"}).
+						AddRow(4, "https://pos.example.com/hooks/stock", "stock.changed", now))
+				mock.ExpectQuery("SELECT (.+) FROM webhook_deliveries WHERE webhook_id = \\? ORDER BY id DESC LIMIT \\?").
+					WithArgs(int64(4), 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "webhook_id", "event_id", "event_type", "payload", "status", "attempts", "response_status", "error", "created_at", "next_attempt_at"}).
+						AddRow(12, 4, "e-2", "stock.changed", `{"id":"e-2"}`, "pending", 2, 503, "unexpected status 503", now, now.Add(2*time.Minute)).
+						AddRow(11, 4, "e-1", "stock.changed", `{"id":"e-1"}`, "succeeded", 1, 200, "", now, nil))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"deliveries":[{"id":12,"webhook_id":4,"event_id":"e-2","event_type":"stock.changed","payload":{"id":"e-2"},"status":"pending","attempts":2,"response_status":503,"error":"unexpected status 503","created_at":"2026-01-02T03:04:05Z","next_attempt_at":"2026-01-02T03:06:05Z"}],"next_cursor":"` + encodeCursor("12") + `"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			router := gin.New()
+			require.NoError(t, setupRoutes(router, &SQLDB{DB: db}))
+
+			req, _ := http.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			responseBody := w.Body.String()
+			t.Logf("テストケース: %s", tc.name)
+			t.Logf("レスポンスボディ: %s", responseBody)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Contains(t, responseBody, tc.expectedBody)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
+
+// webhookReceiver は Webhook の受信側で、受信したリクエストを記録し、statuses の順に応答します。
+type webhookReceiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	status := http.StatusOK
+	if len(r.requests) < len(r.statuses) {
+		status = r.statuses[len(r.requests)]
+	}
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	w.WriteHeader(status)
+}
+
+func TestWebhookEnqueue(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("在庫を変更したトランザクションで購読しているWebhookの配信待ちとして記録する", func(t *testing.T) {
+		db, mock := NewMockDB(t)
+		defer db.Close()
+		mock.ExpectBegin()
+		expectLockStock(mock, "apple", 10, 0)
+		mock.ExpectExec("UPDATE stocks SET amount = \\?, version = version \\+ 1 WHERE name = \\?").
+			WithArgs(7, "apple").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO stock_movements").
+			WithArgs("apple", defaultLocation, -3, 7, "damage", "pos-01", sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO outbox_events").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		payload := &payloadArg{}
+		mock.ExpectExec("INSERT INTO webhook_deliveries \\(webhook_id, event_id, event_type, payload, status, attempts, error, created_at, next_attempt_at\\) "+
+			"SELECT id, \\?, \\?, \\?, \\?, 0, '', \\?, \\? FROM webhooks WHERE event_types = \\? OR event_types LIKE \\? OR event_types LIKE \\? OR event_types LIKE \\?").
+			WithArgs(sqlmock.AnyArg(), eventStockChanged, payload, deliveryPending, sqlmock.AnyArg(), sqlmock.AnyArg(),
+				eventStockChanged, eventStockChanged+",%", "%,"+eventStockChanged, "%,"+eventStockChanged+",%").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		expectThresholds(mock, "apple", nil, nil)
+		mock.ExpectCommit()
+
+		store := &SQLDB{DB: db}
+		router := gin.New()
+		require.NoError(t, setupRoutes(router, store))
+
+		req, _ := http.NewRequest(http.MethodPatch, "/v1/stocks/apple", bytes.NewBufferString(`{"delta":-3,"reason":"damage"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Actor", "pos-01")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var event StockEvent
+		assert.NoError(t, json.Unmarshal([]byte(payload.value), &event))
+		assert.Equal(t, eventStockChanged, event.Type)
+		assert.Equal(t, StockEventData{Name: "apple", Location: defaultLocation, Delta: -3, Amount: 7, Reason: "damage", Actor: "pos-01"}, event.Data)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("未処理の期待値があります: %s", err)
+		}
+	})
+
+	t.Run("ロールバックされた変更は記録しない", func(t *testing.T) {
+		db, mock := NewMockDB(t)
+		defer db.Close()
+		mock.ExpectBegin()
+		expectLockStock(mock, "apple", 10, 0)
+		mock.ExpectRollback()
+
+		store := &SQLDB{DB: db}
+		router := gin.New()
+		require.NoError(t, setupRoutes(router, store))
+
+		req, _ := http.NewRequest(http.MethodPatch, "/v1/stocks/apple", bytes.NewBufferString(`{"delta":-11,"reason":"loss"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("未処理の期待値があります: %s", err)
+		}
+	})
+}
+
+// payloadArg は sqlmock の引数として受け取った文字列を保存します。
+type payloadArg struct {
+	value string
+}
+
+func (a *payloadArg) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	a.value = s
+	return ok
+}
+
+func TestWebhookDeliverPending(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	payload := `{"id":"e-1","type":"stock.changed","occurred_at":"2026-01-02T03:04:05Z","data":{"name":"apple","location":"default","delta":-3,"amount":7,"reason":"damage","actor":"pos-01"}}`
+	deliveryColumns := []string{"id", "webhook_id", "event_id", "event_type", "payload", "attempts"}
+
+	testCases := []struct {
+		name          string
+		statuses      []int
+		deliveries    [][]driver.Value
+		mockUpdates   func(mock sqlmock.Sqlmock)
+		expectedTries int
+		expectedCount int
+	}{
+		{
+			name:       "配信待ちのイベントを署名して送信する",
+			deliveries: [][]driver.Value{{11, 4, "e-1", eventStockChanged, payload, 0}},
+			mockUpdates: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE webhook_deliveries SET status = \\?, attempts = \\?, response_status = \\?, error = \\?, next_attempt_at = \\? WHERE id = \\?").
+					WithArgs(deliverySucceeded, 1, int64(200), "", nil, int64(11)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedTries: 1,
+			expectedCount: 1,
+		},
+		{
+			name:       "失敗した場合は再試行の日時を記録し、同じWebhookの後続のイベントも遅らせる",
+			statuses:   []int{503},
+			deliveries: [][]driver.Value{{11, 4, "e-1", eventStockChanged, payload, 0}, {12, 4, "e-2", eventStockChanged, payload, 0}},
+			mockUpdates: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE webhook_deliveries SET status = \\?, attempts = \\?, response_status = \\?, error = \\?, next_attempt_at = \\? WHERE id = \\?").
+					WithArgs(deliveryPending, 1, int64(503), "unexpected status 503", sqlmock.AnyArg(), int64(11)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE webhook_deliveries SET next_attempt_at = \\? WHERE webhook_id = \\? AND status = \\? AND id > \\? AND next_attempt_at < \\?").
+					WithArgs(sqlmock.AnyArg(), int64(4), deliveryPending, int64(11), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				// 送信しなかった後続のイベントはリースを解き、再試行の日時まで遅らせる
+				mock.ExpectExec("UPDATE webhook_deliveries SET next_attempt_at = \\? WHERE id = \\?").
+					WithArgs(now.Add(time.Minute), int64(12)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedTries: 1,
+		},
+		{
+			name:       "再試行の上限に達すると失敗として記録する",
+			statuses:   []int{500},
+			deliveries: [][]driver.Value{{11, 4, "e-1", eventStockChanged, payload, 2}},
+			mockUpdates: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE webhook_deliveries SET status = \\?, attempts = \\?, response_status = \\?, error = \\?, next_attempt_at = \\? WHERE id = \\?").
+					WithArgs(deliveryFailed, 3, int64(500), "unexpected status 500", nil, int64(11)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedTries: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			receiver := &webhookReceiver{statuses: tc.statuses}
+			server := httptest.NewTLSServer(receiver)
+			defer server.Close()
+
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			rows := sqlmock.NewRows(deliveryColumns)
+			for _, d := range tc.deliveries {
+				rows.AddRow(d...)
+			}
+			// 配信ログを確保してコミットし、送信はトランザクションの外で行う
+			ids := []driver.Value{now.Add(webhookDeliveryLease)}
+			for _, d := range tc.deliveries {
+				ids = append(ids, d[0])
+			}
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT id, webhook_id, event_id, event_type, payload, attempts FROM webhook_deliveries WHERE status = \\? AND next_attempt_at <= \\? ORDER BY id LIMIT \\? FOR UPDATE SKIP LOCKED").
+				WithArgs(deliveryPending, now, webhookDeliveryBatchSize).
+				WillReturnRows(rows)
+			mock.ExpectExec("UPDATE webhook_deliveries SET next_attempt_at = \\? WHERE id IN \\(\\?(, \\?)*\\)").
+				WithArgs(ids...).
+				WillReturnResult(sqlmock.NewResult(0, int64(len(tc.deliveries))))
+			mock.ExpectCommit()
+			mock.ExpectQuery("SELECT id, url, event_types, secret, created_at FROM webhooks ORDER BY id").
+				WillReturnRows(sqlmock.NewRows(webhookColumnNames).
+					AddRow(4, server.URL, "stock.changed", "s3cret", now))
+			mock.ExpectBegin()
+			tc.mockUpdates(mock)
+			mock.ExpectCommit()
+
+			dispatcher := newWebhookDispatcher(&SQLDB{DB: db})
+			// テスト用のサーバーはループバックで待ち受けるため、証明書を信頼するクライアントに差し替える
+			dispatcher.client = server.Client()
+			dispatcher.maxAttempts = 3
+			delivered, more, err := dispatcher.deliverBatch(context.Background(), now)
+			assert.NoError(t, err)
+			assert.False(t, more)
+			assert.Equal(t, tc.expectedCount, delivered)
+
+			// 記録したペイロードをそのまま署名して送信する
+			if assert.Len(t, receiver.requests, tc.expectedTries) {
+				r := receiver.requests[0]
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.Equal(t, eventStockChanged, r.Header.Get("X-Webhook-Event"))
+				assert.Equal(t, "e-1", r.Header.Get("X-Webhook-Delivery"))
+				assert.Equal(t, payload, string(receiver.bodies[0]))
+				assert.Equal(t, signPayload("s3cret", receiver.bodies[0]), r.Header.Get(webhookSignatureHeader))
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}
+
+func TestWebhookClientRejectsInternalAddress(t *testing.T) {
+	receiver := &webhookReceiver{}
+	server := httptest.NewTLSServer(receiver)
+	defer server.Close()
+
+	// 名前解決した後の接続先がループバックのため、接続せずに失敗する
+	d := newWebhookDispatcher(nil)
+	status, err := d.send(context.Background(), Webhook{URL: server.URL}, WebhookDelivery{Payload: json.RawMessage(`{}`)})
+	assert.Equal(t, 0, status)
+	assert.ErrorContains(t, err, "is not allowed")
+	assert.Empty(t, receiver.requests)
+}
+
+func TestIsPublicAddress(t *testing.T) {
+	testCases := []struct {
+		addr     string
+		expected bool
+	}{
+		{"203.0.113.10", true},
+		{"2001:db8::1", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.0.0.1", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"0.0.0.0", false},
+		{"224.0.0.1", false},
+		{"::ffff:127.0.0.1", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.addr, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isPublicAddress(netip.MustParseAddr(tc.addr)))
+		})
+	}
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	d := newWebhookDispatcher(nil)
+	assert.Equal(t, time.Minute, d.retryDelay(1))
+	assert.Equal(t, 2*time.Minute, d.retryDelay(2))
+	assert.Equal(t, 32*time.Minute, d.retryDelay(6))
+	assert.Equal(t, time.Hour, d.retryDelay(7))
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+// 定期実行ジョブの名前
+const (
+	jobSweepReservations = "sweep_reservations"
+	jobDeliverWebhooks   = "deliver_webhooks"
+	jobRelayOutbox       = "relay_outbox"
+)
+
+// ScheduledJob は EventBridge のスケジュールから StockWorkerFunction に渡される入力です。
+type ScheduledJob struct {
+	Job string `json:"job"`
+}
+
+// WorkerHandler は定期実行ジョブを処理する Lambda ハンドラーです。
+// API のリクエストを受けずに動く処理は、LAMBDA_HANDLER=worker の関数でこのハンドラーから実行します。
+func WorkerHandler(ctx context.Context, job ScheduledJob) error {
+	db, err := connectDB()
+	if err != nil {
+		// 在庫を DynamoDB に保存している場合、outbox は DynamoDB にあるため SQL のデータベースがなくても配信できる
+		if job.Job != jobRelayOutbox || os.Getenv("STOCK_STORE") != "dynamodb" {
+			return err
+		}
+		log.Printf("Warning: Failed to connect to database: %v", err)
+	}
+	return runScheduledJob(ctx, db, job.Job)
+}
+
+// runScheduledJob は名前を指定して定期実行ジョブを実行します。
+func runScheduledJob(ctx context.Context, db Storer, job string) error {
+	switch job {
+	case jobSweepReservations:
+		// 引当可能数の計算では期限切れの予約は常に除外されるため、ステータスを expired に揃えるだけの掃除
+		swept, err := sweepExpiredReservations(ctx, db, time.Now())
+		if err != nil {
+			return err
+		}
+		log.Printf("Swept %d expired reservations", swept)
+		return nil
+	case jobDeliverWebhooks:
+		delivered, err := newWebhookDispatcher(db).DeliverPending(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("Delivered %d webhook events", delivered)
+		return nil
+	case jobRelayOutbox:
+		publisher, err := outboxPublisherFromEnv(ctx)
+		if err != nil {
+			return err
+		}
+		if publisher == nil {
+			return errors.New("OUTBOX_PUBLISHER is not set")
+		}
+		stocks, err := stockRepositoryFromEnv(db)
+		if err != nil {
+			return err
+		}
+		published, err := relayOutbox(ctx, outboxFor(db, stocks), publisher)
+		log.Printf("Published %d outbox events", published)
+		return err
+	default:
+		return fmt.Errorf("unknown scheduled job: %s", job)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunScheduledJob(t *testing.T) {
+	testCases := []struct {
+		name        string
+		job         string
+		env         map[string]string
+		mockSetup   func(mock sqlmock.Sqlmock)
+		expectedErr string
+	}{
+		{
+			name: "期限切れの予約を掃除する",
+			job:  jobSweepReservations,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE stock_reservations SET status = \\? WHERE status = 'active' AND expires_at <= \\?").
+					WithArgs("expired", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			name: "配信待ちのWebhookを配信する",
+			job:  jobDeliverWebhooks,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id, webhook_id, event_id, event_type, payload, attempts FROM webhook_deliveries WHERE status = \\? AND next_attempt_at <= \\?").
+					WithArgs(deliveryPending, sqlmock.AnyArg(), webhookDeliveryBatchSize).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "webhook_id", "event_id", "event_type", "payload", "attempts"}))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "outboxのイベントを配信する",
+			job:  jobRelayOutbox,
+			env:  map[string]string{"OUTBOX_PUBLISHER": "memory", "TEST_MODE": "true"},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id, payload FROM outbox_events WHERE published_at IS NULL").
+					WithArgs(outboxBatchSize).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "payload"}))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:        "outboxの配信先が設定されていない場合はエラー",
+			job:         jobRelayOutbox,
+			env:         map[string]string{"OUTBOX_PUBLISHER": ""},
+			mockSetup:   func(mock sqlmock.Sqlmock) {},
+			expectedErr: "OUTBOX_PUBLISHER is not set",
+		},
+		{
+			name:        "未知のジョブはエラー",
+			job:         "unknown",
+			mockSetup:   func(mock sqlmock.Sqlmock) {},
+			expectedErr: "unknown scheduled job: unknown",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+			db, mock := NewMockDB(t)
+			defer db.Close()
+			tc.mockSetup(mock)
+
+			err := runScheduledJob(context.Background(), &SQLDB{DB: db}, tc.job)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("未処理の期待値があります: %s", err)
+			}
+		})
+	}
+}